@@ -0,0 +1,66 @@
+package emitter
+
+import "context"
+
+// Adapt turns a typed handler into a Listener. The listener passes the event's context
+// and its payload converted to T to fn, and returns fn's error. Events whose payload is
+// not a T, or is nil, are not passed to fn; the listener returns an error wrapping
+// ErrPayloadTypeMismatch or ErrNilPayload instead:
+//
+//	e.On("order.created", emitter.Adapt(func(ctx context.Context, order *Order) error {
+//		return store.Save(ctx, order)
+//	}))
+func Adapt[T any](fn func(ctx context.Context, payload T) error) Listener {
+	return func(evt Event) error {
+		payload, err := payloadOf[T](evt)
+		if err != nil {
+			return err
+		}
+		return fn(eventContext(evt), payload)
+	}
+}
+
+// AdaptNoError turns a typed handler that cannot fail into a Listener. Payloads are
+// converted as by Adapt.
+func AdaptNoError[T any](fn func(payload T)) Listener {
+	return func(evt Event) error {
+		payload, err := payloadOf[T](evt)
+		if err != nil {
+			return err
+		}
+		fn(payload)
+		return nil
+	}
+}
+
+// OnTyped subscribes a typed handler to a topic of e. The handler receives the event
+// along with its payload converted to T, so listeners no longer start with a type
+// assertion that panics on mismatch. Events whose payload is not a T, or is nil, are
+// not passed to fn: an error wrapping ErrPayloadTypeMismatch or ErrNilPayload is
+// reported to the emitter's error handler instead, like any listener error:
+//
+//	emitter.OnTyped(e, "user.created", func(evt emitter.Event, name string) error {
+//		return greet(name)
+//	})
+func OnTyped[T any](e Emitter, topicName string, fn func(evt Event, payload T) error, opts ...ListenerOption) (string, error) {
+	if fn == nil {
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: ErrNilListener}
+	}
+	return e.On(topicName, func(evt Event) error {
+		payload, err := payloadOf[T](evt)
+		if err != nil {
+			return err
+		}
+		return fn(evt, payload)
+	}, opts...)
+}
+
+// eventContext returns the context of evt, or context.Background() if it has none.
+func eventContext(evt Event) context.Context {
+	if c, ok := evt.(interface{ Context() context.Context }); ok {
+		if ctx := c.Context(); ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
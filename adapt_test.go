@@ -0,0 +1,82 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type adaptKey struct{}
+
+func TestAdapt(t *testing.T) {
+	errFailed := errors.New("failed")
+	var got string
+	listener := Adapt(func(ctx context.Context, name string) error {
+		got = name + " " + ctx.Value(adaptKey{}).(string)
+		return errFailed
+	})
+
+	evt := NewEvent("user.created").WithPayload("alice").WithContext(context.WithValue(context.Background(), adaptKey{}, "ctx"))
+	if err := listener(evt); !errors.Is(err, errFailed) {
+		t.Errorf("listener() = %v, want %v", err, errFailed)
+	}
+	if got != "alice ctx" {
+		t.Errorf("handler got %q, want %q", got, "alice ctx")
+	}
+
+	if err := listener(NewBaseEvent("user.created", 42)); !errors.Is(err, ErrPayloadTypeMismatch) {
+		t.Errorf("listener() = %v, want %v", err, ErrPayloadTypeMismatch)
+	}
+	if err := listener(NewBaseEvent("user.created", nil)); !errors.Is(err, ErrNilPayload) {
+		t.Errorf("listener() = %v, want %v", err, ErrNilPayload)
+	}
+}
+
+func TestAdaptNoError(t *testing.T) {
+	e := NewMemoryEmitter()
+	var total int
+	if _, err := e.On("order.paid", AdaptNoError(func(amount int) { total += amount })); err != nil {
+		t.Fatalf("On() error = %v", err)
+	}
+
+	if errs := e.EmitSync("order.paid", 5); len(errs) != 0 {
+		t.Errorf("EmitSync() errors = %v", errs)
+	}
+	errs := e.EmitSync("order.paid", "5")
+	if len(errs) != 1 || !errors.Is(errs[0], ErrPayloadTypeMismatch) {
+		t.Errorf("EmitSync() errors = %v, want %v", errs, ErrPayloadTypeMismatch)
+	}
+	if total != 5 {
+		t.Errorf("total = %d, want 5", total)
+	}
+}
+
+func TestOnTyped(t *testing.T) {
+	var handled []error
+	e := NewMemoryEmitter(WithErrorHandler(func(evt Event, err error) error {
+		handled = append(handled, err)
+		return nil
+	}))
+	var names []string
+	if _, err := OnTyped(e, "user.*", func(evt Event, name string) error {
+		names = append(names, evt.Topic()+" "+name)
+		return nil
+	}); err != nil {
+		t.Fatalf("OnTyped() error = %v", err)
+	}
+
+	e.EmitSync("user.created", "alice")
+	e.EmitSync("user.created", 42)
+	e.EmitSync("user.deleted", nil)
+
+	if len(names) != 1 || names[0] != "user.created alice" {
+		t.Errorf("handler got %v, want only alice", names)
+	}
+	if len(handled) != 2 || !errors.Is(handled[0], ErrPayloadTypeMismatch) || !errors.Is(handled[1], ErrNilPayload) {
+		t.Errorf("error handler got %v, want a type mismatch and a nil payload", handled)
+	}
+
+	if _, err := OnTyped[string](e, "user.*", nil); !errors.Is(err, ErrNilListener) {
+		t.Errorf("OnTyped(nil) error = %v, want %v", err, ErrNilListener)
+	}
+}
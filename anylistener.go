@@ -0,0 +1,56 @@
+package emitter
+
+// anyTopicName names the listeners added with OnAny in errors and lifecycle events.
+const anyTopicName = "**"
+
+// OnAny subscribes a listener to every event, whatever its topic, for taps such as audit
+// logs or debugging. Unlike a listener on "**", it takes no part in topic matching and
+// does not create a topic: it is notified before the listeners of the matching topics,
+// and its errors name the pattern "**". Listener options apply as with On. It returns
+// the listener ID, which OffAny takes.
+func (m *MemoryEmitter) OnAny(listener Listener, opts ...ListenerOption) (string, error) {
+	if listener == nil {
+		return "", &SubscriptionError{Op: OpOn, Topic: anyTopicName, Err: ErrNilListener}
+	}
+
+	listenerID := m.idGenerator()
+	var topic *Topic
+	opts = m.subscriptionOptions(anyTopicName, listenerID, func() error {
+		return m.OffAny(listenerID)
+	}, func() *Topic { return topic }, opts)
+
+	m.closeMu.RLock()
+	if m.closed.Load().(bool) {
+		m.closeMu.RUnlock()
+		return "", &SubscriptionError{Op: OpOn, Topic: anyTopicName, Err: ErrEmitterClosed}
+	}
+	topic = m.anyTopic.Load()
+	if topic == nil {
+		topic = NewTopic()
+		topic.Name = anyTopicName
+		if !m.anyTopic.CompareAndSwap(nil, topic) {
+			topic = m.anyTopic.Load()
+		}
+	}
+	item := topic.addListener(listenerID, listener, opts...)
+	m.closeMu.RUnlock()
+
+	if deadline := item.deadline(m.clock.Now()); !deadline.IsZero() {
+		m.scheduleExpiry(topic, anyTopicName, listenerID, item, deadline)
+	}
+	m.publishLifecycle(TopicListenerAdded, anyTopicName, listenerID)
+	return listenerID, nil
+}
+
+// OffAny unsubscribes a listener added with OnAny using its ID.
+func (m *MemoryEmitter) OffAny(listenerID string) error {
+	topic := m.anyTopic.Load()
+	if topic == nil {
+		return &SubscriptionError{Op: OpOff, Topic: anyTopicName, ListenerID: listenerID, Err: ErrListenerNotFound}
+	}
+	if err := topic.RemoveListener(listenerID); err != nil {
+		return &SubscriptionError{Op: OpOff, Topic: anyTopicName, ListenerID: listenerID, Err: err}
+	}
+	m.publishLifecycle(TopicListenerRemoved, anyTopicName, listenerID)
+	return nil
+}
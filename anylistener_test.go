@@ -0,0 +1,74 @@
+package emitter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOnAny(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+
+	var seen []string
+	e.On("user.created", func(evt Event) error {
+		seen = append(seen, "topic:"+evt.Topic())
+		return nil
+	})
+	errTap := errors.New("tap failed")
+	id, err := e.OnAny(func(evt Event) error {
+		seen = append(seen, "any:"+evt.Topic())
+		if evt.Topic() == "order.failed" {
+			return errTap
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("OnAny() error = %v", err)
+	}
+	if _, err := e.GetTopic("**"); err == nil {
+		t.Error("OnAny() registered a ** topic")
+	}
+
+	e.EmitSync("user.created")
+	e.EmitSync("")
+	errs := e.EmitSync("order.failed")
+	var emitErr *EmitError
+	if len(errs) != 1 || !errors.As(errs[0], &emitErr) || !errors.Is(errs[0], errTap) || emitErr.Pattern != "**" {
+		t.Errorf("EmitSync() = %v, want %v for pattern **", errs, errTap)
+	}
+
+	want := []string{"any:user.created", "topic:user.created", "any:", "any:order.failed"}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("listeners saw %v, want %v", seen, want)
+	}
+
+	if err := e.OffAny(id); err != nil {
+		t.Fatalf("OffAny() error = %v", err)
+	}
+	if err := e.OffAny(id); !errors.Is(err, ErrListenerNotFound) {
+		t.Errorf("OffAny() again error = %v, want %v", err, ErrListenerNotFound)
+	}
+	seen = nil
+	e.EmitSync("order.failed")
+	if len(seen) != 0 {
+		t.Errorf("listener removed with OffAny saw %v", seen)
+	}
+}
+
+func TestOnAnyMaxCalls(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+
+	calls := 0
+	e.OnAny(func(Event) error {
+		calls++
+		return nil
+	}, WithMaxCalls(2))
+	for _, topic := range []string{"a", "b", "c"} {
+		e.EmitSync(topic)
+	}
+	if calls != 2 {
+		t.Errorf("listener called %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,201 @@
+package emitter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// handlerMethodPrefix marks methods that are subscribed automatically by RegisterOn.
+const handlerMethodPrefix = "Handle"
+
+// handlerTagKey is the struct tag key used to subscribe listener fields.
+const handlerTagKey = "emitter"
+
+// listenerType is the reflected type of a Listener.
+var listenerType = reflect.TypeOf((*func(Event) error)(nil)).Elem()
+
+// attachment records a subscription made by an Attachable.
+type attachment struct {
+	topic string
+	id    string
+}
+
+// Attachable is a mixin that subscribes the handlers of the struct embedding it in one
+// call and removes them all again with Detach.
+//
+// Two kinds of handlers are discovered by RegisterOn:
+//
+//   - Methods named HandleXxx with the signature func(Event) error. The topic is derived
+//     from the rest of the method name, so HandleUserCreated listens on "user.created".
+//   - Fields of type Listener or func(Event) error tagged with `emitter:"topic"`. The tag
+//     may carry a priority, e.g. `emitter:"order.*,priority=high"`.
+type Attachable struct {
+	mu          sync.Mutex
+	emitter     Emitter
+	attachments []attachment
+}
+
+// RegisterOn subscribes every handler declared by owner to the given emitter. Because an
+// embedded struct cannot see the struct embedding it, owner must be that outer struct,
+// typically passed as a pointer. If any handler fails to register, the ones registered
+// so far are removed and the error is returned. Registering an Attachable that is
+// already registered fails with ErrAttachableRegistered.
+func (a *Attachable) RegisterOn(e Emitter, owner interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.emitter != nil {
+		return fmt.Errorf("%w; call Detach first", ErrAttachableRegistered)
+	}
+
+	handlers, err := discoverHandlers(owner)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range handlers {
+		id, err := e.On(h.topic, h.listener, WithPriority(h.priority))
+		if err != nil {
+			a.detach(e)
+			return fmt.Errorf("subscribing %s to %q: %w", h.name, h.topic, err)
+		}
+		a.attachments = append(a.attachments, attachment{topic: h.topic, id: id})
+	}
+
+	a.emitter = e
+	return nil
+}
+
+// Detach removes every listener registered by RegisterOn. It is safe to call Detach on
+// an Attachable that is not registered.
+func (a *Attachable) Detach() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.emitter != nil {
+		a.detach(a.emitter)
+	}
+}
+
+// detach removes the recorded subscriptions from the emitter. Callers must hold a.mu.
+func (a *Attachable) detach(e Emitter) {
+	for _, att := range a.attachments {
+		_ = e.Off(att.topic, att.id)
+	}
+	a.attachments = nil
+	a.emitter = nil
+}
+
+// declaredHandler is a listener discovered on an owner struct.
+type declaredHandler struct {
+	name     string
+	topic    string
+	priority Priority
+	listener Listener
+}
+
+// discoverHandlers collects the HandleXxx methods and tagged listener fields of owner.
+func discoverHandlers(owner interface{}) ([]declaredHandler, error) {
+	value := reflect.ValueOf(owner)
+	if !value.IsValid() {
+		return nil, fmt.Errorf("%w: owner cannot be nil", ErrInvalidHandler)
+	}
+
+	var handlers []declaredHandler
+
+	ownerType := value.Type()
+	for i := 0; i < ownerType.NumMethod(); i++ {
+		method := ownerType.Method(i)
+		if !strings.HasPrefix(method.Name, handlerMethodPrefix) || method.Name == handlerMethodPrefix {
+			continue
+		}
+		bound := value.Method(i)
+		if !bound.Type().ConvertibleTo(listenerType) {
+			return nil, fmt.Errorf("%w: method %s must have the signature func(emitter.Event) error", ErrInvalidHandler, method.Name)
+		}
+		handlers = append(handlers, declaredHandler{
+			name:     method.Name,
+			topic:    methodNameToTopic(strings.TrimPrefix(method.Name, handlerMethodPrefix)),
+			priority: Normal,
+			listener: bound.Convert(listenerType).Interface().(func(Event) error),
+		})
+	}
+
+	structValue := reflect.Indirect(value)
+	if structValue.Kind() != reflect.Struct {
+		return handlers, nil
+	}
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := field.Tag.Lookup(handlerTagKey)
+		if !ok || tag == "-" {
+			continue
+		}
+		if !field.IsExported() || !field.Type.ConvertibleTo(listenerType) {
+			return nil, fmt.Errorf("%w: field %s must be an exported func(emitter.Event) error", ErrInvalidHandler, field.Name)
+		}
+		fieldValue := structValue.Field(i)
+		if fieldValue.IsNil() {
+			continue // Unset optional handler.
+		}
+		topic, priority, err := parseHandlerTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		handlers = append(handlers, declaredHandler{
+			name:     field.Name,
+			topic:    topic,
+			priority: priority,
+			listener: fieldValue.Convert(listenerType).Interface().(func(Event) error),
+		})
+	}
+
+	return handlers, nil
+}
+
+// parseHandlerTag parses a tag of the form "topic[,priority=name]".
+func parseHandlerTag(tag string) (string, Priority, error) {
+	parts := strings.Split(tag, ",")
+	topic := strings.TrimSpace(parts[0])
+	if topic == "" {
+		return "", 0, ErrInvalidTopicName
+	}
+
+	priority := Normal
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "priority":
+			p, err := ParsePriority(value)
+			if err != nil {
+				return "", 0, err
+			}
+			priority = p
+		default:
+			return "", 0, fmt.Errorf("%w: unknown tag option %q", ErrInvalidHandler, key)
+		}
+	}
+	return topic, priority, nil
+}
+
+// methodNameToTopic converts a CamelCase name such as "UserCreated" or "HTTPRequestFailed"
+// into a dot separated topic such as "user.created" or "http.request.failed".
+func methodNameToTopic(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
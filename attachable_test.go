@@ -0,0 +1,124 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+type billingModule struct {
+	Attachable
+	created   int
+	refunded  int
+	OnInvoice Listener `emitter:"invoice.*,priority=high"`
+	Skipped   Listener `emitter:"-"`
+}
+
+func (b *billingModule) HandleOrderCreated(e Event) error {
+	b.created++
+	return nil
+}
+
+func (b *billingModule) HandleOrderRefunded(e Event) error {
+	b.refunded++
+	return nil
+}
+
+func TestAttachableRegisterOnAndDetach(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	var invoices int
+	module := &billingModule{
+		OnInvoice: func(e Event) error {
+			invoices++
+			return nil
+		},
+	}
+
+	if err := module.RegisterOn(emitter, module); err != nil {
+		t.Fatalf("RegisterOn() failed with error: %v", err)
+	}
+
+	emitter.EmitSync("order.created", nil)
+	emitter.EmitSync("order.refunded", nil)
+	emitter.EmitSync("invoice.sent", nil)
+
+	if module.created != 1 || module.refunded != 1 || invoices != 1 {
+		t.Fatalf("Handlers called (created=%d, refunded=%d, invoices=%d), want 1 each", module.created, module.refunded, invoices)
+	}
+
+	topic, err := emitter.GetTopic("invoice.*")
+	if err != nil {
+		t.Fatalf("GetTopic() failed with error: %v", err)
+	}
+	for _, item := range topic.listeners {
+		if item.priority != High {
+			t.Errorf("Tagged listener priority = %v, want %v", item.priority, High)
+		}
+	}
+
+	module.Detach()
+	emitter.EmitSync("order.created", nil)
+	emitter.EmitSync("invoice.sent", nil)
+
+	if module.created != 1 || invoices != 1 {
+		t.Error("Handlers should not be called after Detach()")
+	}
+
+	// Detaching twice is a no-op and the module can be registered again.
+	module.Detach()
+	if err := module.RegisterOn(emitter, module); err != nil {
+		t.Fatalf("RegisterOn() after Detach() failed with error: %v", err)
+	}
+}
+
+func TestAttachableRegisterTwice(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	module := &billingModule{}
+
+	if err := module.RegisterOn(emitter, module); err != nil {
+		t.Fatalf("RegisterOn() failed with error: %v", err)
+	}
+	if err := module.RegisterOn(emitter, module); !errors.Is(err, ErrAttachableRegistered) {
+		t.Errorf("RegisterOn() when already registered error = %v, want %v", err, ErrAttachableRegistered)
+	}
+}
+
+type badSignatureModule struct {
+	Attachable
+}
+
+func (b *badSignatureModule) HandleSomething(payload string) {}
+
+type badTagModule struct {
+	Attachable
+	OnThing Listener `emitter:"thing,priority=urgent"`
+}
+
+func TestAttachableInvalidHandlers(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	bad := &badSignatureModule{}
+	if err := bad.RegisterOn(emitter, bad); !errors.Is(err, ErrInvalidHandler) {
+		t.Errorf("RegisterOn() error = %v, want %v", err, ErrInvalidHandler)
+	}
+
+	tagged := &badTagModule{OnThing: func(e Event) error { return nil }}
+	if err := tagged.RegisterOn(emitter, tagged); !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("RegisterOn() error = %v, want %v", err, ErrInvalidPriority)
+	}
+}
+
+func TestMethodNameToTopic(t *testing.T) {
+	tests := map[string]string{
+		"UserCreated":       "user.created",
+		"HTTPRequestFailed": "http.request.failed",
+		"OrderV2Shipped":    "order.v2.shipped",
+		"Ping":              "ping",
+	}
+
+	for name, want := range tests {
+		if got := methodNameToTopic(name); got != want {
+			t.Errorf("methodNameToTopic(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package emitter
+
+import "context"
+
+// Forwarder carries the events dispatched on an emitter to another system, such as a
+// message broker. Together with DispatchLocal, it lets custom Emitter implementations
+// embed a MemoryEmitter and keep its semantics, such as wildcard matching, priorities,
+// aborts, listener options, error and panic handling, instead of reimplementing them:
+//
+//	type NatsEmitter struct {
+//		*emitter.MemoryEmitter
+//		conn *nats.Conn
+//	}
+//
+//	func NewNatsEmitter(conn *nats.Conn, opts ...emitter.EmitterOption) *NatsEmitter {
+//		n := &NatsEmitter{MemoryEmitter: emitter.NewMemoryEmitter(opts...), conn: conn}
+//		n.SetForwarder(emitter.ForwarderFunc(n.publish))   // Outgoing events.
+//		conn.Subscribe(">", n.receive)                    // Incoming events, via DispatchLocal.
+//		return n
+//	}
+type Forwarder interface {
+	// Forward carries an event that was dispatched to the local listeners. ctx is the
+	// context of its emission.
+	Forward(ctx context.Context, evt Event) error
+}
+
+// ForwarderFunc adapts a function to the Forwarder interface.
+type ForwarderFunc func(ctx context.Context, evt Event) error
+
+// Forward calls f.
+func (f ForwarderFunc) Forward(ctx context.Context, evt Event) error {
+	return f(ctx, evt)
+}
+
+// SetForwarder sets the Forwarder receiving every event emitted on the emitter once it
+// was dispatched to the local listeners, unless one of them aborted it. Events
+// dispatched with DispatchLocal are not forwarded. Forwarding errors are wrapped in an
+// EmitError with Op OpPublish, passed to the error handler and reported to the emitting
+// caller like listener errors. Set it before emitting events; nil disables forwarding.
+func (m *MemoryEmitter) SetForwarder(forwarder Forwarder) {
+	m.forwarder = forwarder
+}
+
+// forward hands a dispatched event to the forwarder and reports its error.
+func (m *MemoryEmitter) forward(event *BaseEvent, errorHandler func(error)) {
+	evt := event.listenerEvent()
+	err := m.forwarder.Forward(event.Context(), evt)
+	if err == nil {
+		return
+	}
+	err = &EmitError{Op: OpPublish, Topic: event.Topic(), EventID: event.id, Err: err}
+	if m.errorHandler != nil {
+		err = m.errorHandler(evt, err)
+	}
+	if err != nil && errorHandler != nil {
+		errorHandler(err)
+	}
+}
+
+// DispatchLocal notifies the local listeners of an event that comes from another system,
+// such as a message broker, and returns their errors. The event is neither forwarded
+// nor propagated to related emitters, so it does not travel back where it came from.
+// Events without a timestamp or ID get them as if they were emitted; panics of the
+// listeners are handled under the emitter's PanicPolicy.
+func (m *MemoryEmitter) DispatchLocal(ctx context.Context, evt Event) []error {
+	if m.closed.Load().(bool) {
+		return []error{&EmitError{Op: OpEmit, Topic: evt.Topic(), EventID: eventID(evt), Err: ErrEmitterClosed}}
+	}
+
+	event := toBaseEvent(evt)
+	if event.timestamp.IsZero() {
+		event.timestamp = m.clock.Now()
+	}
+	if event.id == "" && m.eventIDGenerator != nil {
+		event.id = m.eventIDGenerator()
+	}
+	if cancel := m.prepareEmission(ctx, event); cancel != nil {
+		defer cancel()
+	}
+
+	var errs []error
+	func() {
+		defer m.recoverPanic(event)
+		m.dispatch(event, func(err error) {
+			errs = append(errs, err)
+		})
+	}()
+	return errs
+}
@@ -0,0 +1,127 @@
+package emitter_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// channelEmitter is a custom Emitter built on a MemoryEmitter, sending its events to a
+// shared bus as a broker-backed emitter would.
+type channelEmitter struct {
+	*emitter.MemoryEmitter
+	name string
+	bus  *channelBus
+}
+
+// channelBus delivers the events of each emitter to the others.
+type channelBus struct {
+	mu       sync.Mutex
+	emitters []*channelEmitter
+	down     bool
+}
+
+func newChannelEmitter(name string, bus *channelBus) *channelEmitter {
+	c := &channelEmitter{MemoryEmitter: emitter.NewMemoryEmitter(), name: name, bus: bus}
+	c.SetForwarder(emitter.ForwarderFunc(c.publish))
+	bus.mu.Lock()
+	bus.emitters = append(bus.emitters, c)
+	bus.mu.Unlock()
+	return c
+}
+
+func (c *channelEmitter) publish(ctx context.Context, evt emitter.Event) error {
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+	if c.bus.down {
+		return errors.New("bus down")
+	}
+	for _, other := range c.bus.emitters {
+		if other != c {
+			other.DispatchLocal(ctx, emitter.NewEvent(evt.Topic()).WithPayload(evt.Payload()))
+		}
+	}
+	return nil
+}
+
+func TestCustomBackend(t *testing.T) {
+	bus := &channelBus{}
+	a, b := newChannelEmitter("a", bus), newChannelEmitter("b", bus)
+	defer a.Close()
+	defer b.Close()
+
+	var mu sync.Mutex
+	var seen []string
+	record := func(name string) emitter.Listener {
+		return func(evt emitter.Event) error {
+			mu.Lock()
+			seen = append(seen, name+":"+evt.Topic())
+			mu.Unlock()
+			return nil
+		}
+	}
+	a.On("order.*", record("a"))
+	b.On("order.*", record("b"))
+	b.On("order.secret", func(evt emitter.Event) error {
+		evt.SetAborted(true)
+		return nil
+	}, emitter.WithPriority(emitter.Highest))
+
+	a.EmitSync("order.created")
+	b.EmitSync("order.secret")
+
+	if want := []string{"a:order.created", "b:order.created"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("listeners saw %v, want %v without dispatching or forwarding the aborted event", seen, want)
+	}
+
+	bus.down = true
+	errs := a.EmitSync("order.created")
+	var emitErr *emitter.EmitError
+	if len(errs) != 1 || !errors.As(errs[0], &emitErr) || emitErr.Op != emitter.OpPublish {
+		t.Errorf("EmitSync() with the bus down = %v, want a publish error", errs)
+	}
+}
+
+func TestDispatchLocal(t *testing.T) {
+	var panicked interface{}
+	em := emitter.NewMemoryEmitter(emitter.WithEventIDGenerator(func() string { return "evt-1" }),
+		emitter.WithPanicHandler(func(p interface{}) { panicked = p }))
+	forwarded := false
+	em.SetForwarder(emitter.ForwarderFunc(func(context.Context, emitter.Event) error {
+		forwarded = true
+		return nil
+	}))
+
+	var received emitter.Event
+	em.On("job.*", func(evt emitter.Event) error {
+		if evt.Topic() == "job.crashed" {
+			panic("listener crashed")
+		}
+		received = evt
+		return errors.New("job failed")
+	})
+
+	if errs := em.DispatchLocal(context.Background(), emitter.NewEvent("job.done")); len(errs) != 1 {
+		t.Errorf("DispatchLocal() = %v, want the listener error", errs)
+	}
+	if received == nil || received.(*emitter.BaseEvent).ID() != "evt-1" || received.(*emitter.BaseEvent).Timestamp().IsZero() {
+		t.Errorf("listener received %v, want an event stamped with an ID and timestamp", received)
+	}
+	if forwarded {
+		t.Error("DispatchLocal() forwarded the event")
+	}
+	em.DispatchLocal(context.Background(), emitter.NewEvent("job.crashed"))
+	if panicked != "listener crashed" {
+		t.Errorf("panic handler received %v, want the listener's panic", panicked)
+	}
+
+	em.Close()
+	errs := em.DispatchLocal(context.Background(), emitter.NewEvent("job.done"))
+	if len(errs) != 1 || !errors.Is(errs[0], emitter.ErrEmitterClosed) {
+		t.Errorf("DispatchLocal() after Close = %v, want %v", errs, emitter.ErrEmitterClosed)
+	}
+}
@@ -0,0 +1,103 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Bounds of the delay between attempts to submit a blocked emission.
+const (
+	minSubmitBackoff = 50 * time.Microsecond
+	maxSubmitBackoff = 10 * time.Millisecond
+)
+
+// PoolStats is a snapshot of the emitter's pool utilization.
+type PoolStats struct {
+	Running  int // Number of running workers.
+	Waiting  int // Number of queued tasks, if the pool reports it.
+	Capacity int // Maximum number of queued tasks, if the pool reports it; zero means unbounded.
+}
+
+// PoolStats returns the current utilization of the emitter's pool. Waiting and
+// Capacity are only filled in for pools implementing QueueMetrics.
+func (m *MemoryEmitter) PoolStats() PoolStats {
+	var stats PoolStats
+	if m.Pool == nil {
+		return stats
+	}
+	stats.Running = m.Pool.Running()
+	if metrics, ok := m.Pool.(QueueMetrics); ok {
+		stats.Waiting = metrics.Waiting()
+		stats.Capacity = metrics.Capacity()
+	}
+	return stats
+}
+
+// EmitBlocking behaves like EmitWithContext but waits for the pool to have room for the
+// emission instead of queuing it unboundedly, so producers slow down under load. If ctx
+// ends before the emission could be queued, the emission is dropped and the returned
+// channel holds an ErrEmissionCanceled error.
+//
+// Backpressure requires a pool implementing TrySubmitter or PrioritySubmitter, such as
+// PondPool. Other pools and emitters without a pool accept the emission immediately.
+func (m *MemoryEmitter) EmitBlocking(ctx context.Context, eventName string, args ...interface{}) <-chan error {
+	args, cfg := splitEmitArgs(args)
+	event := newArgsEvent(eventName, args)
+	return m.emitWith(ctx, event, cfg, func(task func()) error {
+		return m.submitBlocking(ctx, task, event.Priority(), event.Topic())
+	})
+}
+
+// TryEmit asynchronously dispatches an event unless the pool is saturated, in which case
+// the event is dropped and ErrPoolSaturated is returned right away. It suits best-effort
+// producers, such as telemetry, that prefer shedding load to queuing it. Listener errors
+// are not reported to the caller; use WithOnComplete or WithErrorTopic to observe them.
+//
+// Only pools implementing TrySubmitter or PrioritySubmitter can refuse emissions. Other
+// pools and emitters without a pool always accept them.
+func (m *MemoryEmitter) TryEmit(eventName string, args ...interface{}) (bool, error) {
+	if m.closed.Load().(bool) {
+		return false, &EmitError{Op: OpEmit, Topic: eventName, Err: ErrEmitterClosed}
+	}
+
+	args, cfg := splitEmitArgs(args)
+	event := newArgsEvent(eventName, args)
+	m.inflight.add()
+	task := func() {
+		defer m.inflight.done()
+		defer m.recoverPanic(event)
+		m.runEmission(context.Background(), event, nil, cfg)
+	}
+	if !m.trySubmit(task, event.Priority(), eventName) {
+		m.inflight.done()
+		err := &EmitError{Op: OpEmit, Topic: eventName, Err: ErrPoolSaturated}
+		cfg.complete(eventName, 0, []error{err})
+		return false, err
+	}
+	return true, nil
+}
+
+// submitBlocking submits the task, retrying with an increasing delay while the pool is
+// saturated, until it is accepted or ctx ends.
+func (m *MemoryEmitter) submitBlocking(ctx context.Context, task func(), priority Priority, topic string) error {
+	backoff := minSubmitBackoff
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w: %w", ErrEmissionCanceled, err)
+		}
+		if m.trySubmit(task, priority, topic) {
+			return nil
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		if backoff *= 2; backoff > maxSubmitBackoff {
+			backoff = maxSubmitBackoff
+		}
+	}
+}
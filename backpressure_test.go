@@ -0,0 +1,120 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmitBlocking(t *testing.T) {
+	emitter := NewMemoryEmitter(WithPool(NewPondPool(1, 1)))
+	defer emitter.Close()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	emitter.On("job.run", func(e Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+
+	emitter.Emit("job.run", 1) // Occupies the only worker.
+	<-started
+	emitter.Emit("job.run", 2) // Fills the queue.
+
+	if stats := emitter.PoolStats(); stats.Waiting != 1 || stats.Capacity != 1 {
+		t.Errorf("PoolStats() = %+v, want one waiting task of capacity 1", stats)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var errs []error
+	for err := range emitter.EmitBlocking(ctx, "job.run", 3) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrEmissionCanceled) {
+		t.Fatalf("EmitBlocking() on a saturated pool = %v, want %v", errs, ErrEmissionCanceled)
+	}
+
+	done := make(chan []error, 1)
+	go func() {
+		var errs []error
+		for err := range emitter.EmitBlocking(context.Background(), "job.run", 4) {
+			errs = append(errs, err)
+		}
+		done <- errs
+	}()
+	close(release)
+
+	select {
+	case errs := <-done:
+		if len(errs) != 0 {
+			t.Errorf("EmitBlocking() = %v, want no errors", errs)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("EmitBlocking() did not proceed once the pool had room")
+	}
+}
+
+func TestEmitBlockingWithoutPool(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	called := make(chan struct{}, 1)
+	emitter.On("job.run", func(e Event) error {
+		called <- struct{}{}
+		return nil
+	})
+
+	for err := range emitter.EmitBlocking(context.Background(), "job.run", nil) {
+		t.Errorf("EmitBlocking() reported %v", err)
+	}
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Listener was not called")
+	}
+}
+
+func TestTryEmit(t *testing.T) {
+	emitter := NewMemoryEmitter(WithPool(NewPondPool(1, 1)))
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	emitter.On("telemetry.sample", func(e Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+
+	if accepted, err := emitter.TryEmit("telemetry.sample", 1); !accepted || err != nil {
+		t.Fatalf("TryEmit() = %v, %v, want accepted", accepted, err)
+	}
+	<-started
+	if accepted, err := emitter.TryEmit("telemetry.sample", 2); !accepted || err != nil {
+		t.Fatalf("TryEmit() = %v, %v, want accepted into the queue", accepted, err)
+	}
+
+	var completed []error
+	accepted, err := emitter.TryEmit("telemetry.sample", 3, WithOnComplete(func(topic string, delivered int, errs []error) {
+		completed = errs
+	}))
+	if accepted || !errors.Is(err, ErrPoolSaturated) {
+		t.Errorf("TryEmit() on a saturated pool = %v, %v, want %v", accepted, err, ErrPoolSaturated)
+	}
+	if len(completed) != 1 || !errors.Is(completed[0], ErrPoolSaturated) {
+		t.Errorf("Completion errors = %v, want [%v]", completed, ErrPoolSaturated)
+	}
+
+	close(release)
+	emitter.Close()
+	if accepted, err := emitter.TryEmit("telemetry.sample", 4); accepted || !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("TryEmit() on a closed emitter = %v, %v, want %v", accepted, err, ErrEmitterClosed)
+	}
+}
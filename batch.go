@@ -0,0 +1,189 @@
+package emitter
+
+import (
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Batching used by OnBatch when WithBatch is not given.
+const (
+	defaultBatchSize = 100
+	defaultBatchWait = 100 * time.Millisecond
+)
+
+// BatchListener handles events in batches, for listeners with a high per-call cost such
+// as database writes. It is subscribed with OnBatch.
+type BatchListener func(events []Event) error
+
+// WithBatch sets how a listener subscribed with OnBatch batches events: a batch is
+// delivered once it holds maxSize events, or maxWait after its first event arrived,
+// whichever comes first. Non-positive values keep the defaults of 100 events and 100ms.
+// It has no effect on listeners subscribed with On.
+func WithBatch(maxSize int, maxWait time.Duration) ListenerOption {
+	return func(item *listenerItem) {
+		item.batchMax, item.batchFor = maxSize, maxWait
+	}
+}
+
+// OnBatch subscribes a listener receiving the matching events in batches, configured
+// with WithBatch, in the order they were emitted. Events are buffered as they are
+// emitted, so listeners must not rely on them being modified afterwards, and payloads
+// from a PayloadPool must not be batched.
+//
+// The emission completing a batch delivers it and reports the listener's error, if any.
+// A batch delivered because its wait elapsed, or because the emitter is closed, reports
+// its error to the error handler, with the last event of the batch, and then to the
+// error topic, if set. Events buffered before the listener is removed are still
+// delivered once their wait elapses, unless the emitter is closed first.
+func (m *MemoryEmitter) OnBatch(topicName string, listener BatchListener, opts ...ListenerOption) (string, error) {
+	if listener == nil {
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: ErrNilListener}
+	}
+	b := &batcher{m: m, listener: listener, pattern: topicName, size: defaultBatchSize, wait: defaultBatchWait}
+	opts = append(opts[:len(opts):len(opts)], withBatcher(b))
+	id, err := m.On(topicName, b.add, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	b.mu.Lock()
+	b.id = id
+	b.mu.Unlock()
+	untrack := m.background.track(b.flushPending)
+	b.mu.Lock()
+	b.untrack = untrack
+	detached := b.detached
+	b.mu.Unlock()
+	if detached {
+		untrack()
+	}
+	return id, nil
+}
+
+// withBatcher attaches the batcher to the listener, applying the WithBatch settings.
+func withBatcher(b *batcher) ListenerOption {
+	return func(item *listenerItem) {
+		if item.batchMax > 0 {
+			b.size = item.batchMax
+		}
+		if item.batchFor > 0 {
+			b.wait = item.batchFor
+		}
+		item.batch = b
+	}
+}
+
+// batcher buffers the events of a BatchListener and delivers them in batches.
+type batcher struct {
+	m        *MemoryEmitter
+	listener BatchListener
+	pattern  string
+	size     int
+	wait     time.Duration
+
+	mu       sync.Mutex
+	id       string  // ID of the listener.
+	pending  []Event // Events of the batch being filled.
+	batch    int     // Number of the batch being filled, to ignore the timers of delivered ones.
+	timer    Timer   // Delivers the batch being filled once its wait elapses.
+	untrack  func()  // Stops Close from delivering the pending batch.
+	detached bool    // Whether the listener was removed.
+	deliver  sync.Mutex
+}
+
+// add buffers the event, delivering the batch once it is full.
+func (b *batcher) add(evt Event) error {
+	b.mu.Lock()
+	b.pending = append(b.pending, boundEvent(evt))
+	if len(b.pending) < b.size {
+		if len(b.pending) == 1 {
+			batch := b.batch
+			b.timer = b.m.afterFunc(b.wait, func() { b.flushBatch(batch) })
+		}
+		b.mu.Unlock()
+		return nil
+	}
+	return b.flushLocked()
+}
+
+// flushLocked delivers the pending events, if any, and returns the listener's error.
+// Callers must hold b.mu, which is released before the listener is called; batches are
+// still delivered one at a time, in order.
+func (b *batcher) flushLocked() error {
+	events := b.pending
+	b.pending = nil
+	b.batch++
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.deliver.Lock()
+	b.mu.Unlock()
+	defer b.deliver.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+	return b.listener(events)
+}
+
+// flushBatch delivers the given batch once its wait elapsed, unless it was delivered
+// already.
+func (b *batcher) flushBatch(batch int) {
+	b.mu.Lock()
+	if batch != b.batch {
+		b.mu.Unlock()
+		return
+	}
+	b.flushOutsideEmission()
+}
+
+// flushPending delivers the pending events when the emitter is closed.
+func (b *batcher) flushPending() {
+	b.mu.Lock()
+	b.flushOutsideEmission()
+}
+
+// flushOutsideEmission delivers the pending events outside of any emission, reporting
+// the listener's error and recovering its panics. Callers must hold b.mu.
+func (b *batcher) flushOutsideEmission() {
+	id := b.id
+	var events []Event
+	defer func() {
+		if r := recover(); r != nil && len(events) > 0 {
+			last := events[len(events)-1]
+			b.m.reportListenerPanic(&ListenerPanic{Topic: last.Topic(), ListenerID: id, Event: last, Value: r, Stack: debug.Stack()})
+		}
+	}()
+	events = b.pending
+	err := b.flushLocked()
+	if err != nil && len(events) > 0 {
+		b.m.reportDeferredError(events[len(events)-1], b.pattern, id, err)
+	}
+}
+
+// detach records that the listener was removed, so that Close no longer delivers its
+// pending batch. The batch is still delivered once its wait elapses.
+func (b *batcher) detach() {
+	b.mu.Lock()
+	b.detached = true
+	untrack := b.untrack
+	b.mu.Unlock()
+	if untrack != nil {
+		untrack()
+	}
+}
+
+// reportDeferredError passes the error of a listener called outside of an emission, such
+// as a batch delivered after its wait, to the error handler along with the last event
+// the listener received, and publishes what is left of it on the error topic, if set.
+func (m *MemoryEmitter) reportDeferredError(last Event, pattern, id string, err error) {
+	if m.errorHandler != nil {
+		err = m.errorHandler(last, err)
+	}
+	if err == nil || m.errorTopic == "" || last.Topic() == m.errorTopic {
+		return
+	}
+	m.publish(m.errorTopic, &DispatchError{Topic: last.Topic(), Pattern: pattern, ListenerID: id, Event: last, Err: err})
+}
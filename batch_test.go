@@ -0,0 +1,126 @@
+package emitter
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder records the batches delivered to a BatchListener.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+	err     error
+}
+
+func (r *batchRecorder) listener(events []Event) error {
+	payloads := make([]interface{}, len(events))
+	for i, evt := range events {
+		payloads[i] = evt.Payload()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, payloads)
+	return r.err
+}
+
+func (r *batchRecorder) sizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sizes := make([]int, len(r.batches))
+	for i, batch := range r.batches {
+		sizes[i] = len(batch)
+	}
+	return sizes
+}
+
+func TestOnBatchDeliversFullBatches(t *testing.T) {
+	e := NewMemoryEmitter()
+	rec := &batchRecorder{}
+	if _, err := e.OnBatch("rows", rec.listener, WithBatch(3, time.Hour)); err != nil {
+		t.Fatalf("OnBatch() error = %v", err)
+	}
+
+	for i := 0; i < 7; i++ {
+		e.EmitSync("rows", i)
+	}
+	if sizes := rec.sizes(); len(sizes) != 2 || sizes[0] != 3 || sizes[1] != 3 {
+		t.Fatalf("batch sizes = %v, want [3 3]", sizes)
+	}
+	if rec.batches[1][0] != 3 || rec.batches[1][2] != 5 {
+		t.Errorf("second batch = %v, want [3 4 5]", rec.batches[1])
+	}
+
+	e.Close()
+	if sizes := rec.sizes(); len(sizes) != 3 || sizes[2] != 1 {
+		t.Errorf("batch sizes after Close = %v, want [3 3 1]", sizes)
+	}
+}
+
+func TestOnBatchReportsErrorOfFullBatch(t *testing.T) {
+	e := NewMemoryEmitter()
+	rec := &batchRecorder{err: errors.New("insert failed")}
+	if _, err := e.OnBatch("rows", rec.listener, WithBatch(2, time.Hour)); err != nil {
+		t.Fatalf("OnBatch() error = %v", err)
+	}
+
+	if errs := e.EmitSync("rows", 1); len(errs) != 0 {
+		t.Errorf("EmitSync() of a buffered event = %v, want no error", errs)
+	}
+	if errs := e.EmitSync("rows", 2); len(errs) != 1 || !errors.Is(errs[0], rec.err) {
+		t.Errorf("EmitSync() completing the batch = %v, want %v", errs, rec.err)
+	}
+}
+
+func TestOnBatchDeliversAfterWait(t *testing.T) {
+	reported := make(chan Event, 1)
+	e := NewMemoryEmitter(WithErrorHandler(func(evt Event, err error) error {
+		reported <- evt
+		return nil
+	}))
+	defer e.Close()
+	rec := &batchRecorder{err: errors.New("insert failed")}
+	if _, err := e.OnBatch("rows", rec.listener, WithBatch(10, 10*time.Millisecond)); err != nil {
+		t.Fatalf("OnBatch() error = %v", err)
+	}
+
+	e.EmitSync("rows", 1)
+	e.EmitSync("rows", 2)
+	select {
+	case evt := <-reported:
+		if evt.Payload() != 2 {
+			t.Errorf("reported event payload = %v, want the last event of the batch", evt.Payload())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("batch was not delivered after its wait")
+	}
+	if sizes := rec.sizes(); len(sizes) != 1 || sizes[0] != 2 {
+		t.Errorf("batch sizes = %v, want [2]", sizes)
+	}
+}
+
+func TestOnBatchRemovedListenerIsNotFlushedOnClose(t *testing.T) {
+	e := NewMemoryEmitter()
+	rec := &batchRecorder{}
+	id, err := e.OnBatch("rows", rec.listener, WithBatch(10, time.Hour))
+	if err != nil {
+		t.Fatalf("OnBatch() error = %v", err)
+	}
+
+	e.EmitSync("rows", 1)
+	if err := e.Off("rows", id); err != nil {
+		t.Fatalf("Off() error = %v", err)
+	}
+	e.Close()
+	if sizes := rec.sizes(); len(sizes) != 0 {
+		t.Errorf("batch sizes = %v, want no batch", sizes)
+	}
+}
+
+func TestOnBatchNilListener(t *testing.T) {
+	e := NewMemoryEmitter()
+	if _, err := e.OnBatch("rows", nil); !errors.Is(err, ErrNilListener) {
+		t.Errorf("OnBatch(nil) error = %v, want %v", err, ErrNilListener)
+	}
+}
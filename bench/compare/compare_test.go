@@ -0,0 +1,157 @@
+package compare
+
+import (
+	"fmt"
+	"testing"
+
+	eventbus "github.com/asaskevich/EventBus"
+	"github.com/kaptinlin/emitter"
+	olebedev "github.com/olebedev/emitter"
+)
+
+// The listeners of every library do the same trivial work, so the benchmarks measure
+// dispatch overhead. All deliveries are synchronous to keep the comparison fair.
+
+var sink int
+
+func benchmarkPublish(b *testing.B, listeners int) {
+	const topic = "order.created"
+
+	b.Run("kaptinlin", func(b *testing.B) {
+		e := emitter.NewMemoryEmitter()
+		defer e.Close()
+		for i := 0; i < listeners; i++ {
+			_, _ = e.On(topic, func(evt emitter.Event) error {
+				sink++
+				return nil
+			})
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			e.EmitSync(topic, i)
+		}
+	})
+
+	b.Run("asaskevich", func(b *testing.B) {
+		bus := eventbus.New()
+		for i := 0; i < listeners; i++ {
+			_ = bus.Subscribe(topic, func(payload int) { sink++ })
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			bus.Publish(topic, i)
+		}
+	})
+
+	b.Run("olebedev", func(b *testing.B) {
+		e := olebedev.New(0)
+		for i := 0; i < listeners; i++ {
+			// Void skips the channel send, so the middleware acts as a callback listener.
+			e.On(topic, func(*olebedev.Event) { sink++ }, olebedev.Void)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			<-e.Emit(topic, i)
+		}
+	})
+}
+
+// BenchmarkPublish measures the latency of publishing to a single listener.
+func BenchmarkPublish(b *testing.B) {
+	benchmarkPublish(b, 1)
+}
+
+// BenchmarkPublishFanOut measures publishing to ten listeners of the same topic.
+func BenchmarkPublishFanOut(b *testing.B) {
+	benchmarkPublish(b, 10)
+}
+
+// BenchmarkSubscribeChurn measures subscribing and immediately unsubscribing a listener
+// while other listeners stay registered.
+func BenchmarkSubscribeChurn(b *testing.B) {
+	const topic = "session.updated"
+
+	b.Run("kaptinlin", func(b *testing.B) {
+		e := emitter.NewMemoryEmitter()
+		defer e.Close()
+		listener := func(evt emitter.Event) error { return nil }
+		for i := 0; i < 10; i++ {
+			_, _ = e.On(topic, listener)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			id, _ := e.On(topic, listener)
+			_ = e.Off(topic, id)
+		}
+	})
+
+	b.Run("asaskevich", func(b *testing.B) {
+		bus := eventbus.New()
+		for i := 0; i < 10; i++ {
+			_ = bus.Subscribe(topic, func() {})
+		}
+		listener := func() {}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = bus.Subscribe(topic, listener)
+			_ = bus.Unsubscribe(topic, listener)
+		}
+	})
+
+	b.Run("olebedev", func(b *testing.B) {
+		e := olebedev.New(0)
+		for i := 0; i < 10; i++ {
+			e.On(topic, olebedev.Void)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ch := e.On(topic, olebedev.Void)
+			e.Off(topic, ch)
+		}
+	})
+}
+
+// BenchmarkWildcardHeavy measures publishing when many wildcard subscriptions are
+// registered and only a few of them match.
+func BenchmarkWildcardHeavy(b *testing.B) {
+	const services = 100
+	const topic = "svc42.order.created"
+
+	b.Run("kaptinlin", func(b *testing.B) {
+		e := emitter.NewMemoryEmitter()
+		defer e.Close()
+		for i := 0; i < services; i++ {
+			_, _ = e.On(fmt.Sprintf("svc%d.order.*", i), func(evt emitter.Event) error {
+				sink++
+				return nil
+			})
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			e.EmitSync(topic, i)
+		}
+	})
+
+	b.Run("asaskevich", func(b *testing.B) {
+		b.Skip("asaskevich/EventBus does not support wildcard subscriptions")
+	})
+
+	b.Run("olebedev", func(b *testing.B) {
+		e := olebedev.New(0)
+		for i := 0; i < services; i++ {
+			e.On(fmt.Sprintf("svc%d.order.*", i), func(*olebedev.Event) { sink++ }, olebedev.Void)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			<-e.Emit(topic, i)
+		}
+	})
+}
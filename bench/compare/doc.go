@@ -0,0 +1,7 @@
+// Package compare benchmarks github.com/kaptinlin/emitter against other popular Go event
+// emitters. It lives in its own module so that the main module does not depend on them.
+//
+// Run the benchmarks from this directory with:
+//
+//	go test -bench . -benchmem
+package compare
@@ -0,0 +1,13 @@
+module github.com/kaptinlin/emitter/bench/compare
+
+go 1.21
+
+require (
+	github.com/asaskevich/EventBus v0.0.0-20200907212545-49d423059eef
+	github.com/kaptinlin/emitter v0.0.0-00010101000000-000000000000
+	github.com/olebedev/emitter v0.0.0-20190110104742-e8d1457e6aee
+)
+
+require github.com/alitto/pond v1.9.2 // indirect
+
+replace github.com/kaptinlin/emitter => ../..
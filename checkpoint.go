@@ -0,0 +1,65 @@
+package emitter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// emission carries the cooperative checkpoint state of a single dispatch. Listener
+// chains consult it between listeners so that cancellation, timeouts and the listener
+// budget preempt them deterministically.
+type emission struct {
+	ctx       context.Context
+	remaining int       // Listener invocations left; negative means unlimited.
+	expiresAt time.Time // Time after which the event must not be delivered, if set.
+	clock     Clock     // Clock used to check expiresAt.
+	stopped   bool      // Whether a checkpoint already stopped this emission.
+}
+
+// newEmission returns checkpoint state for an emission, or nil when the context cannot be
+// canceled, no budget applies and the event does not expire, keeping the common path
+// free of checks.
+func newEmission(ctx context.Context, budget int, expiresAt time.Time, clock Clock) *emission {
+	if ctx.Done() == nil && budget <= 0 && expiresAt.IsZero() {
+		return nil
+	}
+	remaining := budget
+	if budget <= 0 {
+		remaining = -1
+	}
+	return &emission{ctx: ctx, remaining: remaining, expiresAt: expiresAt, clock: clock}
+}
+
+// checkpoint is called before each listener. It reports whether the chain must stop
+// and, the first time it does, the error explaining why.
+func (em *emission) checkpoint() (bool, error) {
+	if em == nil {
+		return false, nil
+	}
+	if em.stopped {
+		return true, nil
+	}
+	if err := em.ctx.Err(); err != nil {
+		em.stopped = true
+		return true, fmt.Errorf("%w: %w", ErrEmissionCanceled, err)
+	}
+	if !em.expiresAt.IsZero() && em.clock.Now().After(em.expiresAt) {
+		em.stopped = true
+		return true, ErrEventExpired
+	}
+	if em.remaining == 0 {
+		em.stopped = true
+		return true, ErrListenerBudgetExceeded
+	}
+	if em.remaining > 0 {
+		em.remaining--
+	}
+	return false, nil
+}
+
+// canceled reports whether the emission was stopped or its context is done, without
+// consuming the listener budget like checkpoint.
+func (em *emission) canceled() bool {
+	return em != nil && (em.stopped || em.ctx.Err() != nil)
+}
@@ -0,0 +1,199 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEmitSyncWithContextCancellation(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls []string
+	emitter.On("job.run", func(e Event) error {
+		calls = append(calls, "first")
+		cancel() // Cancel the emission from inside the chain.
+		return nil
+	}, WithPriority(High))
+	emitter.On("job.run", func(e Event) error {
+		calls = append(calls, "second")
+		return nil
+	}, WithPriority(Low))
+
+	errs := emitter.EmitSyncWithContext(ctx, "job.run", nil)
+
+	if len(calls) != 1 || calls[0] != "first" {
+		t.Errorf("Listeners called = %v, want [first]", calls)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrEmissionCanceled) || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("EmitSyncWithContext() errors = %v, want a single %v wrapping %v", errs, ErrEmissionCanceled, context.Canceled)
+	}
+}
+
+func TestEmitWithContextAlreadyCanceled(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	emitter.On("job.run", func(e Event) error {
+		t.Error("Listener should not be called for a canceled emission")
+		return nil
+	})
+
+	var errs []error
+	for err := range emitter.EmitWithContext(ctx, "job.run", nil) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrEmissionCanceled) {
+		t.Errorf("EmitWithContext() errors = %v, want [%v]", errs, ErrEmissionCanceled)
+	}
+}
+
+func TestEventContextIsPropagated(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "request-42")
+
+	var got interface{}
+	emitter.On("job.run", func(e Event) error {
+		got = e.(*BaseEvent).Context().Value(key{})
+		return nil
+	})
+
+	emitter.EmitSyncWithContext(ctx, "job.run", nil)
+
+	if got != "request-42" {
+		t.Errorf("Event context value = %v, want request-42", got)
+	}
+}
+
+func TestWithEmissionTimeout(t *testing.T) {
+	emitter := NewMemoryEmitter(WithEmissionTimeout(20 * time.Millisecond))
+
+	emitter.On("job.run", func(e Event) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}, WithPriority(High))
+	emitter.On("job.run", func(e Event) error {
+		t.Error("Listener after the timeout should not be called")
+		return nil
+	}, WithPriority(Low))
+
+	errs := emitter.EmitSync("job.run", nil)
+	if len(errs) != 1 || !errors.Is(errs[0], context.DeadlineExceeded) {
+		t.Errorf("EmitSync() errors = %v, want a single %v", errs, context.DeadlineExceeded)
+	}
+}
+
+func TestWithListenerBudget(t *testing.T) {
+	emitter := NewMemoryEmitter(WithListenerBudget(3))
+
+	calls := 0
+	listener := func(e Event) error {
+		calls++
+		return nil
+	}
+	for i := 0; i < 2; i++ {
+		emitter.On("metrics.cpu", listener)
+		emitter.On("metrics.*", listener)
+	}
+
+	errs := emitter.EmitSync("metrics.cpu", nil)
+
+	if calls != 3 {
+		t.Errorf("Listeners called %d times, want 3", calls)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrListenerBudgetExceeded) {
+		t.Errorf("EmitSync() errors = %v, want a single %v", errs, ErrListenerBudgetExceeded)
+	}
+}
+
+func TestWithChainLengthWarning(t *testing.T) {
+	var warnings []int
+	emitter := NewMemoryEmitter(WithChainLengthWarning(2, func(topic string, length int) {
+		if topic != "busy.topic" {
+			t.Errorf("Warning topic = %q, want busy.topic", topic)
+		}
+		warnings = append(warnings, length)
+	}))
+
+	for i := 0; i < 4; i++ {
+		emitter.On("busy.topic", func(e Event) error { return nil })
+	}
+
+	if len(warnings) != 2 || warnings[0] != 3 || warnings[1] != 4 {
+		t.Errorf("Chain length warnings = %v, want [3 4]", warnings)
+	}
+}
+
+func TestNewEmissionWithoutCheckpoints(t *testing.T) {
+	if em := newEmission(context.Background(), 0, time.Time{}, nil); em != nil {
+		t.Error("newEmission() should return nil when there is nothing to check")
+	}
+	if stop, err := (*emission)(nil).checkpoint(); stop || err != nil {
+		t.Errorf("checkpoint() on nil emission = (%v, %v), want (false, nil)", stop, err)
+	}
+}
+
+// manualClock is a Clock whose time is set by the test.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestEventTTL(t *testing.T) {
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	emitter := NewMemoryEmitter(WithClock(clock))
+
+	var calls []string
+	emitter.On("cache.invalidate", func(e Event) error {
+		calls = append(calls, "slow")
+		clock.advance(2 * time.Second) // The event expires while this listener runs.
+		return nil
+	}, WithPriority(High))
+	emitter.On("cache.invalidate", func(e Event) error {
+		calls = append(calls, "late")
+		return nil
+	})
+
+	event := NewEvent("cache.invalidate").WithTTL(time.Second)
+	errs := emitter.EmitEventSync(event)
+
+	if len(calls) != 1 || calls[0] != "slow" {
+		t.Errorf("Listeners called = %v, want only the first one", calls)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrEventExpired) {
+		t.Errorf("EmitEventSync() errors = %v, want [%v]", errs, ErrEventExpired)
+	}
+	if want := clock.now.Add(-2 * time.Second).Add(time.Second); !event.ExpiresAt().Equal(want) {
+		t.Errorf("ExpiresAt() = %v, want %v", event.ExpiresAt(), want)
+	}
+
+	// Emitting with a TTL that has not elapsed delivers to every listener.
+	calls = nil
+	if errs := emitter.EmitEventSync(NewEvent("cache.invalidate").WithTTL(time.Minute)); len(errs) != 0 {
+		t.Errorf("EmitEventSync() errors = %v, want none", errs)
+	}
+	if len(calls) != 2 {
+		t.Errorf("Listeners called = %v, want both", calls)
+	}
+}
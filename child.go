@@ -0,0 +1,155 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+)
+
+// maxPropagationDepth bounds how many emitters an event may travel through, guarding
+// against cycles when the parent chain contains emitters that cannot carry a trail.
+const maxPropagationDepth = 32
+
+// ChildEmitter is a MemoryEmitter whose events also propagate to a parent Emitter. It
+// enables component-local buses that still feed an application-wide bus. Optionally,
+// events emitted on the parent can be delivered down to the child as well.
+type ChildEmitter struct {
+	*MemoryEmitter
+	parent      Emitter
+	upFilter    func(Event) bool // Decides which child events reach the parent.
+	downFilter  func(Event) bool // Decides which parent events reach the child.
+	downward    bool             // Whether parent events are delivered to the child.
+	downID      string           // ID of the listener registered on the parent for downward delivery.
+	emitterOpts []EmitterOption
+}
+
+// ChildOption defines a function type for ChildEmitter configuration options.
+type ChildOption func(*ChildEmitter)
+
+// WithEmitterOptions applies regular emitter options to the child's own MemoryEmitter.
+func WithEmitterOptions(opts ...EmitterOption) ChildOption {
+	return func(c *ChildEmitter) {
+		c.emitterOpts = append(c.emitterOpts, opts...)
+	}
+}
+
+// WithUpwardFilter restricts which events emitted on the child propagate to the parent.
+func WithUpwardFilter(filter func(Event) bool) ChildOption {
+	return func(c *ChildEmitter) {
+		c.upFilter = filter
+	}
+}
+
+// WithDownwardPropagation delivers events emitted on the parent to the child's listeners.
+// A nil filter forwards every event.
+func WithDownwardPropagation(filter func(Event) bool) ChildOption {
+	return func(c *ChildEmitter) {
+		c.downward = true
+		c.downFilter = filter
+	}
+}
+
+// NewChildEmitter creates a ChildEmitter attached to the given parent. Events emitted on
+// the child are dispatched to its own listeners first and then to the parent, unless they
+// were aborted. Events delivered down from the parent are never sent back up, and events
+// originating from the child are not delivered back to it. With downward propagation,
+// it fails if the parent refuses the listener forwarding its events, for example with
+// ErrEmitterClosed.
+func NewChildEmitter(parent Emitter, opts ...ChildOption) (*ChildEmitter, error) {
+	c := &ChildEmitter{parent: parent}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.MemoryEmitter = NewMemoryEmitter(c.emitterOpts...)
+	c.MemoryEmitter.propagate = c.propagateUp
+
+	if c.downward {
+		id, err := parent.On(MultiWildcard, c.receiveFromParent, WithPriority(Lowest), withEnvelope())
+		if err != nil {
+			return nil, err
+		}
+		c.downID = id
+	}
+
+	return c, nil
+}
+
+// Parent returns the emitter this child propagates to.
+func (c *ChildEmitter) Parent() Emitter {
+	return c.parent
+}
+
+// propagateUp forwards an event dispatched on the child to the parent.
+func (c *ChildEmitter) propagateUp(event *BaseEvent, errorHandler func(error)) {
+	if len(event.trail) >= maxPropagationDepth {
+		return
+	}
+	if c.upFilter != nil && !c.upFilter(event.listenerEvent()) {
+		return
+	}
+
+	var errs []error
+	switch forwarder, ok := c.parent.(eventForwarder); {
+	case ok:
+		errs = forwarder.forwardEvent(event)
+	case event.custom != nil:
+		errs = c.parent.EmitEventSync(event.custom)
+	default:
+		errs = c.parent.EmitSync(event.Topic(), event.Args()...)
+	}
+
+	if errorHandler == nil {
+		return
+	}
+	for _, err := range errs {
+		errorHandler(err)
+	}
+}
+
+// receiveFromParent is registered on the parent to deliver its events to the child.
+func (c *ChildEmitter) receiveFromParent(evt Event) error {
+	if c.closed.Load().(bool) {
+		return nil
+	}
+
+	var event *BaseEvent
+	ctx := context.Background()
+	if parentEvent, ok := evt.(*BaseEvent); ok {
+		if parentEvent.visited(c.MemoryEmitter) || len(parentEvent.trail) >= maxPropagationDepth {
+			return nil // The event originated here; do not deliver it twice.
+		}
+		event = parentEvent.forwardedCopy()
+		ctx = parentEvent.Context()
+	} else {
+		event = toBaseEvent(evt)
+		ctx = event.Context()
+	}
+	if cancel := c.prepareEmission(ctx, event); cancel != nil {
+		defer cancel()
+	}
+
+	if c.downFilter != nil && !c.downFilter(event.listenerEvent()) {
+		return nil
+	}
+
+	// Dispatch locally only: events received from the parent never travel back up.
+	var errs []error
+	c.MemoryEmitter.dispatch(event, func(err error) {
+		errs = append(errs, err)
+	})
+	return errors.Join(errs...)
+}
+
+// Close detaches the child from its parent and closes the child's own emitter.
+func (c *ChildEmitter) Close() error {
+	return c.CloseWithContext(context.Background())
+}
+
+// CloseWithContext behaves like Close, with the child's own emitter closed by
+// MemoryEmitter.CloseWithContext.
+func (c *ChildEmitter) CloseWithContext(ctx context.Context) error {
+	if c.downID != "" {
+		_ = c.parent.Off(MultiWildcard, c.downID)
+	}
+	return c.MemoryEmitter.CloseWithContext(ctx)
+}
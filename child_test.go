@@ -0,0 +1,206 @@
+package emitter
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestChildEmitterPropagatesToParent(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent)
+
+	var childCalls, parentCalls int32
+	child.On("user.created", func(e Event) error {
+		atomic.AddInt32(&childCalls, 1)
+		return nil
+	})
+	parent.On("user.*", func(e Event) error {
+		atomic.AddInt32(&parentCalls, 1)
+		if e.Payload() != "jane" {
+			t.Errorf("Parent received payload %v, want jane", e.Payload())
+		}
+		return nil
+	})
+
+	if errs := child.EmitSync("user.created", "jane"); len(errs) != 0 {
+		t.Fatalf("EmitSync() returned errors: %v", errs)
+	}
+	for range child.Emit("user.created", "jane") {
+	}
+
+	if got := atomic.LoadInt32(&childCalls); got != 2 {
+		t.Errorf("Child listener called %d times, want 2", got)
+	}
+	if got := atomic.LoadInt32(&parentCalls); got != 2 {
+		t.Errorf("Parent listener called %d times, want 2", got)
+	}
+}
+
+func TestChildEmitterParentEventsStayInParent(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent)
+
+	child.On("user.created", func(e Event) error {
+		t.Error("Child listener should not receive parent events without downward propagation")
+		return nil
+	})
+
+	parent.EmitSync("user.created", "jane")
+}
+
+func TestChildEmitterUpwardFilter(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent, WithUpwardFilter(func(e Event) bool {
+		return e.Topic() != "internal.tick"
+	}))
+
+	var parentCalls int32
+	parent.On("**", func(e Event) error {
+		atomic.AddInt32(&parentCalls, 1)
+		return nil
+	})
+
+	child.EmitSync("internal.tick", nil)
+	child.EmitSync("user.created", nil)
+
+	if got := atomic.LoadInt32(&parentCalls); got != 1 {
+		t.Errorf("Parent listener called %d times, want 1", got)
+	}
+}
+
+func TestChildEmitterAbortStopsPropagation(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent)
+
+	child.On("order.created", func(e Event) error {
+		e.SetAborted(true)
+		return nil
+	})
+	parent.On("order.created", func(e Event) error {
+		t.Error("Aborted child events should not reach the parent")
+		return nil
+	})
+
+	child.EmitSync("order.created", nil)
+}
+
+func TestChildEmitterDownwardPropagationWithLoopProtection(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent, WithDownwardPropagation(nil))
+	sibling, _ := NewChildEmitter(parent, WithDownwardPropagation(func(e Event) bool {
+		return e.Topic() == "config.updated"
+	}))
+
+	var childCalls, parentCalls, siblingCalls int32
+	child.On("config.updated", func(e Event) error {
+		atomic.AddInt32(&childCalls, 1)
+		return nil
+	})
+	parent.On("config.updated", func(e Event) error {
+		atomic.AddInt32(&parentCalls, 1)
+		return nil
+	})
+	sibling.On("**", func(e Event) error {
+		atomic.AddInt32(&siblingCalls, 1)
+		return nil
+	})
+
+	// Parent events flow down to both children.
+	parent.EmitSync("config.updated", nil)
+	// Child events flow up once and across to the sibling, but never back to the child.
+	child.EmitSync("config.updated", nil)
+	// The sibling's downward filter rejects other topics.
+	parent.EmitSync("config.deleted", nil)
+
+	if got := atomic.LoadInt32(&childCalls); got != 2 {
+		t.Errorf("Child listener called %d times, want 2", got)
+	}
+	if got := atomic.LoadInt32(&parentCalls); got != 2 {
+		t.Errorf("Parent listener called %d times, want 2", got)
+	}
+	if got := atomic.LoadInt32(&siblingCalls); got != 2 {
+		t.Errorf("Sibling listener called %d times, want 2", got)
+	}
+}
+
+func TestChildEmitterNestedChain(t *testing.T) {
+	root := NewMemoryEmitter()
+	middle, _ := NewChildEmitter(root, WithDownwardPropagation(nil))
+	leaf, _ := NewChildEmitter(middle, WithDownwardPropagation(nil))
+
+	var rootCalls, middleCalls, leafCalls int32
+	root.On("ping", func(e Event) error { atomic.AddInt32(&rootCalls, 1); return nil })
+	middle.On("ping", func(e Event) error { atomic.AddInt32(&middleCalls, 1); return nil })
+	leaf.On("ping", func(e Event) error { atomic.AddInt32(&leafCalls, 1); return nil })
+
+	leaf.EmitSync("ping", nil)
+
+	for name, calls := range map[string]*int32{"root": &rootCalls, "middle": &middleCalls, "leaf": &leafCalls} {
+		if got := atomic.LoadInt32(calls); got != 1 {
+			t.Errorf("%s listener called %d times, want 1", name, got)
+		}
+	}
+}
+
+func TestChildEmitterReportsParentErrors(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent)
+
+	parentErr := errors.New("parent failure")
+	parent.On("job.done", func(e Event) error { return parentErr })
+
+	errs := child.EmitSync("job.done", nil)
+	if len(errs) != 1 || !errors.Is(errs[0], parentErr) {
+		t.Errorf("EmitSync() errors = %v, want [%v]", errs, parentErr)
+	}
+}
+
+func TestChildEmitterClose(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent, WithDownwardPropagation(nil))
+
+	if err := child.Close(); err != nil {
+		t.Fatalf("Close() failed with error: %v", err)
+	}
+
+	topic, err := parent.GetTopic(MultiWildcard)
+	if err != nil {
+		t.Fatalf("GetTopic() failed with error: %v", err)
+	}
+	if len(topic.listeners) != 0 {
+		t.Errorf("Close() should remove the downward listener from the parent, %d remain", len(topic.listeners))
+	}
+}
+
+func TestChildEmitterPropagatesEventEnvelope(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent)
+
+	var tenant interface{}
+	var priority Priority
+	parent.On("job.done", func(e Event) error {
+		base := e.(*BaseEvent)
+		tenant, _ = base.MetadataValue("tenant")
+		priority = base.Priority()
+		return nil
+	})
+
+	child.EmitEventSync(NewEvent("job.done").WithMetadata("tenant", "acme").WithPriority(High))
+
+	if tenant != "acme" || priority != High {
+		t.Errorf("Parent saw (%v, %v), want (acme, high)", tenant, priority)
+	}
+}
+
+func TestChildEmitterOnClosedParent(t *testing.T) {
+	parent := NewMemoryEmitter()
+	parent.Close()
+
+	if _, err := NewChildEmitter(parent); err != nil {
+		t.Errorf("NewChildEmitter() without downward propagation error = %v, want nil", err)
+	}
+	if child, err := NewChildEmitter(parent, WithDownwardPropagation(nil)); !errors.Is(err, ErrEmitterClosed) || child != nil {
+		t.Errorf("NewChildEmitter() = %v, %v, want nil and %v", child, err, ErrEmitterClosed)
+	}
+}
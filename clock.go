@@ -0,0 +1,61 @@
+package emitter
+
+import "time"
+
+// Clock abstracts time so that time-based behavior, such as event timestamps and
+// delayed emissions, can be driven by a virtual clock in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// AfterFunc calls f in its own goroutine once the duration has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a handle to a function scheduled with Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the function from running. It reports whether the call stopped it.
+	Stop() bool
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+// RealClock returns the Clock backed by the system time.
+func RealClock() Clock {
+	return realClock{}
+}
+
+// Now returns time.Now().
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc wraps time.AfterFunc.
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// EmitAfter schedules an asynchronous emission once the delay has elapsed on the
+// emitter's clock. The returned Timer can be used to cancel it, and closing the emitter
+// cancels it as well.
+//
+// With a ScheduleStore, the emission is saved to the store until it is emitted or
+// canceled, and closing the emitter leaves it there for RestoreScheduled. Emissions
+// whose payload cannot be encoded as JSON, or that the store fails to save, are kept in
+// memory only, and the error is passed to the error handler.
+func (m *MemoryEmitter) EmitAfter(delay time.Duration, eventName string, args ...interface{}) Timer {
+	if m.schedules.store != nil {
+		if t, ok := m.persistScheduled(delay, eventName, args); ok {
+			return t
+		}
+	}
+	return m.afterFunc(delay, func() {
+		m.Emit(eventName, args...)
+	})
+}
+
+// EmitAt schedules an asynchronous emission at the given time on the emitter's clock.
+func (m *MemoryEmitter) EmitAt(at time.Time, eventName string, args ...interface{}) Timer {
+	return m.EmitAfter(at.Sub(m.clock.Now()), eventName, args...)
+}
@@ -0,0 +1,144 @@
+package emitter
+
+import "reflect"
+
+// Cloneable is implemented by events that know how to copy themselves. Listeners
+// registered with WithClonedEvent receive a copy made with Clone, so custom Event
+// implementations should provide it when their state cannot be copied generically.
+type Cloneable interface {
+	Clone() Event
+}
+
+// CloneEvent returns an independent copy of evt. Events implementing Cloneable are
+// copied with Clone, a *BaseEvent is copied together with its payload and arguments,
+// and any other event is returned as is because it cannot be copied safely.
+func CloneEvent(evt Event) Event {
+	switch e := evt.(type) {
+	case Cloneable:
+		return e.Clone()
+	case *readOnlyEvent:
+		return newReadOnlyEvent(CloneEvent(e.Event))
+	default:
+		return evt
+	}
+}
+
+// Clone returns a copy of the event whose payload, arguments and metadata are deep
+// copied, so that listeners can change them without affecting other listeners. The
+// copy keeps the ID, timestamp, priority, expiry, abort status and context of the
+// event but is not tied to the emission that produced it.
+func (e *BaseEvent) Clone() Event {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	clone := &BaseEvent{
+		id:        e.id,
+		timestamp: e.timestamp,
+		topic:     e.topic,
+		payload:   deepCopy(e.payload),
+		priority:  e.priority,
+		ttl:       e.ttl,
+		expiresAt: e.expiresAt,
+		aborted:   e.aborted,
+		ctx:       e.ctx,
+		running:   e.running,
+		current:   e.current,
+		currentOn: e.currentOn,
+		retainSeq: e.retainSeq,
+	}
+	if e.args != nil {
+		clone.args = make([]interface{}, len(e.args))
+		for i := range e.args {
+			if i == 0 {
+				clone.args[i] = clone.payload // Shared with the payload, like in the original.
+				continue
+			}
+			clone.args[i] = deepCopy(e.args[i])
+		}
+	}
+	if e.metadata != nil {
+		clone.metadata = deepCopy(e.metadata).(map[string]interface{})
+	}
+	if e.custom != nil {
+		clone.custom = CloneEvent(e.custom)
+	}
+	return clone
+}
+
+// deepCopy returns a copy of v in which pointers, slices, maps and interfaces are
+// followed and copied recursively. Values that cannot be copied through reflection,
+// such as unexported struct fields, channels and functions, are shared with v.
+func deepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	copied := copyValue(reflect.ValueOf(v), make(map[uintptr]reflect.Value))
+	return copied.Interface()
+}
+
+// copyValue copies v recursively. Pointers that were already copied are looked up in
+// seen so that shared and cyclic references keep their shape in the copy.
+func copyValue(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		if copied, ok := seen[v.Pointer()]; ok {
+			return copied
+		}
+		copied := reflect.New(v.Elem().Type())
+		seen[v.Pointer()] = copied
+		copied.Elem().Set(copyValue(v.Elem(), seen))
+		return copied
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(copyValue(v.Elem(), seen))
+		return copied
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+		return copied
+
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+		return copied
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), copyValue(iter.Value(), seen))
+		}
+		return copied
+
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v) // Unexported fields are copied shallowly.
+		for i := 0; i < v.NumField(); i++ {
+			if field := copied.Field(i); field.CanSet() {
+				field.Set(copyValue(v.Field(i), seen))
+			}
+		}
+		return copied
+
+	default:
+		return v
+	}
+}
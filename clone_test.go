@@ -0,0 +1,145 @@
+package emitter
+
+import "testing"
+
+type cloneOrder struct {
+	ID    string
+	Items []string
+	Tags  map[string]string
+	Owner *cloneOwner
+	note  string
+}
+
+type cloneOwner struct {
+	Name string
+}
+
+// versionedEvent is a custom event that copies itself with Clone.
+type versionedEvent struct {
+	auditEvent
+	version int
+}
+
+func (e *versionedEvent) Clone() Event {
+	clone := *e
+	clone.version++
+	return &clone
+}
+
+func TestBaseEventClone(t *testing.T) {
+	order := &cloneOrder{
+		ID:    "o-1",
+		Items: []string{"book"},
+		Tags:  map[string]string{"channel": "web"},
+		Owner: &cloneOwner{Name: "Jane"},
+		note:  "gift",
+	}
+	event := NewEvent("order.created").WithPayload(order).WithMetadata("tenant", "acme").WithPriority(High)
+	event.id = "evt-1"
+
+	clone, ok := event.Clone().(*BaseEvent)
+	if !ok {
+		t.Fatal("Clone of a BaseEvent should be a *BaseEvent")
+	}
+	copied := clone.Payload().(*cloneOrder)
+	copied.Items[0] = "pen"
+	copied.Tags["channel"] = "store"
+	copied.Owner.Name = "John"
+	clone.WithMetadata("tenant", "other")
+
+	if order.Items[0] != "book" || order.Tags["channel"] != "web" || order.Owner.Name != "Jane" {
+		t.Errorf("Changing the clone's payload changed the original: %+v", order)
+	}
+	if copied.note != "gift" {
+		t.Errorf("Unexported field = %q, want gift", copied.note)
+	}
+	if value, _ := event.MetadataValue("tenant"); value != "acme" {
+		t.Errorf("Original metadata = %v, want acme", value)
+	}
+	if clone.ID() != "evt-1" || clone.Priority() != High || clone.Topic() != "order.created" {
+		t.Error("Clone should keep the envelope of the event")
+	}
+}
+
+func TestBaseEventCloneArgs(t *testing.T) {
+	event := newArgsEvent("user.banned", []interface{}{[]int{1}, "spam"})
+
+	clone := event.Clone()
+	clone.(*BaseEvent).Args()[0].([]int)[0] = 2
+
+	if event.Args()[0].([]int)[0] != 1 {
+		t.Error("Changing the clone's arguments changed the original")
+	}
+	if reason, _ := Arg[string](clone, 1); reason != "spam" {
+		t.Errorf("Clone argument = %v, want spam", reason)
+	}
+}
+
+func TestDeepCopyCycle(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	n := &node{}
+	n.Next = n
+
+	copied := deepCopy(n).(*node)
+	if copied == n || copied.Next != copied {
+		t.Error("Cyclic references should be copied with the same shape")
+	}
+}
+
+func TestCloneEventCustom(t *testing.T) {
+	event := &versionedEvent{version: 1}
+	if clone := CloneEvent(event).(*versionedEvent); clone.version != 2 {
+		t.Errorf("Clone version = %d, want 2", clone.version)
+	}
+
+	plain := &auditEvent{action: "login"}
+	if CloneEvent(plain) != Event(plain) {
+		t.Error("Events that are not Cloneable should be returned as is")
+	}
+}
+
+func TestWithClonedEvent(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	emitter.On("order.created", func(e Event) error {
+		e.Payload().(*cloneOrder).Items[0] = "tampered"
+		e.SetPayload(nil)
+		return nil
+	}, WithPriority(High), WithClonedEvent())
+
+	var seen *cloneOrder
+	emitter.On("order.created", func(e Event) error {
+		seen, _ = e.Payload().(*cloneOrder)
+		return nil
+	}, WithPriority(Low))
+
+	emitter.EmitSync("order.created", &cloneOrder{Items: []string{"book"}})
+
+	if seen == nil || seen.Items[0] != "book" {
+		t.Errorf("Downstream listener saw %+v, want the untouched order", seen)
+	}
+}
+
+func TestWithClonedEventAbort(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	emitter.On("order.created", func(e Event) error {
+		e.SetAborted(true)
+		return nil
+	}, WithPriority(High), WithClonedEvent())
+
+	called := false
+	emitter.On("order.created", func(e Event) error {
+		called = true
+		return nil
+	}, WithPriority(Low))
+
+	event := NewEvent("order.created").WithPayload("order")
+	emitter.EmitEventSync(event)
+
+	if called || !event.IsAborted() {
+		t.Error("Aborting a cloned event should stop the original event")
+	}
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	// handlersDirective marks a struct whose annotated methods are wired by the generator.
+	handlersDirective = "//emitter:handlers"
+	// onDirective marks a method as the handler of a topic: //emitter:on <topic> [priority=<name>].
+	onDirective = "//emitter:on"
+	// emitterImportPath is the import path of the emitter package.
+	emitterImportPath = "github.com/kaptinlin/emitter"
+)
+
+// handlerStruct describes a struct annotated with //emitter:handlers.
+type handlerStruct struct {
+	Name     string
+	Handlers []handlerMethod
+}
+
+// handlerMethod describes a method annotated with //emitter:on.
+type handlerMethod struct {
+	Method      string
+	Topic       string
+	Priority    string // Name of the emitter priority constant, e.g. "High".
+	PayloadType string // Empty when the method takes the raw emitter.Event.
+	WithEvent   bool   // Whether the method receives the event before the payload.
+}
+
+// packageSpec is everything the generator needs to render a package's wiring.
+type packageSpec struct {
+	Package      string
+	StdImports   []string // Standard library imports referenced by payload types.
+	OtherImports []string // Third-party imports referenced by payload types.
+	Structs      []handlerStruct
+}
+
+// generate parses the Go files in dir and returns the formatted wiring code, or nil
+// when the package has no annotated handler structs.
+func generate(dir string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var pkgName string
+	files := map[string]*ast.File{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_gen.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if pkgName != "" && file.Name.Name != pkgName {
+			return nil, fmt.Errorf("found packages %s and %s in %s", pkgName, file.Name.Name, dir)
+		}
+		pkgName = file.Name.Name
+		files[name] = file
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files found in %s", dir)
+	}
+
+	spec, err := collect(fset, pkgName, files)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.Structs) == 0 {
+		return nil, nil
+	}
+	return render(spec)
+}
+
+// collect walks the package AST and gathers the annotated structs and methods.
+func collect(fset *token.FileSet, pkgName string, files map[string]*ast.File) (*packageSpec, error) {
+	spec := &packageSpec{Package: pkgName}
+	structs := map[string]*handlerStruct{}
+	imports := map[string]bool{}
+
+	fileNames := make([]string, 0, len(files))
+	for name := range files {
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+
+	// First pass: find annotated structs.
+	for _, name := range fileNames {
+		for _, decl := range files[name].Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gen.Specs {
+				typeSpec := s.(*ast.TypeSpec)
+				if _, isStruct := typeSpec.Type.(*ast.StructType); !isStruct {
+					continue
+				}
+				if hasDirective(gen.Doc, handlersDirective) || hasDirective(typeSpec.Doc, handlersDirective) {
+					structs[typeSpec.Name.Name] = &handlerStruct{Name: typeSpec.Name.Name}
+				}
+			}
+		}
+	}
+
+	// Second pass: find annotated methods on those structs.
+	for _, name := range fileNames {
+		file := files[name]
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Doc == nil {
+				continue
+			}
+			directive := findDirective(fn.Doc, onDirective)
+			if directive == "" {
+				continue
+			}
+			position := fset.Position(fn.Pos())
+
+			recv := receiverName(fn.Recv.List[0].Type)
+			target, ok := structs[recv]
+			if !ok {
+				return nil, fmt.Errorf("%s: method %s.%s is annotated but %s is not marked with %s", position, recv, fn.Name.Name, recv, handlersDirective)
+			}
+
+			handler, err := parseHandler(fset, file, fn, directive, imports)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", position, err)
+			}
+			target.Handlers = append(target.Handlers, handler)
+		}
+	}
+
+	names := make([]string, 0, len(structs))
+	for name := range structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if len(structs[name].Handlers) > 0 {
+			spec.Structs = append(spec.Structs, *structs[name])
+		}
+	}
+
+	for path := range imports {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			spec.OtherImports = append(spec.OtherImports, path)
+		} else {
+			spec.StdImports = append(spec.StdImports, path)
+		}
+	}
+	sort.Strings(spec.StdImports)
+	sort.Strings(spec.OtherImports)
+	return spec, nil
+}
+
+// parseHandler validates an annotated method and describes how to adapt it to a Listener.
+func parseHandler(fset *token.FileSet, file *ast.File, fn *ast.FuncDecl, directive string, imports map[string]bool) (handlerMethod, error) {
+	fields := strings.Fields(directive)
+	if len(fields) == 0 {
+		return handlerMethod{}, errors.New("missing topic in " + onDirective + " directive")
+	}
+
+	handler := handlerMethod{Method: fn.Name.Name, Topic: fields[0], Priority: "Normal"}
+	for _, option := range fields[1:] {
+		key, value, _ := strings.Cut(option, "=")
+		if key != "priority" {
+			return handlerMethod{}, fmt.Errorf("unknown option %q in %s directive", key, onDirective)
+		}
+		priority, ok := priorityConstants[strings.ToLower(value)]
+		if !ok {
+			return handlerMethod{}, fmt.Errorf("invalid priority %q", value)
+		}
+		handler.Priority = priority
+	}
+
+	if fn.Type.Results == nil || len(fn.Type.Results.List) != 1 || exprString(fset, fn.Type.Results.List[0].Type) != "error" {
+		return handlerMethod{}, fmt.Errorf("method %s must return a single error", fn.Name.Name)
+	}
+
+	var params []ast.Expr
+	for _, field := range fn.Type.Params.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			params = append(params, field.Type)
+		}
+	}
+
+	emitterName := importName(file, emitterImportPath)
+	isEvent := func(expr ast.Expr) bool {
+		return emitterName != "" && exprString(fset, expr) == emitterName+".Event"
+	}
+
+	switch {
+	case len(params) == 1 && isEvent(params[0]):
+		// func(emitter.Event) error: no adapter needed beyond the method value.
+	case len(params) == 1:
+		handler.PayloadType = exprString(fset, params[0])
+		collectImports(file, params[0], imports)
+	case len(params) == 2 && isEvent(params[0]):
+		handler.WithEvent = true
+		handler.PayloadType = exprString(fset, params[1])
+		collectImports(file, params[1], imports)
+	default:
+		return handlerMethod{}, fmt.Errorf("method %s must take (emitter.Event), (Payload) or (emitter.Event, Payload)", fn.Name.Name)
+	}
+
+	return handler, nil
+}
+
+// priorityConstants maps priority names to the emitter package constants.
+var priorityConstants = map[string]string{
+	"lowest":  "Lowest",
+	"low":     "Low",
+	"normal":  "Normal",
+	"high":    "High",
+	"highest": "Highest",
+}
+
+// hasDirective reports whether the comment group contains the directive line.
+func hasDirective(doc *ast.CommentGroup, directive string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// findDirective returns the arguments of the directive, or "" when it is absent.
+func findDirective(doc *ast.CommentGroup, directive string) string {
+	for _, c := range doc.List {
+		if rest, ok := strings.CutPrefix(c.Text, directive+" "); ok {
+			return strings.TrimSpace(rest)
+		}
+	}
+	return ""
+}
+
+// receiverName returns the type name of a method receiver.
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// importName returns the name under which the file imports path, or "" if it does not.
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if strings.Trim(spec.Path.Value, `"`) != path {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return filepath.Base(path)
+	}
+	return ""
+}
+
+// collectImports records the imports referenced by a payload type expression.
+func collectImports(file *ast.File, expr ast.Expr, imports map[string]bool) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, spec := range file.Imports {
+			path := strings.Trim(spec.Path.Value, `"`)
+			name := filepath.Base(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name == ident.Name && path != emitterImportPath {
+				imports[path] = true
+			}
+		}
+		return false
+	})
+}
+
+// exprString prints an expression as Go source.
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+// render executes the output template and formats the result.
+func render(spec *packageSpec) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := outputTemplate.Execute(&buf, spec); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+var outputTemplate = template.Must(template.New("emittergen").Funcs(template.FuncMap{
+	"lowerFirst": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToLower(s[:1]) + s[1:]
+	},
+}).Parse(`// Code generated by emittergen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+
+	"github.com/kaptinlin/emitter"
+{{- range .OtherImports}}
+	"{{.}}"
+{{- end}}
+)
+{{range $s := .Structs}}
+// {{$s.Name}}Subscriptions tracks the listeners registered by Subscribe{{$s.Name}}.
+type {{$s.Name}}Subscriptions struct {
+	emitter emitter.Emitter
+	topics  []string
+	ids     []string
+}
+
+// Subscribe{{$s.Name}} registers the annotated handlers of h on the emitter. If any
+// registration fails, the handlers registered so far are removed again.
+func Subscribe{{$s.Name}}(e emitter.Emitter, h *{{$s.Name}}) (*{{$s.Name}}Subscriptions, error) {
+	subs := &{{$s.Name}}Subscriptions{emitter: e}
+{{- range $h := $s.Handlers}}
+	if err := subs.add({{printf "%q" $h.Topic}}, {{lowerFirst $s.Name}}{{$h.Method}}Listener(h), emitter.{{$h.Priority}}); err != nil {
+		_ = subs.Unsubscribe()
+		return nil, err
+	}
+{{- end}}
+	return subs, nil
+}
+
+// add registers a single listener and records its ID.
+func (s *{{$s.Name}}Subscriptions) add(topic string, listener emitter.Listener, priority emitter.Priority) error {
+	id, err := s.emitter.On(topic, listener, emitter.WithPriority(priority))
+	if err != nil {
+		return fmt.Errorf("subscribing to %q: %w", topic, err)
+	}
+	s.topics = append(s.topics, topic)
+	s.ids = append(s.ids, id)
+	return nil
+}
+
+// Unsubscribe removes every listener registered by Subscribe{{$s.Name}}.
+func (s *{{$s.Name}}Subscriptions) Unsubscribe() error {
+	var firstErr error
+	for i, id := range s.ids {
+		if err := s.emitter.Off(s.topics[i], id); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	s.topics, s.ids = nil, nil
+	return firstErr
+}
+{{range $h := $s.Handlers}}
+// {{lowerFirst $s.Name}}{{$h.Method}}Listener adapts {{$s.Name}}.{{$h.Method}} to an emitter.Listener for {{printf "%q" $h.Topic}}.
+func {{lowerFirst $s.Name}}{{$h.Method}}Listener(h *{{$s.Name}}) emitter.Listener {
+{{- if not $h.PayloadType}}
+	return h.{{$h.Method}}
+{{- else}}
+	return func(evt emitter.Event) error {
+		payload, ok := evt.Payload().({{$h.PayloadType}})
+		if !ok {
+			return fmt.Errorf("%w: topic %q expects {{$h.PayloadType}}, got %T", emitter.ErrPayloadTypeMismatch, evt.Topic(), evt.Payload())
+		}
+		return h.{{$h.Method}}({{if $h.WithEvent}}evt, {{end}}payload)
+	}
+{{- end}}
+}
+{{end}}
+{{- end}}`))
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMatchesGoldenOutput(t *testing.T) {
+	dir := filepath.Join("testdata", "billing")
+
+	got, err := generate(dir)
+	if err != nil {
+		t.Fatalf("generate() failed with error: %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join(dir, "emitter_handlers_gen.go"))
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generate() output differs from %s; regenerate it with:\n\tgo run ./cmd/emittergen -dir %s\n\ngot:\n%s", filepath.Join(dir, "emitter_handlers_gen.go"), dir, got)
+	}
+}
+
+func TestGenerateWithoutHandlers(t *testing.T) {
+	dir := writePackage(t, `package plain
+
+type Service struct{}
+`)
+
+	got, err := generate(dir)
+	if err != nil {
+		t.Fatalf("generate() failed with error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("generate() = %q, want nil output for a package without handlers", got)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := map[string]struct {
+		source string
+		want   string
+	}{
+		"unmarked struct": {
+			source: `package bad
+
+type Service struct{}
+
+//emitter:on thing.happened
+func (s *Service) OnThing(p string) error { return nil }
+`,
+			want: "is not marked with //emitter:handlers",
+		},
+		"missing error result": {
+			source: `package bad
+
+//emitter:handlers
+type Service struct{}
+
+//emitter:on thing.happened
+func (s *Service) OnThing(p string) {}
+`,
+			want: "must return a single error",
+		},
+		"too many parameters": {
+			source: `package bad
+
+//emitter:handlers
+type Service struct{}
+
+//emitter:on thing.happened
+func (s *Service) OnThing(a, b string) error { return nil }
+`,
+			want: "must take (emitter.Event), (Payload) or (emitter.Event, Payload)",
+		},
+		"invalid priority": {
+			source: `package bad
+
+//emitter:handlers
+type Service struct{}
+
+//emitter:on thing.happened priority=urgent
+func (s *Service) OnThing(p string) error { return nil }
+`,
+			want: `invalid priority "urgent"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := generate(writePackage(t, tt.source))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("generate() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodDirectiveWithEventOnly(t *testing.T) {
+	dir := writePackage(t, `package audit
+
+import "github.com/kaptinlin/emitter"
+
+//emitter:handlers
+type Auditor struct{}
+
+//emitter:on **
+func (a *Auditor) Record(evt emitter.Event) error { return nil }
+`)
+
+	got, err := generate(dir)
+	if err != nil {
+		t.Fatalf("generate() failed with error: %v", err)
+	}
+	if !bytes.Contains(got, []byte("return h.Record\n")) {
+		t.Errorf("generate() should bind event-only handlers directly, got:\n%s", got)
+	}
+}
+
+// writePackage writes a single-file package into a temporary directory.
+func writePackage(t *testing.T, source string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "source.go"), []byte(source), 0o644); err != nil {
+		t.Fatalf("writing source: %v", err)
+	}
+	return dir
+}
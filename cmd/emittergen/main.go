@@ -0,0 +1,50 @@
+// Command emittergen generates reflection-free subscription wiring for handler structs.
+//
+// Annotate a struct with //emitter:handlers and its methods with
+// //emitter:on <topic> [priority=<name>]. Methods may take the raw emitter.Event, a typed
+// payload, or both:
+//
+//	//emitter:handlers
+//	type Billing struct{}
+//
+//	//emitter:on order.created priority=high
+//	func (b *Billing) OnOrderCreated(order Order) error { ... }
+//
+// Running emittergen in the package directory, typically via
+//
+//	//go:generate go run github.com/kaptinlin/emitter/cmd/emittergen
+//
+// writes SubscribeBilling and BillingSubscriptions.Unsubscribe along with a typed
+// listener adapter per handler.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package to scan")
+	output := flag.String("output", "emitter_handlers_gen.go", "name of the generated file, relative to -dir")
+	flag.Parse()
+
+	if err := run(*dir, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "emittergen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the wiring for the package in dir and writes it to output.
+func run(dir, output string) error {
+	code, err := generate(dir)
+	if err != nil {
+		return err
+	}
+	if code == nil {
+		fmt.Fprintln(os.Stderr, "emittergen: no //emitter:handlers structs found in", dir)
+		return nil
+	}
+	return os.WriteFile(filepath.Join(dir, output), code, 0o644)
+}
@@ -0,0 +1,47 @@
+package billing
+
+import (
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// Order is the payload of order events.
+type Order struct {
+	ID string
+}
+
+//emitter:handlers
+type Handlers struct {
+	Created  int
+	Refunds  []time.Duration
+	Audited  []string
+	Payments int
+}
+
+//emitter:on order.created priority=high
+func (h *Handlers) OnOrderCreated(order Order) error {
+	h.Created++
+	return nil
+}
+
+//emitter:on order.refunded
+func (h *Handlers) OnOrderRefunded(evt emitter.Event, delay time.Duration) error {
+	h.Refunds = append(h.Refunds, delay)
+	return nil
+}
+
+//emitter:on order.* priority=lowest
+func (h *Handlers) Audit(evt emitter.Event) error {
+	h.Audited = append(h.Audited, evt.Topic())
+	return nil
+}
+
+//emitter:on payment.received
+func (h *Handlers) OnPayment(order *Order) error {
+	h.Payments++
+	return nil
+}
+
+// helper is not annotated and must be ignored.
+func (h *Handlers) helper() {}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// filter selects journal events by topic pattern and emission time.
+type filter struct {
+	topic string    // Topic pattern; empty selects every topic.
+	since time.Time // Earliest emission time, if set.
+	until time.Time // Emission time before which events must have been emitted, if set.
+}
+
+// parseFilter builds a filter from the command-line flags.
+func parseFilter(topic, since, until string) (filter, error) {
+	f := filter{topic: topic}
+	var err error
+	if since != "" {
+		if f.since, err = time.Parse(time.RFC3339Nano, since); err != nil {
+			return f, fmt.Errorf("invalid -since: %w", err)
+		}
+	}
+	if until != "" {
+		if f.until, err = time.Parse(time.RFC3339Nano, until); err != nil {
+			return f, fmt.Errorf("invalid -until: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// match reports whether the filter selects the envelope.
+func (f filter) match(env *emitter.Envelope) bool {
+	if f.topic != "" && !emitter.MatchTopic(f.topic, env.Topic) {
+		return false
+	}
+	if !f.since.IsZero() && env.Timestamp.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && !env.Timestamp.Before(f.until) {
+		return false
+	}
+	return true
+}
+
+// selectEvents copies the envelopes of the journal read from r that match the filter
+// to w, in journal format, and returns how many it copied.
+func selectEvents(r io.Reader, w io.Writer, f filter) (int, error) {
+	count := 0
+	err := emitter.ReadJournal(r, func(env *emitter.Envelope) error {
+		if !f.match(env) {
+			return nil
+		}
+		line, err := env.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const journal = `{"topic":"order.created","timestamp":"2024-05-01T09:00:00Z","contentType":"application/json","payload":"o-1"}
+{"topic":"user.created","timestamp":"2024-05-01T10:30:00Z","contentType":"application/json","payload":"jane"}
+{"topic":"order.created","timestamp":"2024-05-01T10:30:00Z","contentType":"application/json","payload":"o-2"}
+{"topic":"order.paid","timestamp":"2024-05-01T11:00:00Z","contentType":"application/json","payload":"o-2"}
+`
+
+func TestSelectEvents(t *testing.T) {
+	f, err := parseFilter("order.*", "2024-05-01T10:00:00Z", "2024-05-01T11:00:00Z")
+	if err != nil {
+		t.Fatalf("parseFilter() failed with error: %v", err)
+	}
+
+	var out bytes.Buffer
+	count, err := selectEvents(strings.NewReader(journal), &out, f)
+	if err != nil {
+		t.Fatalf("selectEvents() failed with error: %v", err)
+	}
+
+	want := `{"topic":"order.created","timestamp":"2024-05-01T10:30:00Z","contentType":"application/json","payload":"o-2"}` + "\n"
+	if count != 1 || out.String() != want {
+		t.Errorf("selectEvents() = %d events:\n%s\nwant:\n%s", count, out.String(), want)
+	}
+}
+
+func TestParseFilterInvalidTime(t *testing.T) {
+	if _, err := parseFilter("**", "yesterday", ""); err == nil || !strings.Contains(err.Error(), "-since") {
+		t.Errorf("parseFilter() = %v, want an invalid -since error", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Command emitterjournal inspects, filters and replays journals written by
+// emitter.Journal. Matching events are printed to stdout as newline-delimited JSON
+// envelopes, or re-emitted against an emitterdebug.IngestHandler with -replay:
+//
+//	emitterjournal -topic 'order.**' -since 2024-05-01T10:00:00Z events.ndjson
+//	emitterjournal -topic 'order.**' -replay http://localhost:6060/debug/ingest events.ndjson
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	topic := flag.String("topic", "**", "topic pattern of the events to select")
+	since := flag.String("since", "", "select events emitted at or after this RFC 3339 time")
+	until := flag.String("until", "", "select events emitted before this RFC 3339 time")
+	replay := flag.String("replay", "", "URL of an emitterdebug ingest endpoint to re-emit the selected events to")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: emitterjournal [flags] journal-file")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := parseFilter(*topic, *since, *until)
+	if err == nil {
+		err = run(flag.Arg(0), f, *replay)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "emitterjournal:", err)
+		os.Exit(1)
+	}
+}
+
+// run selects the events of the journal at path and prints or replays them.
+func run(path string, f filter, replayURL string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if replayURL == "" {
+		_, err := selectEvents(file, os.Stdout, f)
+		return err
+	}
+
+	var body bytes.Buffer
+	count, err := selectEvents(file, &body, f)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		fmt.Fprintln(os.Stderr, "emitterjournal: no events selected")
+		return nil
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Post(replayURL, "application/x-ndjson", &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	result, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("replay endpoint returned %s: %s", resp.Status, bytes.TrimSpace(result))
+	}
+	fmt.Fprintf(os.Stderr, "emitterjournal: replayed %d events: %s\n", count, bytes.TrimSpace(result))
+	return nil
+}
@@ -0,0 +1,51 @@
+// Command emitterwatch renders a live stream of events in the terminal during local
+// development. It reads from an emitterdebug.StreamHandler mounted in the application:
+//
+//	http.Handle("/debug/events", emitterdebug.NewStreamHandler(e))
+//
+// and prints one line per event, optionally filtered by topic pattern:
+//
+//	emitterwatch -url http://localhost:6060/debug/events -topic 'order.**'
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+func main() {
+	endpoint := flag.String("url", "http://localhost:6060/debug/events", "URL of the emitterdebug stream endpoint")
+	topic := flag.String("topic", "**", "topic pattern of the events to show")
+	limit := flag.Int("payload", 120, "maximum number of payload bytes to show per event; 0 shows everything")
+	flag.Parse()
+
+	if err := run(*endpoint, *topic, *limit); err != nil {
+		fmt.Fprintln(os.Stderr, "emitterwatch:", err)
+		os.Exit(1)
+	}
+}
+
+// run connects to the stream endpoint and renders its events until it ends.
+func run(endpoint, topic string, limit int) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("topic", topic)
+	u.RawQuery = query.Encode()
+
+	resp, err := http.Get(u.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("stream endpoint returned %s", resp.Status)
+	}
+
+	return watch(resp.Body, os.Stdout, watchOptions{topic: topic, payloadLimit: limit})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// maxLineSize bounds the size of a single streamed event.
+const maxLineSize = 4 << 20
+
+// watchOptions control which events are rendered and how.
+type watchOptions struct {
+	topic        string // Topic pattern of the events to show.
+	payloadLimit int    // Maximum number of payload bytes to show, if positive.
+}
+
+// watch renders each newline-delimited JSON envelope read from r as one line on w,
+// skipping events whose topic does not match the pattern.
+func watch(r io.Reader, w io.Writer, opts watchOptions) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var env emitter.Envelope
+		if err := json.Unmarshal(scanner.Bytes(), &env); err != nil {
+			fmt.Fprintf(w, "! unreadable event: %v\n", err)
+			continue
+		}
+		if opts.topic != "" && !emitter.MatchTopic(opts.topic, env.Topic) {
+			continue
+		}
+		if _, err := io.WriteString(w, formatEnvelope(&env, opts.payloadLimit)+"\n"); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// formatEnvelope renders an envelope as "time topic [id] payload".
+func formatEnvelope(env *emitter.Envelope, limit int) string {
+	line := env.Timestamp.Local().Format("15:04:05.000") + " " + env.Topic
+	if env.ID != "" {
+		line += " [" + env.ID + "]"
+	}
+
+	payload := string(env.Payload)
+	if env.ContentType != emitter.JSONContentType {
+		payload = fmt.Sprintf("<%d bytes %s>", len(env.Payload), env.ContentType)
+	}
+	if limit > 0 && len(payload) > limit {
+		payload = payload[:limit] + "…"
+	}
+	return line + " " + payload
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6e6, time.Local).UTC().Format(time.RFC3339Nano)
+	input := strings.Join([]string{
+		`{"id":"evt-1","topic":"order.created","timestamp":"` + at + `","contentType":"application/json","payload":{"id":"o-1"}}`,
+		`{"topic":"user.created","timestamp":"` + at + `","contentType":"application/json","payload":"jane"}`,
+		`not json`,
+		`{"topic":"order.paid","timestamp":"` + at + `","contentType":"application/octet-stream","payload":"AAEC"}`,
+		`{"topic":"order.shipped","timestamp":"` + at + `","contentType":"application/json","payload":"0123456789"}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := watch(strings.NewReader(input), &out, watchOptions{topic: "order.*", payloadLimit: 8}); err != nil {
+		t.Fatalf("watch() failed with error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		`03:04:05.006 order.created [evt-1] {"id":"o…`,
+		`! unreadable event: invalid character 'o' in literal null (expecting 'u')`,
+		`03:04:05.006 order.paid <3 bytes…`,
+		`03:04:05.006 order.shipped "0123456…`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("watch() rendered %d lines, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+// auditEvent is a custom Event implementation with its own metadata and abort state.
+type auditEvent struct {
+	action  string
+	actor   string
+	aborted bool
+}
+
+func (e *auditEvent) Topic() string            { return "audit." + e.action }
+func (e *auditEvent) Payload() interface{}     { return e.actor }
+func (e *auditEvent) SetPayload(p interface{}) { e.actor, _ = p.(string) }
+func (e *auditEvent) SetAborted(abort bool)    { e.aborted = abort }
+func (e *auditEvent) IsAborted() bool          { return e.aborted }
+func (e *auditEvent) ID() string               { return "audit-1" }
+func (e *auditEvent) Metadata() map[string]interface{} {
+	return map[string]interface{}{"source": "admin-ui"}
+}
+
+func TestEmitCustomEvent(t *testing.T) {
+	var handled Event
+	emitter := NewMemoryEmitter(WithErrorHandler(func(e Event, err error) error {
+		handled = e
+		return err
+	}))
+
+	var received *auditEvent
+	var source interface{}
+	var calls int
+	emitter.On("audit.*", func(e Event) error {
+		calls++
+		received, _ = e.(*auditEvent)
+		e.SetAborted(true)
+		return errors.New("rejected")
+	}, WithPriority(High))
+	emitter.On("audit.*", func(e Event) error {
+		source, _ = LookupMetadata(e, "source")
+		return nil
+	}, WithReadOnlyEvent(), WithPriority(Highest))
+	emitter.On("audit.*", func(e Event) error {
+		calls++
+		return nil
+	})
+
+	event := &auditEvent{action: "delete", actor: "alice"}
+	errs := emitter.EmitEventSync(event)
+
+	if received != event {
+		t.Errorf("Listener received %T, want the emitted *auditEvent", received)
+	}
+	if source != "admin-ui" {
+		t.Errorf("LookupMetadata() = %v, want admin-ui", source)
+	}
+	if !event.IsAborted() || calls != 1 {
+		t.Errorf("Abort state = %v after %d calls, want the custom event aborted after 1 call", event.IsAborted(), calls)
+	}
+	if len(errs) != 1 || handled != event {
+		t.Errorf("Error handler received %T with errors %v, want the custom event and one error", handled, errs)
+	}
+}
+
+func TestEmitCustomEventThroughChildEmitter(t *testing.T) {
+	parent := NewMemoryEmitter()
+	child, _ := NewChildEmitter(parent, WithDownwardPropagation(nil))
+
+	var parentGot Event
+	parent.On("audit.*", func(e Event) error {
+		parentGot = e
+		return nil
+	})
+	childCalls := 0
+	child.On("audit.*", func(e Event) error {
+		childCalls++
+		return nil
+	})
+
+	event := &auditEvent{action: "login", actor: "bob"}
+	child.EmitEventSync(event)
+
+	if parentGot != event {
+		t.Errorf("Parent received %T, want the emitted *auditEvent", parentGot)
+	}
+	if childCalls != 1 {
+		t.Errorf("Child listener called %d times, want 1", childCalls)
+	}
+
+	parent.EmitEventSync(&auditEvent{action: "logout", actor: "bob"})
+	if childCalls != 2 {
+		t.Errorf("Child listener called %d times, want 2 after a parent emission", childCalls)
+	}
+}
+
+func TestLookupMetadata(t *testing.T) {
+	event := NewEvent("x").WithMetadata("k", "v")
+	if value, ok := LookupMetadata(event, "k"); !ok || value != "v" {
+		t.Errorf("LookupMetadata() = (%v, %v), want (v, true)", value, ok)
+	}
+	if _, ok := LookupMetadata(NewBaseEvent("x", nil), "k"); ok {
+		t.Error("LookupMetadata() should fail for events without metadata")
+	}
+}
@@ -0,0 +1,47 @@
+package emitter
+
+import "context"
+
+// lockForWrite takes the topic's write lock and reports whether it did. Listeners run
+// while the emission holds the read lock, so a listener waiting for the write lock of its
+// own topic would never get it. While an emission notifies the topic's listeners, the
+// caller may be one of them, and lockForWrite gives up rather than block.
+func (t *Topic) lockForWrite() bool {
+	if t.mu.TryLock() {
+		return true
+	}
+	if t.dispatching.Load() > 0 {
+		return false
+	}
+	t.mu.Lock()
+	return true
+}
+
+// poolTaskKey is the context key under which the emissions running as tasks of an
+// emitter's pool are marked with the emitter.
+type poolTaskKey struct{}
+
+// markPoolTask returns ctx marked as the context of an emission running on m's pool.
+func (m *MemoryEmitter) markPoolTask(ctx context.Context) context.Context {
+	return context.WithValue(ctx, poolTaskKey{}, m)
+}
+
+// onPool reports whether ctx is the context of an emission running on m's pool, or
+// derives from one.
+func (m *MemoryEmitter) onPool(ctx context.Context) bool {
+	emitter, _ := ctx.Value(poolTaskKey{}).(*MemoryEmitter)
+	return emitter == m
+}
+
+// releasePool releases the emitter's pool on Close. Releasing the pool waits for its
+// tasks, so when ctx is that of an emission running on the pool, whose listener is the
+// caller, the pool is released in the background once the emission completes and
+// releasePool returns ErrListenerDeadlock.
+func (m *MemoryEmitter) releasePool(ctx context.Context) error {
+	if m.onPool(ctx) {
+		go m.Pool.Release()
+		return ErrListenerDeadlock
+	}
+	m.Pool.Release()
+	return nil
+}
@@ -0,0 +1,268 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// withinTimeout fails the test if fn does not return quickly, which would mean it deadlocked.
+func withinTimeout(t *testing.T, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Call did not return; it deadlocked")
+	}
+}
+
+func TestOffFromListenerIsDeferred(t *testing.T) {
+	e := NewMemoryEmitter()
+	var calls []string
+	var selfErr, otherErr, againErr, elsewhereErr error
+	var selfID string
+	otherID, _ := e.On("order.created", func(Event) error {
+		calls = append(calls, "other")
+		return nil
+	}, WithPriority(Low))
+	elsewhereID, _ := e.On("user.created", func(Event) error { return nil })
+	selfID, _ = e.On("order.created", func(Event) error {
+		calls = append(calls, "self")
+		selfErr = e.Off("order.created", selfID)
+		otherErr = e.Off("order.created", otherID)
+		againErr = e.Off("order.created", otherID)
+		elsewhereErr = e.Off("user.created", elsewhereID)
+		return nil
+	}, WithPriority(High))
+
+	withinTimeout(t, func() { e.EmitSync("order.created") })
+	if selfErr != nil || otherErr != nil || elsewhereErr != nil {
+		t.Errorf("Off() errors = %v, %v, %v, want nil", selfErr, otherErr, elsewhereErr)
+	}
+	if !errors.Is(againErr, ErrListenerNotFound) {
+		t.Errorf("Repeated Off() error = %v, want %v", againErr, ErrListenerNotFound)
+	}
+
+	// The removed listeners are skipped for the rest of the emission and removed after it.
+	withinTimeout(t, func() { e.EmitSync("order.created") })
+	if len(calls) != 1 || calls[0] != "self" {
+		t.Errorf("Listener calls = %v, want [self]", calls)
+	}
+	if topic, _ := e.GetTopic("order.created"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after the emission, want 0", topic.ListenerCount())
+	}
+}
+
+func TestOffAllFromListenerIsDeferred(t *testing.T) {
+	e := NewMemoryEmitter()
+	var calls []string
+	var offErr error
+	e.On("order.created", func(Event) error {
+		calls = append(calls, "other")
+		return nil
+	}, WithPriority(Low))
+	e.On("order.created", func(Event) error {
+		calls = append(calls, "self")
+		offErr = e.OffAll("order.created")
+		return nil
+	}, WithPriority(High))
+
+	withinTimeout(t, func() { e.EmitSync("order.created") })
+	withinTimeout(t, func() { e.EmitSync("order.created") })
+	if offErr != nil {
+		t.Errorf("OffAll() error = %v, want nil", offErr)
+	}
+	if len(calls) != 1 || calls[0] != "self" {
+		t.Errorf("Listener calls = %v, want [self]", calls)
+	}
+	if topic, _ := e.GetTopic("order.created"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after the emission, want 0", topic.ListenerCount())
+	}
+}
+
+func TestOffFromNestedEmissionIsDeferred(t *testing.T) {
+	e := NewMemoryEmitter()
+	var calls int
+	var id string
+	id, _ = e.On("counter", func(evt Event) error {
+		calls++
+		if depth := evt.Payload().(int); depth < 2 {
+			e.EmitSync("counter", depth+1)
+			if depth == 0 {
+				return e.Off("counter", id)
+			}
+		}
+		return nil
+	})
+
+	var errs []error
+	withinTimeout(t, func() { errs = e.EmitSync("counter", 0) })
+	if len(errs) != 0 {
+		t.Errorf("EmitSync() errors = %v", errs)
+	}
+	if topic, _ := e.GetTopic("counter"); calls != 3 || topic.ListenerCount() != 0 {
+		t.Errorf("Listener called %d times with %d listeners left, want 3 and 0", calls, topic.ListenerCount())
+	}
+}
+
+func TestOffFromAdaptedListenerIsDeferred(t *testing.T) {
+	e := NewMemoryEmitter()
+	var id string
+	var offErr error
+	calls := 0
+	id, _ = e.On("order.created", AdaptNoError(func(string) {
+		calls++
+		offErr = e.Off("order.created", id)
+	}))
+
+	withinTimeout(t, func() {
+		e.EmitSync("order.created", "o-1")
+		e.EmitSync("order.created", "o-2")
+	})
+	if offErr != nil || calls != 1 {
+		t.Errorf("Off() error = %v after %d calls, want nil after 1", offErr, calls)
+	}
+}
+
+func TestOffFromAnotherGoroutineIsDeferred(t *testing.T) {
+	e := NewMemoryEmitter()
+	started, release := make(chan struct{}), make(chan struct{})
+	calls := 0
+	id, _ := e.On("order.created", func(Event) error {
+		calls++
+		if calls == 1 {
+			close(started)
+			<-release
+		}
+		return nil
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.EmitSync("order.created")
+	}()
+	<-started
+
+	// Removing the running listener from outside is deferred until the emission finishes.
+	if err := e.Off("order.created", id); err != nil {
+		t.Errorf("Off() error = %v, want nil", err)
+	}
+	close(release)
+	<-done
+	e.EmitSync("order.created")
+	if topic, _ := e.GetTopic("order.created"); calls != 1 || topic.ListenerCount() != 0 {
+		t.Errorf("Listener called %d times with %d listeners left, want 1 and 0", calls, topic.ListenerCount())
+	}
+}
+
+func TestOffFromListenerWithConcurrentOn(t *testing.T) {
+	e := NewMemoryEmitter()
+	started, subscribing := make(chan struct{}), make(chan struct{})
+	var id string
+	var offErr error
+	id, _ = e.On("order.created", func(Event) error {
+		close(started)
+		<-subscribing
+		// Give the concurrent On time to wait for the topic's write lock.
+		time.Sleep(10 * time.Millisecond)
+		offErr = e.Off("order.created", id)
+		return nil
+	})
+
+	onErr := make(chan error, 1)
+	go func() {
+		<-started
+		close(subscribing)
+		_, err := e.On("order.created", func(Event) error { return nil })
+		onErr <- err
+	}()
+	withinTimeout(t, func() { e.EmitSync("order.created") })
+	if offErr != nil {
+		t.Errorf("Off() error = %v, want nil", offErr)
+	}
+	if err := <-onErr; err != nil {
+		t.Errorf("On() error = %v, want nil", err)
+	}
+	if topic, _ := e.GetTopic("order.created"); topic.ListenerCount() != 1 {
+		t.Errorf("ListenerCount() = %d after the emission, want 1", topic.ListenerCount())
+	}
+}
+
+// releaseRecorder is a pool reporting when it was released.
+type releaseRecorder struct {
+	*PondPool
+	released chan struct{}
+}
+
+func (p *releaseRecorder) Release() {
+	p.PondPool.Release()
+	close(p.released)
+}
+
+func TestCloseFromPoolListener(t *testing.T) {
+	pool := &releaseRecorder{PondPool: NewPondPool(2, 10), released: make(chan struct{})}
+	e := NewMemoryEmitter(WithPool(pool))
+	closeErr := make(chan error, 1)
+	e.On("shutdown", func(evt Event) error {
+		closeErr <- e.CloseWithContext(evt.(*BaseEvent).Context())
+		return nil
+	})
+
+	e.Emit("shutdown")
+	withinTimeout(t, func() {
+		if err := <-closeErr; !errors.Is(err, ErrListenerDeadlock) {
+			t.Errorf("CloseWithContext() from a pool listener error = %v, want %v", err, ErrListenerDeadlock)
+		}
+	})
+	// The pool is released once the emission that closed the emitter completes.
+	withinTimeout(t, func() { <-pool.released })
+	if err := e.Close(); !errors.Is(err, ErrEmitterAlreadyClosed) {
+		t.Errorf("Close() error = %v, want %v", err, ErrEmitterAlreadyClosed)
+	}
+}
+
+func TestCloseWaitsForRunningEmissions(t *testing.T) {
+	e := NewMemoryEmitter(WithPool(NewPondPool(2, 10)))
+	started, release := make(chan struct{}), make(chan struct{})
+	e.On("report.generate", func(Event) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	e.Emit("report.generate")
+	<-started
+	closed := make(chan error, 1)
+	go func() { closed <- e.Close() }()
+
+	select {
+	case err := <-closed:
+		t.Fatalf("Close() returned %v while an emission was running, want it to wait", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	withinTimeout(t, func() {
+		if err := <-closed; err != nil {
+			t.Errorf("Close() error = %v, want nil", err)
+		}
+	})
+}
+
+func TestCloseFromSyncListener(t *testing.T) {
+	e := NewMemoryEmitter()
+	var closeErr error
+	e.On("shutdown", func(Event) error {
+		closeErr = e.Close()
+		return nil
+	})
+
+	withinTimeout(t, func() { e.EmitSync("shutdown") })
+	if closeErr != nil {
+		t.Errorf("Close() from a synchronous listener error = %v, want nil", closeErr)
+	}
+}
@@ -0,0 +1,139 @@
+package emitter
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TopicListenerDemoted is the reserved topic on which an emitter configured with
+// WithPriorityDecay reports demoted listeners. Its payload is a *Demotion.
+const TopicListenerDemoted = "emitter.listener.demoted"
+
+// Reasons reported in Demotion.Reason.
+const (
+	DemotedForFailures = "failures" // The listener returned errors repeatedly.
+	DemotedForSlowness = "slowness" // The listener was repeatedly slow.
+)
+
+// DecayPolicy describes when listeners are demoted in priority so that healthy
+// listeners stay ahead in the chain. Each demotion lowers a listener's priority by one
+// level, down to Floor, and resets its counters.
+type DecayPolicy struct {
+	Failures  int           // Consecutive failed calls that demote a listener; zero ignores failures.
+	SlowCall  time.Duration // Duration from which a call counts as slow; zero ignores durations.
+	SlowCalls int           // Consecutive slow calls that demote a listener; zero means one.
+	Floor     Priority      // Priority listeners are never demoted below; zero means Lowest.
+}
+
+// Demotion is the payload of the events published on TopicListenerDemoted.
+type Demotion struct {
+	Topic      string   // Topic or pattern the listener is subscribed to.
+	ListenerID string   // ID of the demoted listener.
+	From       Priority // Priority before the demotion.
+	To         Priority // Priority after the demotion.
+	Reason     string   // DemotedForFailures or DemotedForSlowness.
+}
+
+// SetPriorityDecay sets the policy demoting failing or slow listeners. It applies to
+// listeners subscribed afterwards; a nil policy disables demotions.
+func (m *MemoryEmitter) SetPriorityDecay(policy *DecayPolicy) {
+	if policy == nil {
+		m.decay = nil
+		return
+	}
+	normalized := *policy
+	if normalized.SlowCalls <= 0 {
+		normalized.SlowCalls = 1
+	}
+	if normalized.Floor == 0 {
+		normalized.Floor = Lowest
+	}
+	m.decay = &normalized
+}
+
+// listenerHealth tracks the recent calls of a listener subscribed under a DecayPolicy.
+type listenerHealth struct {
+	policy   *DecayPolicy
+	demote   func(reason string) // Demotes the listener; called without topic locks held.
+	mu       sync.Mutex
+	failures int
+	slow     int
+	reason   string // Reason of the demotion due, if any.
+}
+
+// withHealth tracks the listener's calls under the decay policy.
+func withHealth(health *listenerHealth) ListenerOption {
+	return func(item *listenerItem) {
+		item.health = health
+	}
+}
+
+// observe accounts for a call that took elapsed and returned err, and reports whether
+// the listener is now due for a demotion.
+func (h *listenerHealth) observe(err error, elapsed time.Duration) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err != nil {
+		h.failures++
+	} else {
+		h.failures = 0
+	}
+	if h.policy.SlowCall > 0 && elapsed >= h.policy.SlowCall {
+		h.slow++
+	} else {
+		h.slow = 0
+	}
+
+	switch {
+	case h.policy.Failures > 0 && h.failures >= h.policy.Failures:
+		h.reason = DemotedForFailures
+	case h.policy.SlowCall > 0 && h.slow >= h.policy.SlowCalls:
+		h.reason = DemotedForSlowness
+	default:
+		return false
+	}
+	h.failures, h.slow = 0, 0
+	return true
+}
+
+// applyDemotion demotes the listener if a demotion is due.
+func (h *listenerHealth) applyDemotion() {
+	h.mu.Lock()
+	reason := h.reason
+	h.reason = ""
+	h.mu.Unlock()
+	if reason != "" {
+		h.demote(reason)
+	}
+}
+
+// demoteListener lowers the listener's priority by one level, unless it already is at
+// the policy's floor, and publishes the demotion. Demotions requested while the topic's
+// lock cannot be taken, as from inside a nested emission of the topic, are dropped.
+func (m *MemoryEmitter) demoteListener(topic *Topic, topicName, id string, floor Priority, reason string) {
+	if !topic.lockForWrite() {
+		return
+	}
+	item, ok := topic.listeners[id]
+	if !ok || item.removed.Load() || item.priority <= floor {
+		topic.mu.Unlock()
+		return
+	}
+	from := item.priority
+	topic.pendingMu.Lock()
+	topic.removeSortedListenerID(id)
+	item.priority--
+	// Place the listener behind the healthy listeners sharing its new priority.
+	index := sort.Search(len(topic.sortedListenerIDs), func(i int) bool {
+		return topic.listeners[topic.sortedListenerIDs[i]].priority < item.priority
+	})
+	topic.sortedListenerIDs = append(topic.sortedListenerIDs, "")
+	copy(topic.sortedListenerIDs[index+1:], topic.sortedListenerIDs[index:])
+	topic.sortedListenerIDs[index] = id
+	topic.pendingMu.Unlock()
+	topic.mu.Unlock()
+
+	m.publish(TopicListenerDemoted, &Demotion{Topic: topicName, ListenerID: id, From: from, To: from - 1, Reason: reason})
+}
@@ -0,0 +1,77 @@
+package emitter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPriorityDecayForFailures(t *testing.T) {
+	e := NewMemoryEmitter(WithPriorityDecay(DecayPolicy{Failures: 2, Floor: Low}))
+	var demotions []*Demotion
+	e.On(TopicListenerDemoted, func(evt Event) error {
+		demotions = append(demotions, evt.Payload().(*Demotion))
+		return nil
+	})
+
+	var order []string
+	failing, _ := e.On("job", func(evt Event) error {
+		order = append(order, "failing")
+		return errors.New("failed")
+	}, WithPriority(High))
+	e.On("job", func(evt Event) error {
+		order = append(order, "healthy")
+		return nil
+	}, WithPriority(Normal))
+
+	for i := 0; i < 6; i++ {
+		e.EmitSync("job")
+	}
+
+	want := []*Demotion{
+		{Topic: "job", ListenerID: failing, From: High, To: Normal, Reason: DemotedForFailures},
+		{Topic: "job", ListenerID: failing, From: Normal, To: Low, Reason: DemotedForFailures},
+	}
+	if len(demotions) != len(want) {
+		t.Fatalf("demotions = %+v, want %d", demotions, len(want))
+	}
+	for i := range want {
+		if *demotions[i] != *want[i] {
+			t.Errorf("demotion %d = %+v, want %+v", i, demotions[i], want[i])
+		}
+	}
+	// Two calls at High, two at Normal behind the healthy listener, then at the Low floor.
+	if got := strings.Join(order, ","); got != "failing,healthy,failing,healthy,healthy,failing,healthy,failing,healthy,failing,healthy,failing" {
+		t.Errorf("call order = %s", got)
+	}
+}
+
+func TestPriorityDecayForSlowness(t *testing.T) {
+	e := NewMemoryEmitter(WithPriorityDecay(DecayPolicy{SlowCall: time.Millisecond, SlowCalls: 2}))
+	var demotions []*Demotion
+	e.On(TopicListenerDemoted, func(evt Event) error {
+		demotions = append(demotions, evt.Payload().(*Demotion))
+		return nil
+	})
+	slow := true
+	e.On("job", func(evt Event) error {
+		if slow {
+			time.Sleep(2 * time.Millisecond)
+		}
+		return nil
+	})
+
+	e.EmitSync("job")
+	slow = false
+	e.EmitSync("job")
+	slow = true
+	e.EmitSync("job")
+	if len(demotions) != 0 {
+		t.Fatalf("demotions = %+v, want none without consecutive slow calls", demotions)
+	}
+	e.EmitSync("job")
+	if len(demotions) != 1 || demotions[0].Reason != DemotedForSlowness || demotions[0].To != Low {
+		t.Errorf("demotions = %+v, want one for slowness", demotions)
+	}
+}
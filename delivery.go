@@ -0,0 +1,118 @@
+package emitter
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DeliveryMode is the delivery guarantee of the events of a topic.
+type DeliveryMode int
+
+const (
+	// AtMostOnce calls each listener at most once per event, and lost events are not
+	// delivered again. Brokered emitters publish such events without waiting for the
+	// broker to confirm them.
+	AtMostOnce DeliveryMode = iota
+	// AtLeastOnce delivers each event again to the listeners that failed it, so that they
+	// may see it several times. The MemoryEmitter calls again a listener that returned an
+	// error; brokered emitters wait for the broker to confirm each publish and let it
+	// redeliver the events that were not acknowledged.
+	AtLeastOnce
+)
+
+// defaultMaxRedeliveries is the number of redeliveries of an AtLeastOnce topic whose
+// TopicConfig does not set MaxRedeliveries.
+const defaultMaxRedeliveries = 3
+
+// String returns the name of the delivery mode.
+func (d DeliveryMode) String() string {
+	switch d {
+	case AtMostOnce:
+		return "at-most-once"
+	case AtLeastOnce:
+		return "at-least-once"
+	default:
+		return "unknown"
+	}
+}
+
+// TopicConfig configures how the events of the topics matching a pattern are delivered.
+// The zero value is the default configuration.
+type TopicConfig struct {
+	Delivery        DeliveryMode // Delivery guarantee of the topic's events.
+	MaxRedeliveries int          // Times a failed AtLeastOnce delivery is retried; zero means 3.
+}
+
+// WithTopicConfig configures the delivery of the events on topics matching pattern.
+func WithTopicConfig(pattern string, cfg TopicConfig) EmitterOption {
+	return func(m Emitter) {
+		m.SetTopicConfig(pattern, cfg)
+	}
+}
+
+// SetTopicConfig configures the delivery of the events on topics matching pattern,
+// replacing the configuration previously set for the same pattern. When several
+// patterns match a topic, the first one set applies.
+func (m *MemoryEmitter) SetTopicConfig(pattern string, cfg TopicConfig) {
+	m.topicConfigs.mu.Lock()
+	defer m.topicConfigs.mu.Unlock()
+
+	var rules []topicConfigRule
+	if current := m.topicConfigs.rules.Load(); current != nil {
+		rules = append(rules, *current...)
+	}
+	for i := range rules {
+		if rules[i].pattern == pattern {
+			rules[i].cfg = cfg
+			m.topicConfigs.rules.Store(&rules)
+			return
+		}
+	}
+	rules = append(rules, topicConfigRule{pattern: pattern, cfg: cfg})
+	m.topicConfigs.rules.Store(&rules)
+}
+
+// TopicConfig returns the configuration applying to the events emitted on topic, or the
+// zero TopicConfig if none was set for a matching pattern.
+func (m *MemoryEmitter) TopicConfig(topic string) TopicConfig {
+	rules := m.topicConfigs.rules.Load()
+	if rules == nil {
+		return TopicConfig{}
+	}
+	profile := m.topics.load().profile
+	for _, rule := range *rules {
+		if rule.pattern == topic || profile.Match(rule.pattern, topic) {
+			return rule.cfg
+		}
+	}
+	return TopicConfig{}
+}
+
+// topicConfigs holds the configurations set with SetTopicConfig.
+type topicConfigs struct {
+	mu    sync.Mutex                        // Serializes changes to rules.
+	rules atomic.Pointer[[]topicConfigRule] // Configurations in the order they were set.
+}
+
+// topicConfigRule is a configuration set for the topics matching a pattern.
+type topicConfigRule struct {
+	pattern string
+	cfg     TopicConfig
+}
+
+// redeliveries returns the number of times a failed delivery of the event on topic is
+// retried.
+func (m *MemoryEmitter) redeliveries(topic string) int {
+	if m.topicConfigs.rules.Load() == nil {
+		return 0
+	}
+	cfg := m.TopicConfig(topic)
+	switch {
+	case cfg.Delivery != AtLeastOnce:
+		return 0
+	case cfg.MaxRedeliveries > 0:
+		return cfg.MaxRedeliveries
+	default:
+		return defaultMaxRedeliveries
+	}
+}
@@ -0,0 +1,72 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTopicConfigAtLeastOnce(t *testing.T) {
+	e := NewMemoryEmitter(
+		WithTopicConfig("payment.*", TopicConfig{Delivery: AtLeastOnce, MaxRedeliveries: 2}),
+		WithTopicConfig("order.*", TopicConfig{Delivery: AtLeastOnce}),
+	)
+	defer e.Close()
+
+	if cfg := e.TopicConfig("payment.captured"); cfg.Delivery != AtLeastOnce || cfg.MaxRedeliveries != 2 {
+		t.Errorf("TopicConfig(payment.captured) = %+v", cfg)
+	}
+	if cfg := e.TopicConfig("user.created"); cfg != (TopicConfig{}) {
+		t.Errorf("TopicConfig(user.created) = %+v, want the zero config", cfg)
+	}
+
+	errFlaky := errors.New("flaky")
+	calls := map[string]int{}
+	listener := func(evt Event) error {
+		calls[evt.Topic()]++
+		if evt.Topic() == "order.created" && calls[evt.Topic()] == 2 {
+			return nil
+		}
+		return errFlaky
+	}
+	for _, topic := range []string{"payment.captured", "order.created", "user.created"} {
+		e.On(topic, listener)
+	}
+
+	if errs := e.EmitSync("payment.captured"); len(errs) != 1 || !errors.Is(errs[0], errFlaky) {
+		t.Errorf("EmitSync(payment.captured) = %v, want one %v", errs, errFlaky)
+	}
+	if errs := e.EmitSync("order.created"); len(errs) != 0 {
+		t.Errorf("EmitSync(order.created) = %v, want the redelivery to succeed", errs)
+	}
+	e.EmitSync("user.created")
+
+	want := map[string]int{"payment.captured": 3, "order.created": 2, "user.created": 1}
+	for topic, n := range want {
+		if calls[topic] != n {
+			t.Errorf("listener of %s called %d times, want %d", topic, calls[topic], n)
+		}
+	}
+
+	e.SetTopicConfig("payment.*", TopicConfig{Delivery: AtMostOnce})
+	calls = map[string]int{}
+	e.EmitSync("payment.captured")
+	if calls["payment.captured"] != 1 {
+		t.Errorf("listener called %d times after switching to at-most-once, want 1", calls["payment.captured"])
+	}
+}
+
+func TestTopicConfigAbortStopsRedelivery(t *testing.T) {
+	e := NewMemoryEmitter(WithTopicConfig("job", TopicConfig{Delivery: AtLeastOnce}))
+	defer e.Close()
+
+	calls := 0
+	e.On("job", func(evt Event) error {
+		calls++
+		evt.SetAborted(true)
+		return errors.New("rejected")
+	})
+	e.EmitSync("job")
+	if calls != 1 {
+		t.Errorf("aborting listener called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,50 @@
+package emitter
+
+// MatchInfo describes a listener that an emission would notify.
+type MatchInfo struct {
+	ListenerInfo
+	Topic string `json:"topic"` // Topic the event would be emitted on.
+}
+
+// DryRun reports the listeners that emitting payload on topicName would notify, in
+// the order they would be called, without calling them or changing any listener
+// state. Listeners exhausted by WithMaxCalls, listeners whose WithFilter predicate
+// rejects the payload and listeners beyond the emitter's listener budget are left out,
+// as are listeners of related emitters.
+//
+// The result is a snapshot: listeners can still abort the real emission early, and
+// listeners subscribed or removed meanwhile change its routing.
+func (m *MemoryEmitter) DryRun(topicName string, payload interface{}) ([]MatchInfo, error) {
+	if m.closed.Load().(bool) {
+		return nil, &EmitError{Op: OpEmit, Topic: topicName, Err: ErrEmitterClosed}
+	}
+
+	matches := []MatchInfo{}
+	var event Event // Lazily created for the listeners' filters.
+	m.topics.load().match(topicName, splitTopic(topicName), func(topic *Topic) {
+		topic.mu.RLock()
+		defer topic.mu.RUnlock()
+		for _, id := range topic.sortedListenerIDs {
+			item := topic.listeners[id]
+			if item.envelope || item.removed.Load() || (item.maxCalls > 0 && item.calls.Load() >= item.maxCalls) {
+				continue
+			}
+			if item.filter != nil {
+				if event == nil {
+					event = newReadOnlyEvent(NewBaseEvent(topicName, payload))
+				}
+				if !item.filter(event) {
+					continue
+				}
+			}
+			matches = append(matches, MatchInfo{
+				ListenerInfo: ListenerInfo{Route: item.route(id, topic.Name), Source: item.source},
+				Topic:        topicName,
+			})
+		}
+	})
+	if m.listenerBudget > 0 && len(matches) > m.listenerBudget {
+		matches = matches[:m.listenerBudget]
+	}
+	return matches, nil
+}
@@ -0,0 +1,66 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	e := NewMemoryEmitter()
+	calls := 0
+	listener := func(evt Event) error {
+		calls++
+		return nil
+	}
+	auditID, _ := e.On("order.*", listener, WithPriority(Low), WithReadOnlyEvent(), WithHandlerName("audit"))
+	billingID, _ := e.On("order.created", listener, WithPriority(High), WithGroup("billing"))
+	onceID, _ := e.On("order.created", listener, WithMaxCalls(1))
+	e.On("user.*", listener)
+
+	matches, err := e.DryRun("order.created", "o-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, match := range matches {
+		if match.Topic != "order.created" {
+			t.Errorf("match %+v has topic %q, want order.created", match, match.Topic)
+		}
+		ids = append(ids, match.ID)
+	}
+	want := []string{billingID, onceID, auditID}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Fatalf("DryRun listeners = %v, want %v", ids, want)
+	}
+	if audit := matches[2]; audit.Pattern != "order.*" || audit.Priority != Low || !audit.Options.ReadOnly || audit.Handler != "audit" {
+		t.Errorf("audit match = %+v", audit)
+	}
+	if calls != 0 {
+		t.Errorf("DryRun called %d listeners, want none", calls)
+	}
+
+	// Exhausted listeners are no longer reported.
+	e.EmitSync("order.created", "o-1")
+	matches, _ = e.DryRun("order.created", "o-2")
+	if len(matches) != 2 {
+		t.Errorf("DryRun after exhausting a listener returned %d matches, want 2", len(matches))
+	}
+}
+
+func TestDryRunBudgetAndClose(t *testing.T) {
+	e := NewMemoryEmitter(WithListenerBudget(1))
+	e.On("order.created", func(evt Event) error { return nil })
+	e.On("order.*", func(evt Event) error { return nil })
+
+	if matches, _ := e.DryRun("order.created", nil); len(matches) != 1 {
+		t.Errorf("DryRun returned %d matches, want 1 within the budget", len(matches))
+	}
+	if matches, _ := e.DryRun("user.created", nil); len(matches) != 0 {
+		t.Errorf("DryRun on an unrouted topic returned %v", matches)
+	}
+
+	e.Close()
+	if _, err := e.DryRun("order.created", nil); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("DryRun after Close error = %v, want ErrEmitterClosed", err)
+	}
+}
@@ -1,5 +1,10 @@
 package emitter
 
+import (
+	"context"
+	"time"
+)
+
 // Emitter is an interface that defines the contract for an event management system.
 // It allows for registration and deregistration of listeners, synchronous and asynchronous
 // event emission, and configuration for custom error handling and concurrency management.
@@ -13,11 +18,42 @@ type Emitter interface {
 	Off(topicName string, listenerID string) error
 
 	// Emit asynchronously sends an event to all subscribers of a topic and returns a channel of errors.
-	Emit(eventName string, payload interface{}) <-chan error
+	// The arguments become the event's payload, except EmitOption values which configure the emission.
+	Emit(eventName string, args ...interface{}) <-chan error
+
+	// EmitDetached asynchronously sends an event like Emit, without allocating a channel for its errors.
+	EmitDetached(eventName string, args ...interface{})
+
+	// EmitWithContext asynchronously sends an event tied to ctx; canceling ctx stops the remaining listeners.
+	EmitWithContext(ctx context.Context, eventName string, args ...interface{}) <-chan error
+
+	// EmitBlocking asynchronously sends an event once the pool has room for it, waiting as long as ctx allows.
+	EmitBlocking(ctx context.Context, eventName string, args ...interface{}) <-chan error
+
+	// TryEmit asynchronously sends an event unless the pool is saturated, reporting whether it was accepted.
+	TryEmit(eventName string, args ...interface{}) (bool, error)
+
+	// EmitEvent asynchronously sends a pre-constructed event, such as one built with NewEvent.
+	EmitEvent(evt Event, opts ...EmitOption) <-chan error
+
+	// Go asynchronously sends an event as part of the group, so callers can wait for every emission they started.
+	Go(group EmissionGroup, eventName string, args ...interface{})
+
+	// EmitAfter schedules an asynchronous emission after a delay and returns a Timer that can cancel it.
+	EmitAfter(delay time.Duration, eventName string, args ...interface{}) Timer
+
+	// EmitAt schedules an asynchronous emission at a specific time and returns a Timer that can cancel it.
+	EmitAt(at time.Time, eventName string, args ...interface{}) Timer
 
 	// EmitSync sends an event synchronously to all subscribers of a topic and collects any errors that occurred.
 	// This method blocks until all listeners have been notified.
-	EmitSync(eventName string, payload interface{}) []error
+	EmitSync(eventName string, args ...interface{}) []error
+
+	// EmitSyncWithContext synchronously sends an event tied to ctx; canceling ctx stops the remaining listeners.
+	EmitSyncWithContext(ctx context.Context, eventName string, args ...interface{}) []error
+
+	// EmitEventSync synchronously sends a pre-constructed event and collects any errors that occurred.
+	EmitEventSync(evt Event, opts ...EmitOption) []error
 
 	// GetTopic retrieves the Topic object associated with the given topic name.
 	// It returns an error if the topic does not exist.
@@ -32,15 +68,90 @@ type Emitter interface {
 	// SetIDGenerator assigns a function that generates a unique ID string for new listeners.
 	SetIDGenerator(func() string)
 
+	// SetEventIDGenerator assigns a function that generates a unique ID string for each emitted event.
+	SetEventIDGenerator(func() string)
+
+	// SetClock sets the clock used for event timestamps and delayed emissions.
+	SetClock(Clock)
+
 	// SetPool sets a custom goroutine pool for managing concurrency within the Emitter.
 	SetPool(Pool)
 
+	// SetPoolFactory sets a function creating the Emitter's pool, so that emitters rebuilt from its configuration get their own.
+	SetPoolFactory(factory func() Pool)
+
 	// SetPanicHandler sets a function that will be called in case of a panic during event handling.
 	SetPanicHandler(PanicHandler)
 
+	// SetPanicPolicy sets how an emission continues after one of its listeners panicked.
+	SetPanicPolicy(policy PanicPolicy)
+
 	// SetErrChanBufferSize sets the size of the buffered channel for errors returned by asynchronous emits.
 	SetErrChanBufferSize(int)
 
+	// SetEmissionTimeout bounds how long a single emission may keep notifying listeners.
+	SetEmissionTimeout(time.Duration)
+
+	// SetListenerBudget limits how many listeners a single emission may notify.
+	SetListenerBudget(int)
+
+	// SetChainLengthWarning sets a handler that is called when a topic's listener chain grows beyond a length.
+	SetChainLengthWarning(length int, handler func(topic string, length int))
+
+	// SetSilentErrors discards listener errors instead of reporting them to the emitting caller.
+	SetSilentErrors(silent bool)
+
+	// SetErrorTopic sets the topic on which unhandled listener errors are published as events.
+	SetErrorTopic(topic string)
+
+	// SetPanicTopic sets the topic on which recovered panics are published as events.
+	SetPanicTopic(topic string)
+
+	// SetLifecycleEvents enables or disables events on the reserved lifecycle topics.
+	SetLifecycleEvents(enabled bool)
+
+	// SetListenerSources enables or disables recording the call site that registered each listener.
+	SetListenerSources(enabled bool)
+
+	// SetProfilerLabels controls whether asynchronous emissions run with pprof labels naming their topic.
+	SetProfilerLabels(enabled bool)
+
+	// SetWildcardLimit makes On refuse new wildcard patterns once limit of them are registered.
+	SetWildcardLimit(limit int)
+
+	// SetWildcardWarning sets a handler that is called when the number of wildcard patterns grows beyond count.
+	SetWildcardWarning(count int, handler func(pattern string, count int))
+
+	// SetMatchCosts controls whether the time spent matching each wildcard pattern is recorded.
+	SetMatchCosts(enabled bool)
+
+	// SetPriorityDecay sets the policy demoting failing or slow listeners; nil disables demotions.
+	SetPriorityDecay(policy *DecayPolicy)
+
+	// SetMatchProfile selects the rules matching emitted topics against wildcard patterns.
+	SetMatchProfile(MatchProfile)
+
+	// SetScheduleStore sets the store persisting the emissions scheduled with EmitAfter and EmitAt.
+	SetScheduleStore(store ScheduleStore)
+
+	// SetTopicConfig configures the delivery of the events on topics matching pattern.
+	SetTopicConfig(pattern string, cfg TopicConfig)
+
+	// TopicConfig returns the configuration applying to the events emitted on topic.
+	TopicConfig(topic string) TopicConfig
+
+	// Use installs middleware around the delivery of every emission.
+	Use(middleware ...Middleware)
+
+	// SetSuppressUnchanged drops emissions on topics matching pattern whose payload did not change.
+	SetSuppressUnchanged(pattern string, equal func(previous, next interface{}) bool)
+
+	// Listeners describes every registered listener, including its source location when recorded.
+	Listeners() []ListenerInfo
+
+	// Flush blocks until all queued and in-flight asynchronous emissions complete, without closing the Emitter.
+	Flush(ctx context.Context) error
+
 	// Close gracefully shuts down the Emitter, ensuring all pending events are processed.
 	Close() error
 }
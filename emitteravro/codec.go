@@ -0,0 +1,152 @@
+package emitteravro
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// ContentType is the content type of payloads encoded by a Codec.
+const ContentType = "application/vnd.confluent.avro"
+
+// ErrMalformedPayload is returned when a payload is not in the Confluent wire format.
+var ErrMalformedPayload = errors.New("payload is not in the Confluent Avro wire format")
+
+// ErrNoSchema is returned when no schema was configured for a topic.
+var ErrNoSchema = errors.New("no Avro schema configured for topic")
+
+// magicByte starts every payload in the Confluent wire format.
+const magicByte = 0
+
+// Serializer encodes values with Avro. It is usually a thin adapter around an Avro
+// library, such as github.com/hamba/avro or github.com/linkedin/goavro.
+type Serializer interface {
+	// Marshal encodes v in the Avro binary encoding of schema.
+	Marshal(schema string, v interface{}) ([]byte, error)
+	// Unmarshal decodes data, written with schema, into v.
+	Unmarshal(schema string, data []byte, v interface{}) error
+}
+
+// Codec is an emitter.Codec that encodes the payloads of one topic with Avro. The
+// topic's schema is registered with the registry on first use.
+type Codec struct {
+	registry   *Registry
+	serializer Serializer
+	subject    string
+	schema     string
+
+	mu         sync.Mutex
+	id         int
+	registered bool // Whether id holds the ID of the registered schema.
+}
+
+// NewCodec creates a Codec registering schema under the subject of topic, as named by
+// SubjectForTopic.
+func NewCodec(registry *Registry, serializer Serializer, topic, schema string) *Codec {
+	return &Codec{
+		registry:   registry,
+		serializer: serializer,
+		subject:    SubjectForTopic(topic),
+		schema:     schema,
+	}
+}
+
+// SubjectForTopic returns the registry subject holding the payload schemas of topic,
+// following the Confluent topic name strategy.
+func SubjectForTopic(topic string) string {
+	return topic + "-value"
+}
+
+// ContentType returns the content type of the Confluent Avro wire format.
+func (c *Codec) ContentType() string {
+	return ContentType
+}
+
+// Marshal encodes v with the topic's schema, registering the schema on first use. A
+// failed registration is tried again by the next call.
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	id, err := c.schemaID()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.serializer.Marshal(c.schema, v)
+	if err != nil {
+		return nil, err
+	}
+	framed := make([]byte, 5, 5+len(data))
+	framed[0] = magicByte
+	binary.BigEndian.PutUint32(framed[1:], uint32(id))
+	return append(framed, data...), nil
+}
+
+// schemaID returns the ID of the topic's schema, registering it unless an earlier call
+// succeeded.
+func (c *Codec) schemaID() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.registered {
+		return c.id, nil
+	}
+	id, err := c.registry.Register(context.Background(), c.subject, c.schema)
+	if err != nil {
+		return 0, err
+	}
+	c.id, c.registered = id, true
+	return id, nil
+}
+
+// Unmarshal decodes data into v with the schema it was written with, fetching the
+// schema from the registry when it is not cached.
+func (c *Codec) Unmarshal(data []byte, v interface{}) error {
+	if len(data) < 5 || data[0] != magicByte {
+		return ErrMalformedPayload
+	}
+	schema, err := c.registry.Schema(context.Background(), int(binary.BigEndian.Uint32(data[1:5])))
+	if err != nil {
+		return err
+	}
+	return c.serializer.Unmarshal(schema, data[5:], v)
+}
+
+// Validate checks the topic's schema against the versions already registered for its
+// subject, so incompatible schema changes can be rejected before anything is emitted.
+func (c *Codec) Validate(ctx context.Context) error {
+	return c.registry.CheckCompatibility(ctx, c.subject, c.schema)
+}
+
+// TopicCodecs holds the Avro codecs of several topics.
+type TopicCodecs struct {
+	codecs map[string]*Codec
+}
+
+// NewTopicCodecs creates codecs for the given schemas, keyed by topic.
+func NewTopicCodecs(registry *Registry, serializer Serializer, schemas map[string]string) *TopicCodecs {
+	tc := &TopicCodecs{codecs: make(map[string]*Codec, len(schemas))}
+	for topic, schema := range schemas {
+		tc.codecs[topic] = NewCodec(registry, serializer, topic, schema)
+	}
+	return tc
+}
+
+// ForTopic returns the codec of topic, or ErrNoSchema if no schema was configured for it.
+func (tc *TopicCodecs) ForTopic(topic string) (emitter.Codec, error) {
+	codec, ok := tc.codecs[topic]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoSchema, topic)
+	}
+	return codec, nil
+}
+
+// Envelope encodes evt with the codec of its topic.
+func (tc *TopicCodecs) Envelope(evt emitter.Event) (*emitter.Envelope, error) {
+	codec, err := tc.ForTopic(evt.Topic())
+	if err != nil {
+		return nil, err
+	}
+	return emitter.NewEnvelope(evt, codec)
+}
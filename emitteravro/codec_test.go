@@ -0,0 +1,132 @@
+package emitteravro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+const orderSchema = `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"}]}`
+
+// jsonSerializer stands in for an Avro library and checks the schema it decodes with.
+type jsonSerializer struct{}
+
+func (jsonSerializer) Marshal(schema string, v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonSerializer) Unmarshal(schema string, data []byte, v interface{}) error {
+	if schema != orderSchema {
+		return errors.New("unexpected schema")
+	}
+	return json.Unmarshal(data, v)
+}
+
+// fakeRegistry serves the subset of the schema registry API used by Registry.
+func fakeRegistry(t *testing.T, registrations *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/subjects/order.created-value/versions":
+			registrations.Add(1)
+			w.Write([]byte(`{"id":7}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/schemas/ids/7":
+			json.NewEncoder(w).Encode(map[string]string{"schema": orderSchema})
+		case r.Method == http.MethodPost && r.URL.Path == "/compatibility/subjects/order.created-value/versions/latest":
+			w.Write([]byte(`{"is_compatible":false}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+		}
+	}))
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	var registrations atomic.Int32
+	server := fakeRegistry(t, &registrations)
+	defer server.Close()
+
+	codecs := NewTopicCodecs(NewRegistry(server.URL, nil), jsonSerializer{}, map[string]string{
+		"order.created": orderSchema,
+	})
+
+	env, err := codecs.Envelope(emitter.NewBaseEvent("order.created", map[string]string{"id": "o-1"}))
+	if err != nil {
+		t.Fatalf("Envelope() failed with error: %v", err)
+	}
+	if env.ContentType != ContentType || env.Payload[0] != 0 || env.Payload[4] != 7 {
+		t.Errorf("Payload = % x, want the Confluent framing with schema ID 7", env.Payload)
+	}
+	if _, err := codecs.Envelope(emitter.NewBaseEvent("order.created", map[string]string{"id": "o-2"})); err != nil {
+		t.Fatalf("Envelope() failed with error: %v", err)
+	}
+	if registrations.Load() != 1 {
+		t.Errorf("Schema registered %d times, want 1", registrations.Load())
+	}
+
+	// A fresh registry client has to fetch the schema by ID to decode.
+	reader := NewCodec(NewRegistry(server.URL, nil), jsonSerializer{}, "order.created", orderSchema)
+	var order map[string]string
+	if err := env.Decode(reader, &order); err != nil || order["id"] != "o-1" {
+		t.Errorf("Decode() = %v, %v, want order o-1", order, err)
+	}
+}
+
+func TestCodecErrors(t *testing.T) {
+	var registrations atomic.Int32
+	server := fakeRegistry(t, &registrations)
+	defer server.Close()
+
+	registry := NewRegistry(server.URL, nil)
+	codecs := NewTopicCodecs(registry, jsonSerializer{}, map[string]string{"order.created": orderSchema})
+
+	if _, err := codecs.ForTopic("user.created"); !errors.Is(err, ErrNoSchema) {
+		t.Errorf("ForTopic() = %v, want %v", err, ErrNoSchema)
+	}
+	if err := NewCodec(registry, jsonSerializer{}, "order.created", orderSchema).Validate(context.Background()); !errors.Is(err, ErrIncompatibleSchema) {
+		t.Errorf("Validate() = %v, want %v", err, ErrIncompatibleSchema)
+	}
+	if _, err := NewCodec(registry, jsonSerializer{}, "user.created", orderSchema).Marshal("x"); err == nil {
+		t.Error("Marshal() should fail when the registry rejects the subject")
+	}
+
+	var v interface{}
+	if err := NewCodec(registry, jsonSerializer{}, "order.created", orderSchema).Unmarshal([]byte{1, 2}, &v); !errors.Is(err, ErrMalformedPayload) {
+		t.Errorf("Unmarshal() = %v, want %v", err, ErrMalformedPayload)
+	}
+}
+
+func TestCodecRetriesFailedRegistration(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":7}`))
+	}))
+	defer server.Close()
+
+	codec := NewCodec(NewRegistry(server.URL, nil), jsonSerializer{}, "order.created", orderSchema)
+	if _, err := codec.Marshal(map[string]string{"id": "o-1"}); err == nil {
+		t.Fatal("Marshal() should fail while the registry is unavailable")
+	}
+	data, err := codec.Marshal(map[string]string{"id": "o-1"})
+	if err != nil || data[4] != 7 {
+		t.Fatalf("Marshal() = % x, %v, want schema ID 7 once the registry recovers", data, err)
+	}
+	if _, err := codec.Marshal(map[string]string{"id": "o-2"}); err != nil || attempts.Load() != 2 {
+		t.Errorf("Marshal() = %v after %d registrations, want the ID cached after 2", err, attempts.Load())
+	}
+}
+
+func TestRegistryTimeout(t *testing.T) {
+	if client := NewRegistry("http://registry", nil).client; client.Timeout != DefaultTimeout {
+		t.Errorf("default client timeout = %v, want %v", client.Timeout, DefaultTimeout)
+	}
+}
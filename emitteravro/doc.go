@@ -0,0 +1,8 @@
+// Package emitteravro provides an emitter.Codec that encodes payloads with Avro and
+// registers their schemas with a Confluent-compatible schema registry, keyed by topic.
+//
+// Payloads are framed in the Confluent wire format: a zero magic byte, the 4-byte
+// big-endian schema ID and the Avro binary encoding. The Avro encoding itself is done
+// by a Serializer, so that any Avro library can be plugged in without this package
+// depending on it.
+package emitteravro
@@ -0,0 +1,155 @@
+package emitteravro
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrIncompatibleSchema is returned when the registry rejects a schema as incompatible
+// with the versions already registered for its subject.
+var ErrIncompatibleSchema = errors.New("schema is incompatible with the registered versions")
+
+// registryContentType is the media type of schema registry requests.
+const registryContentType = "application/vnd.schemaregistry.v1+json"
+
+// DefaultTimeout bounds the registry requests of a Registry created without a client.
+// Codecs make their requests without a deadline of their own, so a custom client should
+// set a timeout as well, lest a stalled registry block emissions.
+const DefaultTimeout = 10 * time.Second
+
+// Registry is a client for a Confluent-compatible schema registry. Registered schema
+// IDs and fetched schemas are cached, so each is requested at most once.
+type Registry struct {
+	baseURL string
+	client  *http.Client
+
+	mu      sync.RWMutex
+	ids     map[string]int // Schema ID by subject and schema.
+	schemas map[int]string // Schema by ID.
+}
+
+// NewRegistry creates a client for the schema registry at baseURL. A nil client uses
+// an http.Client with DefaultTimeout.
+func NewRegistry(baseURL string, client *http.Client) *Registry {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Registry{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  client,
+		ids:     make(map[string]int),
+		schemas: make(map[int]string),
+	}
+}
+
+// Register registers the schema under subject, or looks it up if it is already
+// registered, and returns its ID.
+func (r *Registry) Register(ctx context.Context, subject, schema string) (int, error) {
+	key := subject + "\x00" + schema
+	r.mu.RLock()
+	id, ok := r.ids[key]
+	r.mu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	var resp struct {
+		ID int `json:"id"`
+	}
+	path := "/subjects/" + url.PathEscape(subject) + "/versions"
+	if err := r.do(ctx, http.MethodPost, path, map[string]string{"schema": schema}, &resp); err != nil {
+		return 0, fmt.Errorf("registering schema for subject %s: %w", subject, err)
+	}
+
+	r.mu.Lock()
+	r.ids[key] = resp.ID
+	r.schemas[resp.ID] = schema
+	r.mu.Unlock()
+	return resp.ID, nil
+}
+
+// Schema returns the schema registered under id.
+func (r *Registry) Schema(ctx context.Context, id int) (string, error) {
+	r.mu.RLock()
+	schema, ok := r.schemas[id]
+	r.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	var resp struct {
+		Schema string `json:"schema"`
+	}
+	if err := r.do(ctx, http.MethodGet, fmt.Sprintf("/schemas/ids/%d", id), nil, &resp); err != nil {
+		return "", fmt.Errorf("fetching schema %d: %w", id, err)
+	}
+
+	r.mu.Lock()
+	r.schemas[id] = resp.Schema
+	r.mu.Unlock()
+	return resp.Schema, nil
+}
+
+// CheckCompatibility validates the schema against the latest version registered under
+// subject. It returns ErrIncompatibleSchema when the registry rejects it.
+func (r *Registry) CheckCompatibility(ctx context.Context, subject, schema string) error {
+	var resp struct {
+		IsCompatible bool `json:"is_compatible"`
+	}
+	path := "/compatibility/subjects/" + url.PathEscape(subject) + "/versions/latest"
+	if err := r.do(ctx, http.MethodPost, path, map[string]string{"schema": schema}, &resp); err != nil {
+		return fmt.Errorf("checking schema compatibility for subject %s: %w", subject, err)
+	}
+	if !resp.IsCompatible {
+		return fmt.Errorf("%w: subject %s", ErrIncompatibleSchema, subject)
+	}
+	return nil
+}
+
+// do sends a request to the registry and decodes the JSON response into out.
+func (r *Registry) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", registryContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", registryContentType)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			ErrorCode int    `json:"error_code"`
+			Message   string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("schema registry error %d: %s", apiErr.ErrorCode, apiErr.Message)
+		}
+		return fmt.Errorf("schema registry returned %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,5 @@
+// Package emitterdebug exposes an emitter over HTTP for development and operations
+// tools: StreamHandler streams its events to the emitterwatch command, IngestHandler
+// emits events replayed by the emitterjournal command, and ListenersHandler lists the
+// registered listeners with the code that registered them.
+package emitterdebug
@@ -0,0 +1,68 @@
+package emitterdebug
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// IngestHandler emits the events POSTed to it as newline-delimited JSON envelopes, such
+// as those replayed from a journal by the emitterjournal command. Each event is emitted
+// synchronously with its payload still encoded, as described for emitter.Envelope.Event.
+type IngestHandler struct {
+	emitter emitter.Emitter
+	mapper  emitter.TopicMapper
+}
+
+// NewIngestHandler creates an IngestHandler emitting on e.
+func NewIngestHandler(e emitter.Emitter) *IngestHandler {
+	return &IngestHandler{emitter: e}
+}
+
+// SetTopicMapper translates the topics of ingested envelopes into local topics with
+// mapper's ToLocal. Envelopes whose topic cannot be translated are not emitted, and
+// the error is reported in the response.
+func (h *IngestHandler) SetTopicMapper(mapper emitter.TopicMapper) {
+	h.mapper = mapper
+}
+
+// ingestResult is the JSON response of an IngestHandler.
+type ingestResult struct {
+	Emitted int      `json:"emitted"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// ServeHTTP emits every envelope of the request body and responds with the number of
+// emitted events and the listener errors they caused.
+func (h *IngestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var result ingestResult
+	err := emitter.ReadJournal(r.Body, func(env *emitter.Envelope) error {
+		if h.mapper != nil {
+			topic, err := h.mapper.ToLocal(env.Topic)
+			if err != nil {
+				result.Errors = append(result.Errors, err.Error())
+				return nil
+			}
+			env.Topic = topic
+		}
+		for _, err := range h.emitter.EmitEventSync(env.Event()) {
+			result.Errors = append(result.Errors, err.Error())
+		}
+		result.Emitted++
+		return nil
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,95 @@
+package emitterdebug
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestIngestHandler(t *testing.T) {
+	em := emitter.NewMemoryEmitter()
+	var received []string
+	em.On("order.*", func(evt emitter.Event) error {
+		var id string
+		if err := json.Unmarshal(evt.Payload().(json.RawMessage), &id); err != nil {
+			return err
+		}
+		received = append(received, evt.Topic()+" "+id)
+		if evt.Topic() == "order.failed" {
+			return errors.New("rejected")
+		}
+		return nil
+	})
+
+	body := strings.Join([]string{
+		`{"topic":"order.created","contentType":"application/json","payload":"o-1"}`,
+		`{"topic":"order.failed","contentType":"application/json","payload":"o-2"}`,
+	}, "\n")
+	rec := httptest.NewRecorder()
+	NewIngestHandler(em).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var result ingestResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Decoding response failed with error: %v", err)
+	}
+	if result.Emitted != 2 || len(result.Errors) != 1 ||
+		!strings.HasPrefix(result.Errors[0], `notify "order.failed" listener `) || !strings.HasSuffix(result.Errors[0], ": rejected") {
+		t.Errorf("Result = %+v, want 2 emitted events and the order.failed error", result)
+	}
+	if strings.Join(received, ",") != "order.created o-1,order.failed o-2" {
+		t.Errorf("Received %v", received)
+	}
+}
+
+func TestIngestHandlerTopicMapper(t *testing.T) {
+	em := emitter.NewMemoryEmitter()
+	var received []string
+	em.On("order.*", func(evt emitter.Event) error {
+		received = append(received, evt.Topic())
+		return nil
+	})
+
+	body := strings.Join([]string{
+		`{"topic":"prod.orders.created","contentType":"application/json","payload":"o-1"}`,
+		`{"topic":"staging.orders.created","contentType":"application/json","payload":"o-2"}`,
+	}, "\n")
+	handler := NewIngestHandler(em)
+	handler.SetTopicMapper(emitter.NewTopicMap(emitter.TopicRule{Local: "order", External: "prod.orders"}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	var result ingestResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Decoding response failed with error: %v", err)
+	}
+	if result.Emitted != 1 || len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "staging.orders.created") {
+		t.Errorf("Result = %+v, want 1 emitted event and the unmapped topic error", result)
+	}
+	if strings.Join(received, ",") != "order.created" {
+		t.Errorf("Received %v, want order.created", received)
+	}
+}
+
+func TestIngestHandlerRejects(t *testing.T) {
+	handler := NewIngestHandler(emitter.NewMemoryEmitter())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not json")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Malformed body status = %d, want 400", rec.Code)
+	}
+}
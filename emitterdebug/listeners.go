@@ -0,0 +1,38 @@
+package emitterdebug
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// ListenersHandler serves the listeners registered on an emitter as a JSON array of
+// emitter.ListenerInfo, including the file and line that registered each listener when
+// the emitter records them with emitter.WithListenerSources. The topic query parameter
+// restricts the response to the listeners whose pattern matches a topic.
+type ListenersHandler struct {
+	emitter emitter.Emitter
+}
+
+// NewListenersHandler creates a ListenersHandler for the emitter.
+func NewListenersHandler(e emitter.Emitter) *ListenersHandler {
+	return &ListenersHandler{emitter: e}
+}
+
+// ServeHTTP responds with the listeners of the emitter.
+func (h *ListenersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	infos := h.emitter.Listeners()
+	if topic := r.URL.Query().Get("topic"); topic != "" {
+		matching := infos[:0]
+		for _, info := range infos {
+			if emitter.MatchTopic(info.Pattern, topic) {
+				matching = append(matching, info)
+			}
+		}
+		infos = matching
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(infos)
+}
@@ -0,0 +1,33 @@
+package emitterdebug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestListenersHandler(t *testing.T) {
+	em := emitter.NewMemoryEmitter(emitter.WithListenerSources())
+	noop := func(emitter.Event) error { return nil }
+	em.On("order.*", noop, emitter.WithHandlerName("audit"))
+	em.On("user.created", noop)
+
+	rec := httptest.NewRecorder()
+	NewListenersHandler(em).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?topic=order.created", nil))
+
+	var infos []emitter.ListenerInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &infos); err != nil {
+		t.Fatalf("Decoding response failed with error: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Pattern != "order.*" || infos[0].Handler != "audit" {
+		t.Fatalf("Listeners = %+v, want the order.* audit listener", infos)
+	}
+	if !strings.HasPrefix(filepath.Base(infos[0].Source), "listeners_test.go:") {
+		t.Errorf("Source = %q, want this test file", infos[0].Source)
+	}
+}
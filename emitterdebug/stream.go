@@ -0,0 +1,97 @@
+package emitterdebug
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// DefaultBufferSize is the number of events a stream buffers for a slow client before
+// it starts dropping them.
+const DefaultBufferSize = 256
+
+// StreamHandler streams the events of an emitter to HTTP clients as newline-delimited
+// JSON envelopes. Clients choose the events with the topic query parameter, a topic
+// pattern that defaults to "**".
+//
+// Listeners are registered read-only for the duration of each request and never block
+// emissions: when a client cannot keep up, events are dropped from its stream.
+type StreamHandler struct {
+	emitter    emitter.Emitter
+	bufferSize int
+	mapper     emitter.TopicMapper
+}
+
+// NewStreamHandler creates a StreamHandler for the emitter.
+func NewStreamHandler(e emitter.Emitter) *StreamHandler {
+	return &StreamHandler{emitter: e, bufferSize: DefaultBufferSize}
+}
+
+// SetTopicMapper translates the topics of streamed envelopes into external names with
+// mapper's ToExternal. The topic query parameter still takes a local pattern. Events
+// whose topic cannot be translated are left out of the stream.
+func (h *StreamHandler) SetTopicMapper(mapper emitter.TopicMapper) {
+	h.mapper = mapper
+}
+
+// ServeHTTP subscribes to the requested topic pattern and streams its events until the
+// client disconnects.
+func (h *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	pattern := r.URL.Query().Get("topic")
+	if pattern == "" {
+		pattern = "**"
+	}
+
+	lines := make(chan []byte, h.bufferSize)
+	listenerID, err := h.emitter.On(pattern, func(evt emitter.Event) error {
+		env, err := emitter.NewEnvelope(evt, emitter.JSONCodec{})
+		if err != nil {
+			return nil // Payloads that cannot be encoded are left out of the stream.
+		}
+		if h.mapper != nil {
+			if env.Topic, err = h.mapper.ToExternal(env.Topic); err != nil {
+				return nil
+			}
+		}
+		line, err := json.Marshal(env)
+		if err != nil {
+			return nil
+		}
+		select {
+		case lines <- append(line, '\n'):
+		default: // The client is too slow; drop the event rather than block the emitter.
+		}
+		return nil
+	}, emitter.WithReadOnlyEvent())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer func() {
+		_ = h.emitter.Off(pattern, listenerID) // The emitter may already be closed.
+	}()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line := <-lines:
+			if _, err := w.Write(line); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
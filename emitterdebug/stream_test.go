@@ -0,0 +1,76 @@
+package emitterdebug
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestStreamHandler(t *testing.T) {
+	em := emitter.NewMemoryEmitter()
+	server := httptest.NewServer(NewStreamHandler(em))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?topic=order.*")
+	if err != nil {
+		t.Fatalf("GET failed with error: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+
+	// The listener is registered before the headers are sent.
+	em.EmitSync("user.created", "ignored")
+	em.EmitSync("order.created", map[string]string{"id": "o-1"})
+
+	lines := make(chan string, 1)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		if scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	select {
+	case line := <-lines:
+		var env emitter.Envelope
+		if err := json.Unmarshal([]byte(line), &env); err != nil {
+			t.Fatalf("Decoding streamed line %q failed with error: %v", line, err)
+		}
+		if env.Topic != "order.created" || string(env.Payload) != `{"id":"o-1"}` {
+			t.Errorf("Streamed envelope = %s %s, want order.created", env.Topic, env.Payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("No event was streamed")
+	}
+}
+
+func TestStreamHandlerUnsubscribesOnDisconnect(t *testing.T) {
+	em := emitter.NewMemoryEmitter()
+	server := httptest.NewServer(NewStreamHandler(em))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET failed with error: %v", err)
+	}
+	topic, err := em.GetTopic("**")
+	if err != nil || topic.ListenerCount() != 1 {
+		t.Fatalf("Stream should subscribe to **, got %v", err)
+	}
+	resp.Body.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for topic.ListenerCount() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Stream listener was not removed after the client disconnected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,8 @@
+// Package emitterpb encodes emitter envelopes in the protobuf wire format described by
+// envelope.proto, so that gRPC and Kafka transports and consumers written in other
+// languages share one format with the Go emitter.
+//
+// The encoder is written against the wire format directly and does not depend on a
+// protobuf runtime. Code generated from envelope.proto by protoc reads and writes the
+// same bytes.
+package emitterpb
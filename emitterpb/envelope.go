@@ -0,0 +1,258 @@
+package emitterpb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// ErrMalformedEnvelope is returned when data is not a valid protobuf Envelope.
+var ErrMalformedEnvelope = errors.New("malformed protobuf envelope")
+
+// Field numbers of envelope.proto.
+const (
+	fieldID          = 1
+	fieldTopic       = 2
+	fieldTimestamp   = 3
+	fieldMetadata    = 4
+	fieldContentType = 5
+	fieldPayload     = 6
+	fieldExpiresAt   = 7
+
+	fieldSeconds = 1 // google.protobuf.Timestamp.seconds
+	fieldNanos   = 2 // google.protobuf.Timestamp.nanos
+
+	fieldMapKey   = 1
+	fieldMapValue = 2
+)
+
+// Wire types of the protobuf encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Marshal encodes the envelope as a protobuf Envelope message. Metadata values are
+// encoded as JSON and written in key order, so equal envelopes produce equal bytes.
+func Marshal(env *emitter.Envelope) ([]byte, error) {
+	var b []byte
+	b = appendString(b, fieldID, env.ID)
+	b = appendString(b, fieldTopic, env.Topic)
+	b = appendTimestamp(b, fieldTimestamp, env.Timestamp)
+
+	keys := make([]string, 0, len(env.Metadata))
+	for key := range env.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, err := json.Marshal(env.Metadata[key])
+		if err != nil {
+			return nil, fmt.Errorf("encoding metadata %q: %w", key, err)
+		}
+		var entry []byte
+		entry = appendString(entry, fieldMapKey, key)
+		entry = appendString(entry, fieldMapValue, string(value))
+		b = appendBytes(appendTag(b, fieldMetadata, wireBytes), entry)
+	}
+
+	b = appendString(b, fieldContentType, env.ContentType)
+	if len(env.Payload) > 0 {
+		b = appendBytes(appendTag(b, fieldPayload, wireBytes), env.Payload)
+	}
+	b = appendTimestamp(b, fieldExpiresAt, env.ExpiresAt)
+	return b, nil
+}
+
+// Unmarshal decodes a protobuf Envelope message. Unknown fields are skipped, so
+// envelopes written by newer versions of envelope.proto can still be read.
+func Unmarshal(data []byte) (*emitter.Envelope, error) {
+	env := &emitter.Envelope{}
+	err := readFields(data, func(field int, wire int, value uint64, raw []byte) error {
+		switch {
+		case field == fieldID && wire == wireBytes:
+			env.ID = string(raw)
+		case field == fieldTopic && wire == wireBytes:
+			env.Topic = string(raw)
+		case field == fieldTimestamp && wire == wireBytes:
+			return readTimestamp(raw, &env.Timestamp)
+		case field == fieldExpiresAt && wire == wireBytes:
+			return readTimestamp(raw, &env.ExpiresAt)
+		case field == fieldMetadata && wire == wireBytes:
+			return readMetadataEntry(env, raw)
+		case field == fieldContentType && wire == wireBytes:
+			env.ContentType = string(raw)
+		case field == fieldPayload && wire == wireBytes:
+			env.Payload = append([]byte(nil), raw...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// MarshalEvent encodes evt as a protobuf Envelope, encoding its payload with codec.
+func MarshalEvent(evt emitter.Event, codec emitter.Codec) ([]byte, error) {
+	env, err := emitter.NewEnvelope(evt, codec)
+	if err != nil {
+		return nil, err
+	}
+	return Marshal(env)
+}
+
+// UnmarshalEvent decodes a protobuf Envelope into an event whose payload is still
+// encoded, as described for emitter.Envelope.Event.
+func UnmarshalEvent(data []byte) (*emitter.BaseEvent, error) {
+	env, err := Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return env.Event(), nil
+}
+
+// readTimestamp decodes a google.protobuf.Timestamp message into t, leaving it zero for
+// the Unix epoch as the encoder does.
+func readTimestamp(data []byte, t *time.Time) error {
+	var seconds, nanos int64
+	err := readFields(data, func(field int, wire int, value uint64, _ []byte) error {
+		switch {
+		case field == fieldSeconds && wire == wireVarint:
+			seconds = int64(value)
+		case field == fieldNanos && wire == wireVarint:
+			nanos = int64(int32(value))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if seconds != 0 || nanos != 0 {
+		*t = time.Unix(seconds, nanos).UTC()
+	}
+	return nil
+}
+
+// readMetadataEntry decodes a map entry of the metadata field into env.
+func readMetadataEntry(env *emitter.Envelope, data []byte) error {
+	var key, value string
+	err := readFields(data, func(field int, wire int, _ uint64, raw []byte) error {
+		switch {
+		case field == fieldMapKey && wire == wireBytes:
+			key = string(raw)
+		case field == fieldMapValue && wire == wireBytes:
+			value = string(raw)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var decoded interface{}
+	if value != "" {
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			return fmt.Errorf("%w: metadata %q: %w", ErrMalformedEnvelope, key, err)
+		}
+	}
+	if env.Metadata == nil {
+		env.Metadata = make(map[string]interface{})
+	}
+	env.Metadata[key] = decoded
+	return nil
+}
+
+// readFields calls fn for every field of a message. Varint fields are passed as value
+// and length-delimited fields as raw; fixed-size fields are skipped.
+func readFields(data []byte, fn func(field int, wire int, value uint64, raw []byte) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 || tag>>3 == 0 || tag>>3 > math.MaxInt32 {
+			return ErrMalformedEnvelope
+		}
+		data = data[n:]
+		field, wire := int(tag>>3), int(tag&7)
+
+		var value uint64
+		var raw []byte
+		switch wire {
+		case wireVarint:
+			value, n = binary.Uvarint(data)
+			if n <= 0 {
+				return ErrMalformedEnvelope
+			}
+			data = data[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return ErrMalformedEnvelope
+			}
+			raw = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if wire == wireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return ErrMalformedEnvelope
+			}
+			data = data[size:]
+			continue
+		default:
+			return ErrMalformedEnvelope
+		}
+
+		if err := fn(field, wire, value, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendTimestamp appends a google.protobuf.Timestamp field, omitting it for the zero time.
+func appendTimestamp(b []byte, field int, t time.Time) []byte {
+	if t.IsZero() {
+		return b
+	}
+	var ts []byte
+	if seconds := t.Unix(); seconds != 0 {
+		ts = appendVarint(appendTag(ts, fieldSeconds, wireVarint), uint64(seconds))
+	}
+	if nanos := t.Nanosecond(); nanos != 0 {
+		ts = appendVarint(appendTag(ts, fieldNanos, wireVarint), uint64(nanos))
+	}
+	return appendBytes(appendTag(b, field, wireBytes), ts)
+}
+
+// appendTag appends a field tag.
+func appendTag(b []byte, field int, wire int) []byte {
+	return appendVarint(b, uint64(field)<<3|uint64(wire))
+}
+
+// appendVarint appends v in base-128 varint encoding.
+func appendVarint(b []byte, v uint64) []byte {
+	return binary.AppendUvarint(b, v)
+}
+
+// appendBytes appends a length-prefixed byte string.
+func appendBytes(b []byte, v []byte) []byte {
+	return append(appendVarint(b, uint64(len(v))), v...)
+}
+
+// appendString appends a string field, omitting it when empty as proto3 does.
+func appendString(b []byte, field int, v string) []byte {
+	if v == "" {
+		return b
+	}
+	return append(appendVarint(appendTag(b, field, wireBytes), uint64(len(v))), v...)
+}
@@ -0,0 +1,117 @@
+package emitterpb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestMarshalWireFormat(t *testing.T) {
+	data, err := Marshal(&emitter.Envelope{
+		ID:          "a",
+		Topic:       "t",
+		Timestamp:   time.Unix(1, 2),
+		ContentType: "x",
+		Payload:     []byte{0xff},
+	})
+	if err != nil {
+		t.Fatalf("Marshal() failed with error: %v", err)
+	}
+
+	want := []byte{
+		0x0a, 0x01, 'a', // id
+		0x12, 0x01, 't', // topic
+		0x1a, 0x04, 0x08, 0x01, 0x10, 0x02, // timestamp{seconds: 1, nanos: 2}
+		0x2a, 0x01, 'x', // content_type
+		0x32, 0x01, 0xff, // payload
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("Marshal() = % x, want % x", data, want)
+	}
+}
+
+func TestEventRoundTrip(t *testing.T) {
+	event := emitter.NewEvent("order.created").
+		WithPayload(map[string]int{"total": 42}).
+		WithMetadata("tenant", "acme").
+		WithMetadata("attempt", 2)
+
+	data, err := MarshalEvent(event, emitter.JSONCodec{})
+	if err != nil {
+		t.Fatalf("MarshalEvent() failed with error: %v", err)
+	}
+	decoded, err := UnmarshalEvent(data)
+	if err != nil {
+		t.Fatalf("UnmarshalEvent() failed with error: %v", err)
+	}
+
+	if decoded.Topic() != "order.created" {
+		t.Errorf("Topic() = %q, want order.created", decoded.Topic())
+	}
+	want := map[string]interface{}{"tenant": "acme", "attempt": float64(2)}
+	if got := decoded.Metadata(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Metadata() = %v, want %v", got, want)
+	}
+	var payload map[string]int
+	if err := json.Unmarshal(decoded.Payload().(json.RawMessage), &payload); err != nil || payload["total"] != 42 {
+		t.Errorf("Payload decoded to %v, %v", payload, err)
+	}
+}
+
+func TestEnvelopeExpiresAtRoundTrip(t *testing.T) {
+	env := &emitter.Envelope{
+		Topic:       "quote.price",
+		Timestamp:   time.Unix(100, 0).UTC(),
+		ExpiresAt:   time.Unix(101, 500).UTC(),
+		ContentType: emitter.JSONContentType,
+		Payload:     []byte("42"),
+	}
+	data, err := Marshal(env)
+	if err != nil {
+		t.Fatalf("Marshal() failed with error: %v", err)
+	}
+	decoded, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal() failed with error: %v", err)
+	}
+	if !decoded.ExpiresAt.Equal(env.ExpiresAt) {
+		t.Errorf("ExpiresAt = %v, want %v", decoded.ExpiresAt, env.ExpiresAt)
+	}
+	if got := decoded.Event().ExpiresAt(); !got.Equal(env.ExpiresAt) {
+		t.Errorf("Event().ExpiresAt() = %v, want %v", got, env.ExpiresAt)
+	}
+}
+
+func TestUnmarshalSkipsUnknownFields(t *testing.T) {
+	data := []byte{
+		0x12, 0x01, 't', // topic
+		0x78, 0x05, // field 15, varint
+		0x85, 0x01, 0x01, 0x02, 0x03, 0x04, // field 16, fixed32
+		0x8a, 0x01, 0x02, 'h', 'i', // field 17, bytes
+	}
+	env, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal() failed with error: %v", err)
+	}
+	if env.Topic != "t" {
+		t.Errorf("Topic = %q, want t", env.Topic)
+	}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	for _, data := range [][]byte{
+		{0x12, 0x05, 't'},             // Length beyond the end of the message.
+		{0x0b},                        // Unsupported wire type.
+		{0x00, 0x01},                  // Field number zero.
+		{0x22, 0x03, 0x0a, 0x05, 'k'}, // Truncated metadata entry.
+	} {
+		if _, err := Unmarshal(data); !errors.Is(err, ErrMalformedEnvelope) {
+			t.Errorf("Unmarshal(% x) = %v, want %v", data, err, ErrMalformedEnvelope)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package emittertest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// FakeClock is an emitter.Clock whose time only moves when Advance is called. Functions
+// scheduled with AfterFunc run synchronously, in order of their due time, while the
+// clock is advanced past them.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+	seq    int // Orders timers that are due at the same instant.
+}
+
+// fakeTimer is a function scheduled on a FakeClock.
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	seq   int
+	fn    func()
+}
+
+// NewFakeClock creates a FakeClock set to the given time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current virtual time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to run once the clock has been advanced by d.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) emitter.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	t := &fakeTimer{clock: c, when: c.now.Add(d), seq: c.seq, fn: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d, running every timer that becomes due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.advance(d, nil)
+}
+
+// PendingTimers returns the number of scheduled timers that have not fired yet.
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// advance moves the clock forward by d. Timers fire one at a time with the clock set to
+// their due time, and afterFire, if set, runs after each of them.
+func (c *FakeClock) advance(d time.Duration, afterFire func()) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		next := c.popDue(target)
+		if next == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		c.now = next.when
+		c.mu.Unlock()
+
+		next.fn()
+		if afterFire != nil {
+			afterFire()
+		}
+	}
+}
+
+// popDue removes and returns the earliest timer due at or before target. Callers must
+// hold c.mu.
+func (c *FakeClock) popDue(target time.Time) *fakeTimer {
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		if c.timers[i].when.Equal(c.timers[j].when) {
+			return c.timers[i].seq < c.timers[j].seq
+		}
+		return c.timers[i].when.Before(c.timers[j].when)
+	})
+	if len(c.timers) == 0 || c.timers[0].when.After(target) {
+		return nil
+	}
+	next := c.timers[0]
+	c.timers = c.timers[1:]
+	return next
+}
+
+// Stop cancels the timer. It reports whether the timer was still pending.
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package emittertest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvanceRunsDueTimersInOrder(t *testing.T) {
+	clock := NewFakeClock(DefaultStartTime)
+
+	var fired []string
+	var firedAt []time.Duration
+	schedule := func(name string, d time.Duration) {
+		clock.AfterFunc(d, func() {
+			fired = append(fired, name)
+			firedAt = append(firedAt, clock.Now().Sub(DefaultStartTime))
+		})
+	}
+	schedule("late", 3*time.Second)
+	schedule("early", time.Second)
+	schedule("tie-first", 2*time.Second)
+	schedule("tie-second", 2*time.Second)
+
+	clock.Advance(2 * time.Second)
+	if len(fired) != 3 || fired[0] != "early" || fired[1] != "tie-first" || fired[2] != "tie-second" {
+		t.Fatalf("Fired timers = %v, want [early tie-first tie-second]", fired)
+	}
+	if firedAt[0] != time.Second || firedAt[1] != 2*time.Second {
+		t.Errorf("Timers fired at %v, want their due times", firedAt)
+	}
+	if clock.PendingTimers() != 1 {
+		t.Errorf("PendingTimers() = %d, want 1", clock.PendingTimers())
+	}
+
+	clock.Advance(time.Hour)
+	if len(fired) != 4 || fired[3] != "late" {
+		t.Errorf("Fired timers = %v, want late to fire last", fired)
+	}
+	if got := clock.Now().Sub(DefaultStartTime); got != time.Hour+2*time.Second {
+		t.Errorf("Now() advanced by %v, want %v", got, time.Hour+2*time.Second)
+	}
+}
+
+func TestFakeClockStop(t *testing.T) {
+	clock := NewFakeClock(DefaultStartTime)
+
+	timer := clock.AfterFunc(time.Second, func() {
+		t.Error("Stopped timer should not fire")
+	})
+	if !timer.Stop() {
+		t.Error("Stop() should report that the timer was pending")
+	}
+	if timer.Stop() {
+		t.Error("Stop() should report false for an already stopped timer")
+	}
+
+	clock.Advance(time.Minute)
+}
+
+func TestFakeClockTimerSchedulingTimer(t *testing.T) {
+	clock := NewFakeClock(DefaultStartTime)
+
+	ticks := 0
+	var tick func()
+	tick = func() {
+		ticks++
+		clock.AfterFunc(time.Second, tick)
+	}
+	clock.AfterFunc(time.Second, tick)
+
+	clock.Advance(5 * time.Second)
+	if ticks != 5 {
+		t.Errorf("Timer chain fired %d times, want 5", ticks)
+	}
+}
@@ -0,0 +1,3 @@
+// Package emittertest provides utilities for testing code built on the emitter package,
+// including a virtual clock and an emitter whose asynchronous work runs deterministically.
+package emittertest
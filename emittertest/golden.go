@@ -0,0 +1,141 @@
+package emittertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that makes AssertGolden rewrite golden files
+// instead of comparing against them, when set to a true value:
+//
+//	EMITTERTEST_UPDATE=1 go test ./...
+//
+// An environment variable rather than a flag leaves test packages free to define their
+// own -update flag.
+const UpdateEnv = "EMITTERTEST_UPDATE"
+
+// goldenDir is the directory, relative to the test's package, holding golden files.
+const goldenDir = "testdata"
+
+// FormatRecords renders records in the golden file format: one line per event holding
+// the topic and the payload as canonical JSON, with object keys sorted. Events emitted
+// with several arguments are rendered as a JSON array of all arguments.
+func FormatRecords(records []Record) ([]byte, error) {
+	var b bytes.Buffer
+	for i, r := range records {
+		var value interface{} = r.Payload
+		if r.Args != nil {
+			value = r.Args
+		}
+		payload, err := canonicalJSON(value)
+		if err != nil {
+			return nil, fmt.Errorf("event #%d (%s): %w", i+1, r.Topic, err)
+		}
+		b.WriteString(r.Topic)
+		b.WriteByte(' ')
+		b.Write(payload)
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
+
+// canonicalJSON marshals v and normalizes it so that struct fields and map keys are
+// emitted in sorted order.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// AssertGolden compares the events recorded by source with the golden file
+// testdata/<name>.golden and reports any difference on t. Running the tests with
+// UpdateEnv set writes the observed sequence to the file instead.
+//
+// The comparison is order-sensitive, so sources should record events deterministically,
+// as VirtualEmitter does.
+func AssertGolden(t testing.TB, source RecordSource, name string) bool {
+	t.Helper()
+
+	got, err := FormatRecords(source.Records())
+	if err != nil {
+		t.Errorf("formatting events for golden file %q: %v", name, err)
+		return false
+	}
+	if err := compareGolden(filepath.Join(goldenDir, name+".golden"), got, updateGolden()); err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+// updateGolden reports whether UpdateEnv asks for golden files to be rewritten.
+func updateGolden() bool {
+	update, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return update
+}
+
+// compareGolden checks got against the golden file at path, or rewrites the file when
+// update is set.
+func compareGolden(path string, got []byte, update bool) error {
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, got, 0o644)
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("golden file %s does not exist; run the test with %s=1 to create it", path, UpdateEnv)
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(got, want) {
+		return nil
+	}
+	return fmt.Errorf("events differ from golden file %s (run with %s=1 to accept them):\n%s", path, UpdateEnv, diffLines(want, got))
+}
+
+// diffLines describes the first line at which want and got differ.
+func diffLines(want, got []byte) string {
+	wantLines := strings.Split(strings.TrimSuffix(string(want), "\n"), "\n")
+	gotLines := strings.Split(strings.TrimSuffix(string(got), "\n"), "\n")
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, orNone(w, i < len(wantLines)), orNone(g, i < len(gotLines)))
+		}
+	}
+	return "files differ only in trailing content"
+}
+
+// orNone returns line, or a placeholder when the line does not exist.
+func orNone(line string, exists bool) string {
+	if !exists {
+		return "<no event>"
+	}
+	return line
+}
@@ -0,0 +1,97 @@
+package emittertest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestFormatRecords(t *testing.T) {
+	got, err := FormatRecords([]Record{
+		{Topic: "order.created", Payload: order{ID: "123", Amount: 10}},
+		{Topic: "email.sent", Payload: map[string]interface{}{"to": "a@example.com", "cc": nil}},
+		{Topic: "ping"},
+	})
+	if err != nil {
+		t.Fatalf("FormatRecords() failed with error: %v", err)
+	}
+
+	want := `order.created {"Amount":10,"ID":"123"}
+email.sent {"cc":null,"to":"a@example.com"}
+ping null
+`
+	if string(got) != want {
+		t.Errorf("FormatRecords() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := FormatRecords([]Record{{Topic: "bad", Payload: make(chan int)}}); err == nil {
+		t.Error("FormatRecords() should fail for payloads that cannot be encoded as JSON")
+	}
+}
+
+func TestCompareGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "flow.golden")
+	got := []byte("order.created {\"ID\":\"123\"}\nemail.sent null\n")
+
+	if err := compareGolden(path, got, false); err == nil || !strings.Contains(err.Error(), UpdateEnv) {
+		t.Errorf("compareGolden() error = %v, want a hint to set %s", err, UpdateEnv)
+	}
+
+	if err := compareGolden(path, got, true); err != nil {
+		t.Fatalf("compareGolden() with update failed with error: %v", err)
+	}
+	if written, _ := os.ReadFile(path); string(written) != string(got) {
+		t.Errorf("Golden file content = %q, want %q", written, got)
+	}
+	if err := compareGolden(path, got, false); err != nil {
+		t.Errorf("compareGolden() failed with error: %v", err)
+	}
+
+	changed := []byte("order.created {\"ID\":\"123\"}\n")
+	err := compareGolden(path, changed, false)
+	if err == nil || !strings.Contains(err.Error(), "line 2:\n  want: email.sent null\n  got:  <no event>") {
+		t.Errorf("compareGolden() error = %v, want it to describe the missing event", err)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	v := NewVirtualEmitter()
+	v.On("order.created", func(e emitter.Event) error {
+		v.Emit("email.queued", map[string]string{"template": "receipt"})
+		return nil
+	})
+
+	v.Emit("order.created", order{ID: "123", Amount: 10})
+	if err := v.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() failed with error: %v", err)
+	}
+
+	AssertGolden(t, v, "checkout")
+}
+
+func TestFormatRecordsWithArgs(t *testing.T) {
+	v := NewVirtualEmitter()
+	v.EmitSync("user.banned", "user-1", "admin")
+	v.EmitSync("ping")
+
+	got, err := FormatRecords(v.Records())
+	if err != nil {
+		t.Fatalf("FormatRecords() failed with error: %v", err)
+	}
+	if want := "user.banned [\"user-1\",\"admin\"]\nping null\n"; string(got) != want {
+		t.Errorf("FormatRecords() = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateGoldenEnv(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "0": false, "1": true, "true": true} {
+		t.Setenv(UpdateEnv, value)
+		if got := updateGolden(); got != want {
+			t.Errorf("updateGolden() with %s=%q = %v, want %v", UpdateEnv, value, got, want)
+		}
+	}
+}
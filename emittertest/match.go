@@ -0,0 +1,228 @@
+package emittertest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// RecordSource provides the events observed during a test, in dispatch order.
+// VirtualEmitter implements it.
+type RecordSource interface {
+	Records() []Record
+}
+
+// PayloadMatcher reports whether an event payload satisfies a condition. String
+// describes the condition in failure messages.
+type PayloadMatcher interface {
+	Match(payload interface{}) bool
+	String() string
+}
+
+// payloadFunc adapts a function to the PayloadMatcher interface.
+type payloadFunc struct {
+	desc  string
+	match func(interface{}) bool
+}
+
+func (p payloadFunc) Match(payload interface{}) bool { return p.match(payload) }
+func (p payloadFunc) String() string                 { return p.desc }
+
+// PayloadWhere returns a PayloadMatcher that accepts payloads for which fn returns true.
+// The description is used in failure messages.
+func PayloadWhere(desc string, fn func(payload interface{}) bool) PayloadMatcher {
+	return payloadFunc{desc: desc, match: fn}
+}
+
+// Equals matches payloads that are deeply equal to want.
+func Equals(want interface{}) PayloadMatcher {
+	return payloadFunc{
+		desc:  fmt.Sprintf("Equals(%#v)", want),
+		match: func(payload interface{}) bool { return reflect.DeepEqual(payload, want) },
+	}
+}
+
+// HasField matches struct payloads, or pointers to them, whose exported field name is
+// deeply equal to want. Maps with string keys are matched on the key name.
+func HasField(name string, want interface{}) PayloadMatcher {
+	return payloadFunc{
+		desc: fmt.Sprintf("HasField(%q, %#v)", name, want),
+		match: func(payload interface{}) bool {
+			value, ok := fieldValue(payload, name)
+			return ok && reflect.DeepEqual(value, want)
+		},
+	}
+}
+
+// fieldValue looks up a named struct field or string map key in payload.
+func fieldValue(payload interface{}, name string) (interface{}, bool) {
+	v := reflect.ValueOf(payload)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		field, ok := v.Type().FieldByName(name)
+		if !ok || !field.IsExported() {
+			return nil, false
+		}
+		return v.FieldByIndex(field.Index).Interface(), true
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !value.IsValid() {
+			return nil, false
+		}
+		return value.Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+// EventMatcher describes events expected among the records of a test. Build one with
+// Emitted and refine it with the chained methods:
+//
+//	Emitted("order.*").WithPayload(HasField("ID", "123")).Times(2).Before(Emitted("email.sent"))
+type EventMatcher struct {
+	pattern string
+	payload []PayloadMatcher
+	times   int // Exact number of matches expected, or -1 for at least one.
+	before  []*EventMatcher
+}
+
+// Emitted returns a matcher for events whose topic matches the pattern. Wildcards follow
+// the same rules as listener subscriptions. By default at least one matching event is
+// expected.
+func Emitted(pattern string) *EventMatcher {
+	return &EventMatcher{pattern: pattern, times: -1}
+}
+
+// WithPayload restricts the matcher to events whose payload satisfies every condition.
+func (m *EventMatcher) WithPayload(conditions ...PayloadMatcher) *EventMatcher {
+	m.payload = append(m.payload, conditions...)
+	return m
+}
+
+// Times expects exactly n matching events. Times(0) asserts that no such event occurred.
+func (m *EventMatcher) Times(n int) *EventMatcher {
+	m.times = n
+	return m
+}
+
+// Before expects every event matched by m to be dispatched before the first event
+// matched by other, and other to occur at least once.
+func (m *EventMatcher) Before(other *EventMatcher) *EventMatcher {
+	m.before = append(m.before, other)
+	return m
+}
+
+// String describes the matcher in the same form it is built.
+func (m *EventMatcher) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Emitted(%q)", m.pattern)
+	if len(m.payload) > 0 {
+		descs := make([]string, len(m.payload))
+		for i, p := range m.payload {
+			descs[i] = p.String()
+		}
+		fmt.Fprintf(&b, ".WithPayload(%s)", strings.Join(descs, ", "))
+	}
+	if m.times >= 0 {
+		fmt.Fprintf(&b, ".Times(%d)", m.times)
+	}
+	return b.String()
+}
+
+// matches reports whether a single record satisfies the topic and payload conditions.
+func (m *EventMatcher) matches(r Record) bool {
+	if !emitter.MatchTopic(m.pattern, r.Topic) {
+		return false
+	}
+	for _, p := range m.payload {
+		if !p.Match(r.Payload) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexes returns the positions of the records satisfied by m.
+func (m *EventMatcher) indexes(records []Record) []int {
+	var found []int
+	for i, r := range records {
+		if m.matches(r) {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+// Match checks the matcher against records and returns an error describing the first
+// unmet expectation along with the observed event sequence.
+func (m *EventMatcher) Match(records []Record) error {
+	found := m.indexes(records)
+
+	switch {
+	case m.times < 0 && len(found) == 0:
+		return m.failure(records, "expected %s, but no matching event was emitted", m)
+	case m.times >= 0 && len(found) != m.times:
+		return m.failure(records, "expected %s, but %d matching events were emitted", m, len(found))
+	}
+
+	for _, other := range m.before {
+		if err := other.Match(records); err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			continue
+		}
+		first := other.indexes(records)[0]
+		last := found[len(found)-1]
+		if last > first {
+			return m.failure(records, "expected %s before %s, but event #%d (%s) was emitted after event #%d (%s)",
+				m, other, last+1, records[last].Topic, first+1, records[first].Topic)
+		}
+	}
+	return nil
+}
+
+// failure formats an error message followed by the observed events.
+func (m *EventMatcher) failure(records []Record, format string, args ...interface{}) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, format, args...)
+	if len(records) == 0 {
+		b.WriteString("\nno events were emitted")
+		return errors.New(b.String())
+	}
+	b.WriteString("\nemitted events:")
+	for i, r := range records {
+		fmt.Fprintf(&b, "\n  #%d %s %+v", i+1, r.Topic, r.Payload)
+	}
+	return errors.New(b.String())
+}
+
+// AssertEmitted checks every matcher against the records of source and reports each
+// failure on t. It returns whether all matchers were satisfied.
+func AssertEmitted(t testing.TB, source RecordSource, matchers ...*EventMatcher) bool {
+	t.Helper()
+
+	records := source.Records()
+	ok := true
+	for _, m := range matchers {
+		if err := m.Match(records); err != nil {
+			t.Error(err)
+			ok = false
+		}
+	}
+	return ok
+}
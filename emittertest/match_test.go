@@ -0,0 +1,116 @@
+package emittertest
+
+import (
+	"strings"
+	"testing"
+)
+
+type order struct {
+	ID     string
+	Amount int
+}
+
+func orderRecords() []Record {
+	return []Record{
+		{Topic: "order.created", Payload: order{ID: "123", Amount: 10}},
+		{Topic: "order.paid", Payload: &order{ID: "123", Amount: 10}},
+		{Topic: "email.sent", Payload: map[string]interface{}{"to": "a@example.com"}},
+		{Topic: "order.created", Payload: order{ID: "456"}},
+	}
+}
+
+func TestEventMatcher(t *testing.T) {
+	records := orderRecords()
+
+	tests := map[string]struct {
+		matcher *EventMatcher
+		wantErr string
+	}{
+		"wildcard": {
+			matcher: Emitted("order.*").Times(3),
+		},
+		"payload field": {
+			matcher: Emitted("order.*").WithPayload(HasField("ID", "123")).Times(2),
+		},
+		"map payload": {
+			matcher: Emitted("email.sent").WithPayload(HasField("to", "a@example.com")),
+		},
+		"equals": {
+			matcher: Emitted("order.created").WithPayload(Equals(order{ID: "456"})).Times(1),
+		},
+		"custom condition": {
+			matcher: Emitted("order.created").WithPayload(PayloadWhere("Amount > 5", func(p interface{}) bool {
+				return p.(order).Amount > 5
+			})).Times(1),
+		},
+		"before": {
+			matcher: Emitted("order.*").WithPayload(HasField("ID", "123")).Times(2).Before(Emitted("email.sent")),
+		},
+		"never": {
+			matcher: Emitted("order.refunded").Times(0),
+		},
+		"missing": {
+			matcher: Emitted("order.refunded"),
+			wantErr: `expected Emitted("order.refunded"), but no matching event was emitted`,
+		},
+		"wrong count": {
+			matcher: Emitted("order.created").Times(1),
+			wantErr: `expected Emitted("order.created").Times(1), but 2 matching events were emitted`,
+		},
+		"unknown field": {
+			matcher: Emitted("order.created").WithPayload(HasField("Missing", 1)),
+			wantErr: `Emitted("order.created").WithPayload(HasField("Missing", 1))`,
+		},
+		"out of order": {
+			matcher: Emitted("order.created").Before(Emitted("email.sent")),
+			wantErr: `expected Emitted("order.created") before Emitted("email.sent"), but event #4 (order.created) was emitted after event #3 (email.sent)`,
+		},
+		"before target missing": {
+			matcher: Emitted("order.paid").Before(Emitted("shipment.created")),
+			wantErr: `expected Emitted("shipment.created"), but no matching event was emitted`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.matcher.Match(records)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Match() failed with error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Match() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventMatcherFailureListsEvents(t *testing.T) {
+	err := Emitted("order.refunded").Match(orderRecords())
+	if err == nil {
+		t.Fatal("Match() should fail")
+	}
+	if !strings.Contains(err.Error(), "#2 order.paid &{ID:123 Amount:10}") {
+		t.Errorf("Failure message should list the emitted events, got:\n%v", err)
+	}
+
+	err = Emitted("order.refunded").Match(nil)
+	if err == nil || !strings.Contains(err.Error(), "no events were emitted") {
+		t.Errorf("Match() error = %v, want it to report that no events were emitted", err)
+	}
+}
+
+func TestAssertEmittedWithVirtualEmitter(t *testing.T) {
+	v := NewVirtualEmitter()
+	v.EmitSync("order.created", order{ID: "123"})
+	v.EmitSync("email.sent", nil)
+
+	if !AssertEmitted(t, v,
+		Emitted("order.created").WithPayload(HasField("ID", "123")).Times(1).Before(Emitted("email.sent")),
+		Emitted("order.cancelled").Times(0),
+	) {
+		t.Error("AssertEmitted() should report success")
+	}
+}
@@ -0,0 +1,54 @@
+package emittertest
+
+import "sync"
+
+// ManualPool is an emitter.Pool that queues submitted tasks instead of running them.
+// Tasks run, in submission order, only when RunPending is called, which makes
+// asynchronous emissions fully deterministic.
+type ManualPool struct {
+	mu    sync.Mutex
+	queue []func()
+}
+
+// NewManualPool creates an empty ManualPool.
+func NewManualPool() *ManualPool {
+	return &ManualPool{}
+}
+
+// Submit queues the task.
+func (p *ManualPool) Submit(task func()) {
+	p.mu.Lock()
+	p.queue = append(p.queue, task)
+	p.mu.Unlock()
+}
+
+// Running returns the number of queued tasks.
+func (p *ManualPool) Running() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.queue)
+}
+
+// Release runs every queued task, so that closing an emitter completes its work.
+func (p *ManualPool) Release() {
+	p.RunPending()
+}
+
+// RunPending runs queued tasks on the calling goroutine until the queue is empty,
+// including tasks submitted by the tasks themselves. It returns how many tasks ran.
+func (p *ManualPool) RunPending() int {
+	ran := 0
+	for {
+		p.mu.Lock()
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return ran
+		}
+		task := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		task()
+		ran++
+	}
+}
@@ -0,0 +1,28 @@
+package emittertest
+
+import "testing"
+
+func TestManualPoolRunsTasksInOrder(t *testing.T) {
+	pool := NewManualPool()
+
+	var order []int
+	pool.Submit(func() {
+		order = append(order, 1)
+		pool.Submit(func() { order = append(order, 3) })
+	})
+	pool.Submit(func() { order = append(order, 2) })
+
+	if len(order) != 0 {
+		t.Fatal("Submit() should not run tasks immediately")
+	}
+	if pool.Running() != 2 {
+		t.Errorf("Running() = %d, want 2", pool.Running())
+	}
+
+	if ran := pool.RunPending(); ran != 3 {
+		t.Errorf("RunPending() ran %d tasks, want 3", ran)
+	}
+	if len(order) != 3 || order[0] != 1 || order[1] != 2 || order[2] != 3 {
+		t.Errorf("Task order = %v, want [1 2 3]", order)
+	}
+}
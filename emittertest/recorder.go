@@ -0,0 +1,118 @@
+package emittertest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// Call is a listener invocation captured by a Recorder.
+type Call struct {
+	Label   string // Label given to Listener or Record.
+	Topic   string
+	Payload interface{}
+}
+
+// Recorder captures listener invocations in the order they happen. It is safe for
+// concurrent use, so listeners running on pool goroutines can record into the same
+// Recorder, and Wait replaces WaitGroup bookkeeping in tests of asynchronous emissions.
+type Recorder struct {
+	mu      sync.Mutex
+	calls   []Call
+	changed chan struct{} // Closed and replaced whenever a call is recorded.
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{changed: make(chan struct{})}
+}
+
+// Listener returns a listener that records each invocation under label and returns nil.
+func (r *Recorder) Listener(label string) emitter.Listener {
+	return func(evt emitter.Event) error {
+		r.Record(label, evt)
+		return nil
+	}
+}
+
+// Record captures an invocation of the listener identified by label. Call it from
+// listeners that need to do more than record.
+func (r *Recorder) Record(label string, evt emitter.Event) {
+	call := Call{Label: label}
+	if evt != nil {
+		call.Topic = evt.Topic()
+		call.Payload = evt.Payload()
+	}
+
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	close(r.changed)
+	r.changed = make(chan struct{})
+	r.mu.Unlock()
+}
+
+// Calls returns a copy of the recorded invocations in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Labels returns the labels of the recorded invocations in order.
+func (r *Recorder) Labels() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	labels := make([]string, len(r.calls))
+	for i, c := range r.calls {
+		labels[i] = c.Label
+	}
+	return labels
+}
+
+// Len returns the number of recorded invocations.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.calls)
+}
+
+// Records returns the recorded invocations as Records, so a Recorder can be used with
+// AssertEmitted and AssertGolden.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := make([]Record, len(r.calls))
+	for i, c := range r.calls {
+		records[i] = Record{Topic: c.Topic, Payload: c.Payload}
+	}
+	return records
+}
+
+// Wait blocks until at least n invocations have been recorded or ctx is done, in which
+// case the context error is returned.
+func (r *Recorder) Wait(ctx context.Context, n int) error {
+	for {
+		r.mu.Lock()
+		count, changed := len(r.calls), r.changed
+		r.mu.Unlock()
+
+		if count >= n {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Reset discards the recorded invocations.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.calls = nil
+	r.mu.Unlock()
+}
@@ -0,0 +1,81 @@
+package emittertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestRecorderConcurrentListeners(t *testing.T) {
+	e := emitter.NewMemoryEmitter()
+	defer e.Close()
+
+	rec := NewRecorder()
+	e.On("job.*", rec.Listener("worker"))
+
+	const emissions = 50
+	for i := 0; i < emissions; i++ {
+		e.Emit("job.done", i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := rec.Wait(ctx, emissions); err != nil {
+		t.Fatalf("Wait() failed with error: %v", err)
+	}
+
+	seen := make(map[interface{}]bool)
+	for _, call := range rec.Calls() {
+		if call.Label != "worker" || call.Topic != "job.done" {
+			t.Errorf("Unexpected call %+v", call)
+		}
+		seen[call.Payload] = true
+	}
+	if len(seen) != emissions {
+		t.Errorf("Recorded %d distinct payloads, want %d", len(seen), emissions)
+	}
+}
+
+func TestRecorderOrder(t *testing.T) {
+	e := emitter.NewMemoryEmitter()
+	rec := NewRecorder()
+
+	for _, p := range []emitter.Priority{emitter.Low, emitter.Highest, emitter.Normal} {
+		e.On("topic", rec.Listener(p.String()), emitter.WithPriority(p))
+	}
+	e.On("topic", func(evt emitter.Event) error {
+		rec.Record("custom", evt)
+		return errors.New("custom listener failed")
+	}, emitter.WithPriority(emitter.Lowest))
+
+	e.EmitSync("topic", "payload")
+
+	got := fmt.Sprint(rec.Labels())
+	if want := "[highest normal low custom]"; got != want {
+		t.Errorf("Labels() = %s, want %s", got, want)
+	}
+	if rec.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", rec.Len())
+	}
+
+	AssertEmitted(t, rec, Emitted("topic").WithPayload(Equals("payload")).Times(4))
+
+	rec.Reset()
+	if rec.Len() != 0 {
+		t.Error("Reset() should discard recorded calls")
+	}
+}
+
+func TestRecorderWaitTimeout(t *testing.T) {
+	rec := NewRecorder()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := rec.Wait(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
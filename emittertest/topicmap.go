@@ -0,0 +1,31 @@
+package emittertest
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// AssertTopicMapping checks that mapper translates each local topic of mappings into its
+// external name with ToExternal and back with ToLocal, and reports each failure on t. It
+// returns whether every mapping held in both directions.
+//
+//	emittertest.AssertTopicMapping(t, mapper, map[string]string{
+//		"order.created": "prod.orders.created",
+//	})
+func AssertTopicMapping(t testing.TB, mapper emitter.TopicMapper, mappings map[string]string) bool {
+	t.Helper()
+
+	ok := true
+	for local, external := range mappings {
+		if got, err := mapper.ToExternal(local); err != nil || got != external {
+			t.Errorf("ToExternal(%q) = %q, %v, want %q", local, got, err, external)
+			ok = false
+		}
+		if got, err := mapper.ToLocal(external); err != nil || got != local {
+			t.Errorf("ToLocal(%q) = %q, %v, want %q", external, got, err, local)
+			ok = false
+		}
+	}
+	return ok
+}
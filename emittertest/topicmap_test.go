@@ -0,0 +1,19 @@
+package emittertest
+
+import (
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestAssertTopicMapping(t *testing.T) {
+	mapper := emitter.NewTopicMap(emitter.TopicRule{Local: "order", External: "prod.orders"})
+	if !AssertTopicMapping(t, mapper, map[string]string{"order.created": "prod.orders.created"}) {
+		t.Error("AssertTopicMapping() failed on a valid mapping")
+	}
+
+	failing := &testing.T{}
+	if AssertTopicMapping(failing, mapper, map[string]string{"user.created": "prod.users.created"}) {
+		t.Error("AssertTopicMapping() passed on an unmapped topic")
+	}
+}
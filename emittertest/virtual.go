@@ -0,0 +1,108 @@
+package emittertest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// DefaultStartTime is the initial time of the clock used by NewVirtualEmitter.
+var DefaultStartTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Record describes an event observed by a VirtualEmitter.
+type Record struct {
+	ID      string
+	Topic   string
+	Payload interface{}
+	Args    []interface{} // All arguments, set only for events emitted with several.
+	Time    time.Time     // Virtual time at which the event was dispatched.
+}
+
+// VirtualEmitter is a MemoryEmitter wired to a FakeClock and a ManualPool. Asynchronous
+// emissions and delayed work only run when Flush or Advance is called, and every
+// dispatched event is recorded, which makes time-dependent tests deterministic.
+type VirtualEmitter struct {
+	*emitter.MemoryEmitter
+	Clock *FakeClock
+	Pool  *ManualPool
+
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewVirtualEmitter creates a VirtualEmitter. The given options are applied after the
+// virtual clock and pool, so they can override them if needed.
+func NewVirtualEmitter(opts ...emitter.EmitterOption) *VirtualEmitter {
+	v := &VirtualEmitter{
+		Clock: NewFakeClock(DefaultStartTime),
+		Pool:  NewManualPool(),
+	}
+
+	base := []emitter.EmitterOption{emitter.WithClock(v.Clock), emitter.WithPool(v.Pool)}
+	v.MemoryEmitter = emitter.NewMemoryEmitter(append(base, opts...)...)
+
+	// Record every dispatched event ahead of the highest user priority, so that no
+	// listener can abort it before it is recorded.
+	_, _ = v.MemoryEmitter.On(emitter.MultiWildcard, v.record, emitter.WithPriority(emitter.Highest+1), emitter.WithReadOnlyEvent())
+	return v
+}
+
+// record stores a dispatched event.
+func (v *VirtualEmitter) record(evt emitter.Event) error {
+	r := Record{Topic: evt.Topic(), Payload: evt.Payload(), Time: v.Clock.Now()}
+	if multi, ok := evt.(interface{ Args() []interface{} }); ok {
+		if args := multi.Args(); len(args) != 1 {
+			r.Args = args
+		}
+	}
+	if identified, ok := evt.(interface{ ID() string }); ok {
+		r.ID = identified.ID()
+	}
+
+	v.mu.Lock()
+	v.records = append(v.records, r)
+	v.mu.Unlock()
+	return nil
+}
+
+// Flush runs every queued asynchronous emission, including emissions they trigger, on
+// the calling goroutine. It never waits on real time.
+func (v *VirtualEmitter) Flush(ctx context.Context) error {
+	v.Pool.RunPending()
+	return v.MemoryEmitter.Flush(ctx)
+}
+
+// Advance moves the virtual clock forward by d. Each timer that becomes due fires at its
+// own virtual time and the asynchronous work it causes is flushed before the next one.
+func (v *VirtualEmitter) Advance(d time.Duration) {
+	v.Pool.RunPending()
+	v.Clock.advance(d, func() { v.Pool.RunPending() })
+}
+
+// Records returns a copy of the events dispatched so far, in dispatch order.
+func (v *VirtualEmitter) Records() []Record {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]Record(nil), v.records...)
+}
+
+// Topics returns the topics of the events dispatched so far, in dispatch order.
+func (v *VirtualEmitter) Topics() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	topics := make([]string, len(v.records))
+	for i, r := range v.records {
+		topics[i] = r.Topic
+	}
+	return topics
+}
+
+// ResetRecords discards the recorded events.
+func (v *VirtualEmitter) ResetRecords() {
+	v.mu.Lock()
+	v.records = nil
+	v.mu.Unlock()
+}
@@ -0,0 +1,114 @@
+package emittertest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+)
+
+func TestVirtualEmitterFlush(t *testing.T) {
+	v := NewVirtualEmitter()
+
+	calls := 0
+	v.On("order.created", func(e emitter.Event) error {
+		calls++
+		v.Emit("email.queued", e.Payload()) // Follow-up async work.
+		return nil
+	})
+
+	v.Emit("order.created", "order-1")
+	if calls != 0 {
+		t.Fatal("Async emissions should not run before Flush()")
+	}
+
+	if err := v.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() failed with error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Listener called %d times, want 1", calls)
+	}
+
+	topics := v.Topics()
+	if len(topics) != 2 || topics[0] != "order.created" || topics[1] != "email.queued" {
+		t.Errorf("Recorded topics = %v, want [order.created email.queued]", topics)
+	}
+}
+
+func TestVirtualEmitterAdvanceDrivesDelayedEmissions(t *testing.T) {
+	v := NewVirtualEmitter()
+
+	v.EmitAfter(time.Minute, "reminder.due", "first")
+	v.EmitAfter(2*time.Minute, "reminder.due", "second")
+	canceled := v.EmitAfter(90*time.Second, "reminder.due", "canceled")
+	canceled.Stop()
+
+	v.Advance(time.Minute)
+	if records := v.Records(); len(records) != 1 || records[0].Payload != "first" {
+		t.Fatalf("Records after 1m = %v, want only the first reminder", records)
+	}
+
+	v.Advance(time.Hour)
+	records := v.Records()
+	if len(records) != 2 || records[1].Payload != "second" {
+		t.Fatalf("Records after 1h = %v, want both reminders", records)
+	}
+	if got := records[1].Time.Sub(DefaultStartTime); got != 2*time.Minute {
+		t.Errorf("Second reminder dispatched at +%v, want +2m", got)
+	}
+}
+
+func TestVirtualEmitterDebouncePattern(t *testing.T) {
+	v := NewVirtualEmitter()
+
+	// A simple debouncer: each input reschedules the flush one second later.
+	var pending emitter.Timer
+	v.On("input.changed", func(e emitter.Event) error {
+		if pending != nil {
+			pending.Stop()
+		}
+		pending = v.EmitAfter(time.Second, "input.settled", e.Payload())
+		return nil
+	})
+
+	for i, value := range []string{"a", "ab", "abc"} {
+		v.EmitSync("input.changed", value)
+		if i < 2 {
+			v.Advance(500 * time.Millisecond)
+		}
+	}
+	v.Advance(time.Second)
+
+	var settled []interface{}
+	for _, r := range v.Records() {
+		if r.Topic == "input.settled" {
+			settled = append(settled, r.Payload)
+		}
+	}
+	if len(settled) != 1 || settled[0] != "abc" {
+		t.Errorf("Settled payloads = %v, want [abc]", settled)
+	}
+}
+
+func TestVirtualEmitterEventTimestamps(t *testing.T) {
+	v := NewVirtualEmitter()
+
+	var stamp time.Time
+	v.On("tick", func(e emitter.Event) error {
+		stamp = e.(*emitter.BaseEvent).Timestamp()
+		return nil
+	})
+
+	v.Advance(time.Hour)
+	v.EmitSync("tick", nil)
+
+	if want := DefaultStartTime.Add(time.Hour); !stamp.Equal(want) {
+		t.Errorf("Event timestamp = %v, want %v", stamp, want)
+	}
+
+	v.ResetRecords()
+	if len(v.Records()) != 0 {
+		t.Error("ResetRecords() should discard recorded events")
+	}
+}
@@ -0,0 +1,8 @@
+// Package emittertx runs emitter listeners inside database transactions. Listener
+// begins a transaction before each call, hands it to the listener through the context,
+// and commits it when the listener succeeds or rolls it back when it fails or panics.
+//
+// Transactions are abstracted by the Tx and Beginner interfaces, so that any database
+// library can be plugged in: DB adapts database/sql, and the transactions of
+// github.com/jackc/pgx satisfy Tx as they are.
+package emittertx
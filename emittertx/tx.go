@@ -0,0 +1,127 @@
+package emittertx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// Tx is a database transaction.
+type Tx interface {
+	// Commit commits the transaction.
+	Commit(ctx context.Context) error
+	// Rollback aborts the transaction.
+	Rollback(ctx context.Context) error
+}
+
+// Beginner begins database transactions.
+type Beginner interface {
+	// Begin starts a transaction.
+	Begin(ctx context.Context) (Tx, error)
+}
+
+// BeginnerFunc adapts a function to the Beginner interface. With pgx, it wraps a pool:
+//
+//	emittertx.BeginnerFunc(func(ctx context.Context) (emittertx.Tx, error) {
+//		return pool.Begin(ctx)
+//	})
+type BeginnerFunc func(ctx context.Context) (Tx, error)
+
+// Begin calls f.
+func (f BeginnerFunc) Begin(ctx context.Context) (Tx, error) {
+	return f(ctx)
+}
+
+// txKey is the context key of the transaction of a listener call.
+type txKey struct{}
+
+// FromContext returns the transaction a Listener runs in.
+func FromContext(ctx context.Context) (Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(Tx)
+	return tx, ok
+}
+
+// Listener returns an emitter.Listener calling listener inside a transaction begun with
+// db, which listener retrieves from its context with FromContext, or SQLTx for
+// transactions begun with DB. The transaction is committed if listener returns nil, and
+// rolled back if it returns an error or panics, in which case the panic goes on.
+//
+// The context is the event's, so canceling the emission cancels the transaction. If it
+// already carries a transaction, such as for an event emitted with
+// EmitSyncWithContext from inside another transactional listener, listener joins that
+// transaction instead of beginning its own, and the outer listener commits it.
+func Listener(db Beginner, listener func(ctx context.Context, evt emitter.Event) error) emitter.Listener {
+	return func(evt emitter.Event) (err error) {
+		ctx := eventContext(evt)
+		if _, ok := FromContext(ctx); ok {
+			return listener(ctx, evt)
+		}
+
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		committed := false
+		defer func() {
+			if !committed {
+				// Roll back even if the emission was canceled meanwhile.
+				if rbErr := tx.Rollback(context.WithoutCancel(ctx)); rbErr != nil && err != nil {
+					err = errors.Join(err, rbErr)
+				}
+			}
+		}()
+
+		if err := listener(context.WithValue(ctx, txKey{}, tx), evt); err != nil {
+			return err
+		}
+		committed = true
+		return tx.Commit(ctx)
+	}
+}
+
+// eventContext returns the context of evt, or context.Background() if it has none.
+func eventContext(evt emitter.Event) context.Context {
+	if c, ok := evt.(interface{ Context() context.Context }); ok {
+		if ctx := c.Context(); ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// DB adapts a database/sql database, or a connection of one, to the Beginner
+// interface. Transactions are begun with opts, which may be nil.
+func DB(db interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}, opts *sql.TxOptions) Beginner {
+	return BeginnerFunc(func(ctx context.Context) (Tx, error) {
+		tx, err := db.BeginTx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return sqlTx{tx}, nil
+	})
+}
+
+// SQLTx returns the database/sql transaction a Listener runs in, if it was begun with DB.
+func SQLTx(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(sqlTx)
+	return tx.Tx, ok
+}
+
+// sqlTx adapts a database/sql transaction to the Tx interface.
+type sqlTx struct {
+	*sql.Tx
+}
+
+// Commit commits the transaction.
+func (tx sqlTx) Commit(context.Context) error {
+	return tx.Tx.Commit()
+}
+
+// Rollback aborts the transaction.
+func (tx sqlTx) Rollback(context.Context) error {
+	return tx.Tx.Rollback()
+}
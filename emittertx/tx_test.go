@@ -0,0 +1,93 @@
+package emittertx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kaptinlin/emitter"
+)
+
+// fakeTx records how a transaction ended.
+type fakeTx struct {
+	committed, rolledBack bool
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+// fakeDB begins fakeTx transactions and keeps them for inspection.
+type fakeDB struct {
+	txs []*fakeTx
+}
+
+func (db *fakeDB) Begin(context.Context) (Tx, error) {
+	tx := &fakeTx{}
+	db.txs = append(db.txs, tx)
+	return tx, nil
+}
+
+func TestListener(t *testing.T) {
+	db := &fakeDB{}
+	e := emitter.NewMemoryEmitter()
+	defer e.Close()
+
+	errInvalid := errors.New("invalid order")
+	e.On("order.*", Listener(db, func(ctx context.Context, evt emitter.Event) error {
+		tx, ok := FromContext(ctx)
+		if !ok || tx != db.txs[len(db.txs)-1] {
+			t.Errorf("FromContext() = %v, %v, want the listener's transaction", tx, ok)
+		}
+		switch evt.Topic() {
+		case "order.invalid":
+			return errInvalid
+		case "order.crashed":
+			panic("listener crashed")
+		case "order.created":
+			// Listeners of events emitted with the transaction's context join it.
+			e.EmitSyncWithContext(ctx, "order.audited")
+		}
+		return nil
+	}))
+
+	if errs := e.EmitSync("order.created"); len(errs) != 0 {
+		t.Fatalf("EmitSync() = %v, want no error", errs)
+	}
+	if len(db.txs) != 1 || !db.txs[0].committed || db.txs[0].rolledBack {
+		t.Errorf("transactions = %+v, want one committed for the event and the nested one", db.txs)
+	}
+
+	if errs := e.EmitSync("order.invalid"); len(errs) != 1 || !errors.Is(errs[0], errInvalid) {
+		t.Errorf("EmitSync() = %v, want %v", errs, errInvalid)
+	}
+	if tx := db.txs[1]; tx.committed || !tx.rolledBack {
+		t.Errorf("transaction of a failing listener = %+v, want it rolled back", tx)
+	}
+
+	e.EmitSync("order.crashed")
+	if tx := db.txs[2]; tx.committed || !tx.rolledBack {
+		t.Errorf("transaction of a panicking listener = %+v, want it rolled back", tx)
+	}
+}
+
+func TestListenerBeginError(t *testing.T) {
+	errDown := errors.New("database down")
+	called := false
+	listener := Listener(BeginnerFunc(func(context.Context) (Tx, error) {
+		return nil, errDown
+	}), func(context.Context, emitter.Event) error {
+		called = true
+		return nil
+	})
+
+	if err := listener(emitter.NewBaseEvent("order.created", nil)); !errors.Is(err, errDown) || called {
+		t.Errorf("listener() = %v with called = %v, want %v without calling it", err, called, errDown)
+	}
+}
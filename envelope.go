@@ -0,0 +1,207 @@
+package emitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Codec encodes and decodes event payloads for transport and storage.
+type Codec interface {
+	// ContentType returns the media type of the encoded payloads, such as "application/json".
+	ContentType() string
+	// Marshal encodes a payload.
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal decodes a payload into v.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONContentType is the content type of payloads encoded with JSONCodec.
+const JSONContentType = "application/json"
+
+// JSONCodec is the Codec encoding payloads as JSON. It is the codec behind the JSON form
+// of BaseEvent.
+type JSONCodec struct{}
+
+// ContentType returns JSONContentType.
+func (JSONCodec) ContentType() string {
+	return JSONContentType
+}
+
+// Marshal encodes v with encoding/json.
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes data with encoding/json.
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Envelope is the canonical, transport-independent form of an event. Its JSON form is
+// stable: the fields are always written in the order id, topic, timestamp, expiresAt,
+// metadata, contentType, payload, and expiresAt is left out for events that do not
+// expire. JSON payloads are embedded as is, others as base64 strings.
+type Envelope struct {
+	ID          string
+	Topic       string
+	Timestamp   time.Time
+	ExpiresAt   time.Time // Time after which the event is no longer delivered, or zero.
+	Metadata    map[string]interface{}
+	ContentType string
+	Payload     []byte // Payload encoded with the codec named by ContentType.
+}
+
+// envelopeJSON is the JSON layout of an Envelope.
+type envelopeJSON struct {
+	ID          string                 `json:"id,omitempty"`
+	Topic       string                 `json:"topic"`
+	Timestamp   time.Time              `json:"timestamp"`
+	ExpiresAt   *time.Time             `json:"expiresAt,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	ContentType string                 `json:"contentType"`
+	Payload     json.RawMessage        `json:"payload"`
+}
+
+// NewEnvelope captures the envelope of evt, encoding its payload with codec. The ID,
+// timestamp, expiry and metadata are taken from the event when it provides them.
+func NewEnvelope(evt Event, codec Codec) (*Envelope, error) {
+	payload, err := codec.Marshal(evt.Payload())
+	if err != nil {
+		return nil, fmt.Errorf("encoding payload of topic %s: %w", evt.Topic(), err)
+	}
+
+	env := &Envelope{
+		Topic:       evt.Topic(),
+		ContentType: codec.ContentType(),
+		Payload:     payload,
+	}
+	if v, ok := evt.(interface{ ID() string }); ok {
+		env.ID = v.ID()
+	}
+	if v, ok := evt.(interface{ Timestamp() time.Time }); ok {
+		env.Timestamp = v.Timestamp()
+	}
+	if v, ok := evt.(interface{ ExpiresAt() time.Time }); ok {
+		env.ExpiresAt = v.ExpiresAt()
+	}
+	if v, ok := evt.(MetadataCarrier); ok {
+		env.Metadata = v.Metadata()
+	}
+	return env, nil
+}
+
+// Decode decodes the payload into v with codec.
+func (e *Envelope) Decode(codec Codec, v interface{}) error {
+	if e.ContentType != codec.ContentType() {
+		return fmt.Errorf("%w: payload is %s, codec expects %s", ErrPayloadTypeMismatch, e.ContentType, codec.ContentType())
+	}
+	return codec.Unmarshal(e.Payload, v)
+}
+
+// Event returns a BaseEvent with the envelope's ID, topic, timestamp, expiry and
+// metadata. Its payload is the encoded payload, as a json.RawMessage for JSON payloads
+// and as a []byte otherwise, so listeners can decode it into the type they expect. An
+// emitter receiving the event from another process stops delivering it once it
+// expired, as the emitter it was first emitted on would, provided their clocks agree.
+func (e *Envelope) Event() *BaseEvent {
+	event := &BaseEvent{
+		id:        e.ID,
+		topic:     e.Topic,
+		timestamp: e.Timestamp,
+		expiresAt: e.ExpiresAt,
+		metadata:  e.Metadata,
+	}
+	if e.ContentType == JSONContentType {
+		event.payload = json.RawMessage(e.Payload)
+	} else {
+		event.payload = e.Payload
+	}
+	return event
+}
+
+// MarshalJSON encodes the envelope in its canonical JSON form.
+func (e *Envelope) MarshalJSON() ([]byte, error) {
+	payload := json.RawMessage(e.Payload)
+	if e.ContentType != JSONContentType {
+		encoded, err := json.Marshal(e.Payload) // Base64 string.
+		if err != nil {
+			return nil, err
+		}
+		payload = encoded
+	}
+	var expiresAt *time.Time
+	if !e.ExpiresAt.IsZero() {
+		expiresAt = &e.ExpiresAt
+	}
+	return json.Marshal(envelopeJSON{
+		ID:          e.ID,
+		Topic:       e.Topic,
+		Timestamp:   e.Timestamp,
+		ExpiresAt:   expiresAt,
+		Metadata:    e.Metadata,
+		ContentType: e.ContentType,
+		Payload:     payload,
+	})
+}
+
+// UnmarshalJSON decodes an envelope from its canonical JSON form.
+func (e *Envelope) UnmarshalJSON(data []byte) error {
+	var raw envelopeJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.ContentType == "" {
+		raw.ContentType = JSONContentType
+	}
+
+	payload := []byte(raw.Payload)
+	if raw.ContentType != JSONContentType {
+		if err := json.Unmarshal(raw.Payload, &payload); err != nil {
+			return fmt.Errorf("decoding %s payload: %w", raw.ContentType, err)
+		}
+	}
+
+	*e = Envelope{
+		ID:          raw.ID,
+		Topic:       raw.Topic,
+		Timestamp:   raw.Timestamp,
+		Metadata:    raw.Metadata,
+		ContentType: raw.ContentType,
+		Payload:     payload,
+	}
+	if raw.ExpiresAt != nil {
+		e.ExpiresAt = *raw.ExpiresAt
+	}
+	return nil
+}
+
+// MarshalJSON encodes the event as a JSON Envelope with its payload encoded by JSONCodec.
+func (e *BaseEvent) MarshalJSON() ([]byte, error) {
+	env, err := NewEnvelope(e, JSONCodec{})
+	if err != nil {
+		return nil, err
+	}
+	return env.MarshalJSON()
+}
+
+// UnmarshalJSON decodes an event from its JSON Envelope. The payload is kept encoded, as
+// described for Envelope.Event.
+func (e *BaseEvent) UnmarshalJSON(data []byte) error {
+	var env Envelope
+	if err := env.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	decoded := env.Event()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.id = decoded.id
+	e.topic = decoded.topic
+	e.timestamp = decoded.timestamp
+	e.expiresAt = decoded.expiresAt
+	e.metadata = decoded.metadata
+	e.payload = decoded.payload
+	e.args = nil
+	return nil
+}
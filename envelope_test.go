@@ -0,0 +1,144 @@
+package emitter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type envelopeOrder struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+// reverseCodec is a non-JSON codec storing strings reversed.
+type reverseCodec struct{}
+
+func (reverseCodec) ContentType() string { return "text/reversed" }
+
+func (reverseCodec) Marshal(v interface{}) ([]byte, error) {
+	b := []byte(v.(string))
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b, nil
+}
+
+func (c reverseCodec) Unmarshal(data []byte, v interface{}) error {
+	b, _ := c.Marshal(string(data))
+	*v.(*string) = string(b)
+	return nil
+}
+
+func TestBaseEventJSON(t *testing.T) {
+	event := NewEvent("order.created").
+		WithPayload(envelopeOrder{ID: "o-1", Total: 42}).
+		WithMetadata("tenant", "acme")
+	event.id = "evt-1"
+	event.timestamp = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed with error: %v", err)
+	}
+	want := `{"id":"evt-1","topic":"order.created","timestamp":"2024-01-02T03:04:05Z","metadata":{"tenant":"acme"},"contentType":"application/json","payload":{"id":"o-1","total":42}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded BaseEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed with error: %v", err)
+	}
+	if decoded.ID() != "evt-1" || decoded.Topic() != "order.created" || !decoded.Timestamp().Equal(event.Timestamp()) {
+		t.Errorf("Decoded envelope = %s %s %v, want the original", decoded.ID(), decoded.Topic(), decoded.Timestamp())
+	}
+	if tenant, _ := decoded.MetadataValue("tenant"); tenant != "acme" {
+		t.Errorf("Decoded metadata tenant = %v, want acme", tenant)
+	}
+
+	var order envelopeOrder
+	if err := json.Unmarshal(decoded.Payload().(json.RawMessage), &order); err != nil {
+		t.Fatalf("Decoding payload failed with error: %v", err)
+	}
+	if order != (envelopeOrder{ID: "o-1", Total: 42}) {
+		t.Errorf("Decoded payload = %+v", order)
+	}
+}
+
+func TestEnvelopeCustomCodec(t *testing.T) {
+	env, err := NewEnvelope(NewBaseEvent("greeting", "hello"), reverseCodec{})
+	if err != nil {
+		t.Fatalf("NewEnvelope() failed with error: %v", err)
+	}
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("json.Marshal() failed with error: %v", err)
+	}
+
+	var decoded Envelope
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed with error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Payload, []byte("olleh")) {
+		t.Errorf("Decoded payload = %q, want olleh", decoded.Payload)
+	}
+
+	var greeting string
+	if err := decoded.Decode(reverseCodec{}, &greeting); err != nil || greeting != "hello" {
+		t.Errorf("Decode() = %q, %v, want hello", greeting, err)
+	}
+	if err := decoded.Decode(JSONCodec{}, &greeting); !errors.Is(err, ErrPayloadTypeMismatch) {
+		t.Errorf("Decode() with the wrong codec = %v, want %v", err, ErrPayloadTypeMismatch)
+	}
+}
+
+func TestEnvelopeExpiry(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	sender := NewMemoryEmitter(WithClock(&manualClock{now: start}))
+	var sent []byte
+	sender.On("quote.price", func(evt Event) error {
+		var err error
+		sent, err = json.Marshal(evt)
+		return err
+	})
+	sender.EmitEventSync(NewEvent("quote.price").WithPayload(42).WithTTL(time.Second))
+
+	want := `"expiresAt":"2024-01-02T03:04:06Z"`
+	if !strings.Contains(string(sent), want) {
+		t.Fatalf("json.Marshal() = %s, want it to contain %s", sent, want)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		now     time.Time
+		expired bool
+	}{
+		{name: "in time", now: start.Add(500 * time.Millisecond)},
+		{name: "expired", now: start.Add(2 * time.Second), expired: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var received BaseEvent
+			if err := json.Unmarshal(sent, &received); err != nil {
+				t.Fatalf("json.Unmarshal() failed with error: %v", err)
+			}
+			receiver := NewMemoryEmitter(WithClock(&manualClock{now: tc.now}))
+			called := false
+			receiver.On("quote.price", func(Event) error {
+				called = true
+				return nil
+			})
+			errs := receiver.EmitEventSync(&received)
+			if called == tc.expired {
+				t.Errorf("listener called = %v, want %v", called, !tc.expired)
+			}
+			if expired := len(errs) == 1 && errors.Is(errs[0], ErrEventExpired); expired != tc.expired {
+				t.Errorf("EmitEventSync() = %v, want expired %v", errs, tc.expired)
+			}
+		})
+	}
+}
@@ -1,12 +1,25 @@
 package emitter
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Initialization Errors relate to the setup of listeners and topics.
 var (
-	ErrNilListener      = errors.New("listener cannot be nil")
-	ErrInvalidTopicName = errors.New("invalid topic name")
-	ErrInvalidPriority  = errors.New("invalid priority")
+	ErrNilListener           = errors.New("listener cannot be nil")
+	ErrInvalidTopicName      = errors.New("invalid topic name")
+	ErrInvalidPriority       = errors.New("invalid priority")
+	ErrInvalidHandler        = errors.New("invalid handler")
+	ErrHandlerNotFound       = errors.New("handler not registered")
+	ErrInvalidInterval       = errors.New("interval must be positive")
+	ErrInvalidRoutes         = errors.New("invalid routing table")
+	ErrWildcardLimitExceeded = errors.New("wildcard pattern limit exceeded")
+	ErrIncompatibleSchema    = errors.New("incompatible payload schema")
+	ErrNoScheduleStore       = errors.New("no schedule store configured")
+	ErrInvalidNodeID         = errors.New("invalid snowflake node ID")
+	ErrAttachableRegistered  = errors.New("attachable is already registered")
 )
 
 // Runtime Errors occur during the event emission and listener execution.
@@ -14,6 +27,22 @@ var (
 	ErrTopicNotFound          = errors.New("topic not found")
 	ErrListenerNotFound       = errors.New("listener not found")
 	ErrEventProcessingAborted = errors.New("event processing aborted")
+	ErrPayloadTypeMismatch    = errors.New("payload type mismatch")
+	ErrNilPayload             = errors.New("payload is nil")
+	ErrEmissionCanceled       = errors.New("emission canceled")
+	ErrListenerBudgetExceeded = errors.New("listener budget exceeded")
+	ErrReadOnlyEvent          = errors.New("event is read-only")
+	ErrAbortNotPermitted      = errors.New("listener is not permitted to abort")
+	ErrSubscriptionNotFound   = errors.New("subscription not found")
+	ErrEventExpired           = errors.New("event expired")
+	ErrPoolSaturated          = errors.New("pool is saturated")
+	ErrListenerDeadlock       = errors.New("call from a listener would deadlock")
+	ErrNoCurrentListener      = errors.New("event is not being handled by a listener")
+	ErrTopicPaused            = errors.New("topic is paused")
+	ErrUnmappedTopic          = errors.New("topic has no mapping")
+	ErrPublishQueueFull       = errors.New("publish queue is full")
+	ErrClockBeforeEpoch       = errors.New("clock is before the snowflake epoch")
+	ErrULIDOverflow           = errors.New("ULID entropy overflow")
 )
 
 // Manager Errors are related to the emitter.
@@ -21,3 +50,147 @@ var (
 	ErrEmitterClosed        = errors.New("emitter is closed")
 	ErrEmitterAlreadyClosed = errors.New("emitter is already closed")
 )
+
+// Operations reported by SubscriptionError and EmitError.
+const (
+	OpOn      = "on"      // Subscribing a listener.
+	OpOff     = "off"     // Unsubscribing a listener.
+	OpEmit    = "emit"    // Starting an emission.
+	OpNotify  = "notify"  // Notifying the listeners of a topic.
+	OpPublish = "publish" // Publishing an event to a remote backend.
+)
+
+// SubscriptionError reports a failure to subscribe or unsubscribe a listener. It wraps
+// one of the sentinel errors, such as ErrInvalidTopicName or ErrListenerNotFound.
+type SubscriptionError struct {
+	Op         string // OpOn or OpOff.
+	Topic      string // Topic name or pattern.
+	ListenerID string // ID of the listener, if known.
+	Err        error
+}
+
+// Error describes the failed operation and its cause.
+func (e *SubscriptionError) Error() string {
+	if e.ListenerID != "" {
+		return fmt.Sprintf("%s %q listener %s: %v", e.Op, e.Topic, e.ListenerID, e.Err)
+	}
+	return fmt.Sprintf("%s %q: %v", e.Op, e.Topic, e.Err)
+}
+
+// Unwrap returns the cause of the failure.
+func (e *SubscriptionError) Unwrap() error {
+	return e.Err
+}
+
+// EmitError reports an emission that could not be started, with Op OpEmit, an error
+// raised while notifying listeners, with Op OpNotify, or a failure to publish the event
+// to a remote backend, with Op OpPublish. Listener errors are wrapped as is,
+// and the emitter's own failures wrap sentinels such as ErrEmitterClosed or
+// ErrEmissionCanceled, so callers can still match them with errors.Is.
+type EmitError struct {
+	Op         string // OpEmit, OpNotify or OpPublish.
+	Topic      string // Topic the event was emitted on.
+	Pattern    string // Subscription pattern of the topic being notified, if any.
+	ListenerID string // ID of the failing listener, if any.
+	EventID    string // ID of the event, if it has one.
+	Err        error
+}
+
+// Error describes the failed operation and its cause, along with the event and listener
+// IDs when they are known.
+func (e *EmitError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q", e.Op, e.Topic)
+	if e.EventID != "" {
+		fmt.Fprintf(&b, " event %s", e.EventID)
+	}
+	if e.ListenerID != "" {
+		fmt.Fprintf(&b, " listener %s", e.ListenerID)
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	return b.String()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *EmitError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigError reports an invalid configuration passed to Op, such as a non-positive
+// interval or an invalid routing table.
+type ConfigError struct {
+	Op  string // Name of the method or option given the configuration.
+	Err error
+}
+
+// Error describes the invalid configuration.
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaError reports a payload schema declaration that breaks the compatibility mode of
+// a SchemaRegistry. It wraps ErrIncompatibleSchema.
+type SchemaError struct {
+	Topic    string        // Topic whose payload schema changed.
+	Mode     Compatibility // Compatibility mode that was violated.
+	Problems []string      // Changes breaking the mode.
+}
+
+// Error lists the changes that break compatibility.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("topic %s: %v under %s compatibility: %s", e.Topic, ErrIncompatibleSchema, e.Mode, strings.Join(e.Problems, "; "))
+}
+
+// Unwrap returns ErrIncompatibleSchema.
+func (e *SchemaError) Unwrap() error {
+	return ErrIncompatibleSchema
+}
+
+// DispatchError describes a listener error that was left unhandled by the error handler.
+// Emitters configured with WithErrorTopic publish it as the payload of an event on the
+// error topic.
+type DispatchError struct {
+	Topic      string // Topic the event was emitted on.
+	Pattern    string // Subscription pattern of the failing listener's topic.
+	ListenerID string // ID of the failing listener, if known.
+	Event      Event  // Event that was being dispatched.
+	Err        error  // Error reported by the listener.
+}
+
+// Error returns the listener error prefixed with the topic the event was emitted on and
+// the event's ID, if it has one.
+func (e *DispatchError) Error() string {
+	if id := eventID(e.Event); id != "" {
+		return fmt.Sprintf("topic %s event %s: %v", e.Topic, id, e.Err)
+	}
+	return fmt.Sprintf("topic %s: %v", e.Topic, e.Err)
+}
+
+// Unwrap returns the listener error.
+func (e *DispatchError) Unwrap() error {
+	return e.Err
+}
+
+// ListenerPanic describes a panic recovered while dispatching an event. Emitters configured
+// with WithPanicTopic publish it as the payload of an event on the panic topic.
+type ListenerPanic struct {
+	Topic      string      // Topic the event was emitted on.
+	ListenerID string      // ID of the listener that panicked, if known.
+	Event      Event       // Event that was being dispatched.
+	Value      interface{} // Value passed to panic.
+	Stack      []byte      // Stack trace of the panicking goroutine.
+}
+
+// Error returns the panic value prefixed with the topic, event and listener it occurred
+// in.
+func (p *ListenerPanic) Error() string {
+	if id := eventID(p.Event); id != "" {
+		return fmt.Sprintf("topic %s event %s: listener %s panicked: %v", p.Topic, id, p.ListenerID, p.Value)
+	}
+	return fmt.Sprintf("topic %s: listener %s panicked: %v", p.Topic, p.ListenerID, p.Value)
+}
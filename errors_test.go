@@ -0,0 +1,100 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmitErrorWrapsListenerError(t *testing.T) {
+	errRejected := errors.New("rejected")
+	e := NewMemoryEmitter()
+	id, _ := e.On("order.*", func(evt Event) error { return errRejected })
+
+	errs := e.EmitSync("order.created", nil)
+	if len(errs) != 1 {
+		t.Fatalf("EmitSync returned %d errors, want 1", len(errs))
+	}
+	var emitErr *EmitError
+	if !errors.As(errs[0], &emitErr) {
+		t.Fatalf("error %v is not an *EmitError", errs[0])
+	}
+	if emitErr.Op != OpNotify || emitErr.Topic != "order.created" || emitErr.Pattern != "order.*" || emitErr.ListenerID != id {
+		t.Errorf("EmitError = %+v, want notify on order.created through order.* by listener %s", emitErr, id)
+	}
+	if !errors.Is(errs[0], errRejected) {
+		t.Errorf("errors.Is(%v, errRejected) = false", errs[0])
+	}
+}
+
+func TestEmitErrorOnClosedEmitter(t *testing.T) {
+	e := NewMemoryEmitter()
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := e.EmitSync("order.created", nil)
+	var emitErr *EmitError
+	if len(errs) != 1 || !errors.As(errs[0], &emitErr) || emitErr.Op != OpEmit || emitErr.Topic != "order.created" {
+		t.Fatalf("EmitSync errors = %v, want one emit error for order.created", errs)
+	}
+	if !errors.Is(errs[0], ErrEmitterClosed) {
+		t.Errorf("errors.Is(%v, ErrEmitterClosed) = false", errs[0])
+	}
+	if err := <-e.Emit("order.created"); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("Emit error = %v, want ErrEmitterClosed", err)
+	}
+}
+
+func TestSubscriptionError(t *testing.T) {
+	e := NewMemoryEmitter()
+
+	_, err := e.On("order.created", nil)
+	var subErr *SubscriptionError
+	if !errors.As(err, &subErr) || subErr.Op != OpOn || subErr.Topic != "order.created" || !errors.Is(err, ErrNilListener) {
+		t.Errorf("On(nil) error = %v, want an on error wrapping ErrNilListener", err)
+	}
+
+	err = e.Off("order.created", "missing")
+	if !errors.As(err, &subErr) || subErr.Op != OpOff || subErr.ListenerID != "missing" || !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("Off error = %v, want an off error wrapping ErrTopicNotFound", err)
+	}
+
+	e.On("order.created", func(evt Event) error { return nil })
+	err = e.Off("order.created", "missing")
+	if !errors.As(err, &subErr) || !errors.Is(err, ErrListenerNotFound) {
+		t.Errorf("Off error = %v, want an off error wrapping ErrListenerNotFound", err)
+	}
+}
+
+func TestConfigError(t *testing.T) {
+	e := NewMemoryEmitter()
+	_, err := e.ExpectActivity("order.*", 0, nil)
+	var cfgErr *ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Op != "ExpectActivity" || !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("ExpectActivity error = %v, want a config error wrapping ErrInvalidInterval", err)
+	}
+
+	err = e.ImportRoutes(RoutingTable{Version: RoutingTableVersion + 1}, nil)
+	if !errors.As(err, &cfgErr) || cfgErr.Op != "ImportRoutes" || !errors.Is(err, ErrInvalidRoutes) {
+		t.Errorf("ImportRoutes error = %v, want a config error wrapping ErrInvalidRoutes", err)
+	}
+}
+
+func TestErrorsIncludeEventID(t *testing.T) {
+	errRejected := errors.New("rejected")
+	var dispatched *DispatchError
+	e := NewMemoryEmitter(WithEventIDGenerator(func() string { return "42" }), WithErrorTopic("errors"))
+	e.On("errors", func(evt Event) error {
+		dispatched, _ = evt.Payload().(*DispatchError)
+		return nil
+	})
+	id, _ := e.On("order.created", func(evt Event) error { return errRejected })
+
+	errs := e.EmitSync("order.created", nil)
+	if want := `notify "order.created" event 42 listener ` + id + ": rejected"; len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("EmitSync errors = %v, want %q", errs, want)
+	}
+	if want := "topic order.created event 42: rejected"; dispatched == nil || dispatched.Error() != want {
+		t.Errorf("DispatchError = %v, want %q", dispatched, want)
+	}
+}
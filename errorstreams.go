@@ -0,0 +1,88 @@
+package emitter
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrorStreams splits the error channel of an asynchronous emission into per-topic
+// streams, so a consumer interested only in failures under "billing.**" does not have to
+// sift through every other error. Create one with SplitErrors.
+type ErrorStreams struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	errs []error
+	done bool
+}
+
+// SplitErrors drains errChan, typically the channel returned by Emit, EmitWithContext
+// or EmitEvent, and returns a handle whose Errors method serves the errors by topic.
+// Errors are kept until the handle is discarded, so streams requested after some
+// errors were reported still receive them.
+func SplitErrors(errChan <-chan error) *ErrorStreams {
+	s := &ErrorStreams{}
+	s.cond = sync.NewCond(&s.mu)
+	go func() {
+		for err := range errChan {
+			s.mu.Lock()
+			s.errs = append(s.errs, err)
+			s.mu.Unlock()
+			s.cond.Broadcast()
+		}
+		s.mu.Lock()
+		s.done = true
+		s.mu.Unlock()
+		s.cond.Broadcast()
+	}()
+	return s
+}
+
+// Errors returns a channel of the errors raised by listeners whose subscription
+// pattern matches topicPattern, in the order they were reported. Errors that do not
+// come from a listener, such as a refused emission, are matched by the emitted topic
+// instead. The channel is closed once the emission completes and must be drained.
+func (s *ErrorStreams) Errors(topicPattern string) <-chan error {
+	out := make(chan error)
+	go func() {
+		defer close(out)
+		for i := 0; ; i++ {
+			s.mu.Lock()
+			for i >= len(s.errs) && !s.done {
+				s.cond.Wait()
+			}
+			if i >= len(s.errs) {
+				s.mu.Unlock()
+				return
+			}
+			err := s.errs[i]
+			s.mu.Unlock()
+
+			if errorMatches(topicPattern, err) {
+				out <- err
+			}
+		}
+	}()
+	return out
+}
+
+// Wait blocks until the emission completes and returns all of its errors.
+func (s *ErrorStreams) Wait() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !s.done {
+		s.cond.Wait()
+	}
+	return append([]error(nil), s.errs...)
+}
+
+// errorMatches reports whether err belongs to the stream of topicPattern.
+func errorMatches(topicPattern string, err error) bool {
+	var emitErr *EmitError
+	if !errors.As(err, &emitErr) {
+		return false
+	}
+	if emitErr.Pattern != "" {
+		return matchTopicPattern(topicPattern, emitErr.Pattern)
+	}
+	return matchTopicPattern(topicPattern, emitErr.Topic)
+}
@@ -0,0 +1,57 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSplitErrors(t *testing.T) {
+	e := NewMemoryEmitter()
+	errBilling := errors.New("card declined")
+	errAudit := errors.New("audit log full")
+	e.On("billing.**", func(evt Event) error { return errBilling })
+	e.On("*.invoice.*", func(evt Event) error { return nil })
+	e.On("**", func(evt Event) error { return errAudit })
+
+	streams := SplitErrors(e.Emit("billing.invoice.paid"))
+
+	var billing []error
+	for err := range streams.Errors("billing.**") {
+		billing = append(billing, err)
+	}
+	if len(billing) != 1 || !errors.Is(billing[0], errBilling) {
+		t.Errorf("billing errors = %v, want only the card declined error", billing)
+	}
+
+	// A stream requested after the emission completed still receives its errors.
+	var all []error
+	for err := range streams.Errors("**") {
+		all = append(all, err)
+	}
+	if len(all) != 2 {
+		t.Errorf("all errors = %v, want 2", all)
+	}
+	if errs := streams.Wait(); len(errs) != 2 {
+		t.Errorf("Wait() = %v, want 2 errors", errs)
+	}
+}
+
+func TestSplitErrorsRefusedEmission(t *testing.T) {
+	e := NewMemoryEmitter()
+	e.Close()
+
+	streams := SplitErrors(e.Emit("billing.invoice.paid"))
+	var billing, shipping []error
+	for err := range streams.Errors("billing.*.*") {
+		billing = append(billing, err)
+	}
+	for err := range streams.Errors("shipping.**") {
+		shipping = append(shipping, err)
+	}
+	if len(billing) != 1 || !errors.Is(billing[0], ErrEmitterClosed) {
+		t.Errorf("billing errors = %v, want ErrEmitterClosed", billing)
+	}
+	if len(shipping) != 0 {
+		t.Errorf("shipping errors = %v, want none", shipping)
+	}
+}
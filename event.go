@@ -1,6 +1,11 @@
 package emitter
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // Event is an interface representing the structure of an event.
 type Event interface {
@@ -11,12 +16,71 @@ type Event interface {
 	IsAborted() bool
 }
 
+// argsEvent is implemented by events emitted with several arguments, such as BaseEvent.
+type argsEvent interface {
+	Args() []interface{}
+}
+
+// eventArgs returns the arguments of evt, or its payload alone when evt does not carry
+// arguments.
+func eventArgs(evt Event) []interface{} {
+	if a, ok := evt.(argsEvent); ok {
+		return a.Args()
+	}
+	return []interface{}{evt.Payload()}
+}
+
+// MetadataCarrier is implemented by events that carry metadata, such as BaseEvent.
+// Custom Event implementations can provide it so that their metadata is kept when the
+// event is emitted with EmitEvent and is visible through LookupMetadata.
+type MetadataCarrier interface {
+	Metadata() map[string]interface{}
+}
+
+// LookupMetadata returns the metadata value stored under key on any event that carries
+// metadata, including read-only views handed to listeners.
+func LookupMetadata(evt Event, key string) (interface{}, bool) {
+	if v, ok := evt.(interface {
+		MetadataValue(key string) (interface{}, bool)
+	}); ok {
+		return v.MetadataValue(key)
+	}
+	if carrier, ok := evt.(MetadataCarrier); ok {
+		value, ok := carrier.Metadata()[key]
+		return value, ok
+	}
+	return nil, false
+}
+
 // BaseEvent provides a basic implementation of the Event interface.
 type BaseEvent struct {
-	topic   string
-	payload interface{}
-	aborted bool
-	mu      sync.RWMutex // Changed from sync.Mutex to sync.RWMutex
+	id           string
+	timestamp    time.Time
+	topic        string
+	payload      interface{}
+	args         []interface{} // All emitted arguments, or nil for a single payload.
+	metadata     map[string]interface{}
+	priority     Priority
+	ttl          time.Duration
+	expiresAt    time.Time // Set from ttl when the event is emitted.
+	custom       Event     // Custom event handed to listeners in place of this envelope.
+	aborted      bool
+	mu           sync.RWMutex     // Changed from sync.Mutex to sync.RWMutex
+	trail        []*MemoryEmitter // Emitters that have already dispatched this event.
+	ctx          context.Context  // Context of the emission that produced this event.
+	checkpoint   *emission        // Cooperative checkpoint state of the emission.
+	delivered    atomic.Int64     // Number of listeners notified with this event.
+	running      string           // ID of the listener currently being notified.
+	current      *listenerItem    // Listener currently being notified.
+	currentOn    *Topic           // Topic of the listener currently being notified.
+	recoverer    *MemoryEmitter   // Emitter recovering the panics of each listener, under PanicRecover.
+	panicked     bool             // Whether a panic of this emission was reported and is propagating.
+	forced       bool             // Whether the emission bypasses the suppression of unchanged payloads.
+	retain       bool             // Whether the event is to be kept as its topic's retained event.
+	completion   emitConfig       // Completion of the emission, run by ResumeTopic once a paused topic buffered the event.
+	held         bool             // Whether a paused topic buffered the event, postponing the completion of its emission.
+	retainSeq    uint64           // Position of the event among retained events, or zero.
+	redeliveries int              // Times a listener that failed the event is called again, under AtLeastOnce.
 }
 
 // NewBaseEvent creates a new instance of BaseEvent with a payload.
@@ -27,23 +91,201 @@ func NewBaseEvent(topic string, payload interface{}) *BaseEvent {
 	}
 }
 
+// NewEvent starts building an event for the given topic. Chain the With methods to fill
+// in the envelope and pass the result to EmitEvent or EmitEventSync:
+//
+//	evt := emitter.NewEvent("order.created").
+//		WithPayload(order).
+//		WithMetadata("tenant", "acme").
+//		WithTTL(time.Minute).
+//		WithPriority(emitter.High)
+//	e.EmitEvent(evt)
+//
+// The With methods are meant for building the event before it is emitted and must not
+// be called concurrently with listeners reading it.
+func NewEvent(topic string) *BaseEvent {
+	return &BaseEvent{topic: topic}
+}
+
+// WithPayload sets the payload of the event.
+func (e *BaseEvent) WithPayload(payload interface{}) *BaseEvent {
+	e.SetPayload(payload)
+	return e
+}
+
+// WithMetadata attaches a metadata value to the event under key.
+func (e *BaseEvent) WithMetadata(key string, value interface{}) *BaseEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.metadata == nil {
+		e.metadata = make(map[string]interface{})
+	}
+	e.metadata[key] = value
+	return e
+}
+
+// WithTTL limits how long the event stays deliverable, measured from the time it is
+// emitted. Listeners that would be notified after it expired are skipped and the
+// emission reports ErrEventExpired.
+func (e *BaseEvent) WithTTL(ttl time.Duration) *BaseEvent {
+	e.ttl = ttl
+	return e
+}
+
+// WithPriority sets the priority of the event.
+func (e *BaseEvent) WithPriority(priority Priority) *BaseEvent {
+	e.priority = priority
+	return e
+}
+
+// WithContext sets the context the event is emitted with. Canceling it stops the
+// remaining listeners, as with EmitWithContext.
+func (e *BaseEvent) WithContext(ctx context.Context) *BaseEvent {
+	e.ctx = ctx
+	return e
+}
+
+// newArgsEvent creates an event from the arguments of an emission. A single argument
+// becomes the payload; with several, the first is the payload and all are kept as args.
+func newArgsEvent(topic string, args []interface{}) *BaseEvent {
+	if len(args) == 1 {
+		return NewBaseEvent(topic, args[0])
+	}
+	event := &BaseEvent{topic: topic, args: append(make([]interface{}, 0, len(args)), args...)}
+	if len(args) > 0 {
+		event.payload = args[0]
+	}
+	return event
+}
+
+// ID returns the event's unique identifier, or an empty string if the emitter was not
+// configured with an event ID generator.
+func (e *BaseEvent) ID() string {
+	return e.id
+}
+
+// String formats the event as its topic followed by its ID, if it has one, such as
+// "order.created#42", so that events can be correlated in log lines.
+func (e *BaseEvent) String() string {
+	return formatEvent(e)
+}
+
+// formatEvent formats evt as its topic followed by its ID, if it has one.
+func formatEvent(evt Event) string {
+	if id := eventID(evt); id != "" {
+		return evt.Topic() + "#" + id
+	}
+	return evt.Topic()
+}
+
+// eventID returns the ID of evt, or an empty string if it has none.
+func eventID(evt Event) string {
+	if identified, ok := evt.(interface{ ID() string }); ok {
+		return identified.ID()
+	}
+	return ""
+}
+
+// Timestamp returns the time at which the event was emitted, according to the emitter's
+// clock. It is the zero time for events that were not dispatched by an emitter.
+func (e *BaseEvent) Timestamp() time.Time {
+	return e.timestamp
+}
+
 // Topic returns the event's topic.
 func (e *BaseEvent) Topic() string {
 	return e.topic
 }
 
-// Payload returns the event's payload.
+// Payload returns the event's payload. For events emitted with several arguments it is
+// the first argument.
 func (e *BaseEvent) Payload() interface{} {
 	e.mu.RLock() // Read lock
 	defer e.mu.RUnlock()
 	return e.payload
 }
 
-// SetPayload sets the event's payload.
+// Args returns a copy of the arguments the event was emitted with. An event with a
+// single payload has exactly one argument.
+func (e *BaseEvent) Args() []interface{} {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.args == nil {
+		return []interface{}{e.payload}
+	}
+	return append([]interface{}(nil), e.args...)
+}
+
+// Metadata returns a copy of the event's metadata, or nil if it has none.
+func (e *BaseEvent) Metadata() map[string]interface{} {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.metadata == nil {
+		return nil
+	}
+	metadata := make(map[string]interface{}, len(e.metadata))
+	for k, v := range e.metadata {
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// MetadataValue returns the metadata value stored under key.
+func (e *BaseEvent) MetadataValue(key string) (interface{}, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	value, ok := e.metadata[key]
+	return value, ok
+}
+
+// Priority returns the priority of the event, Normal unless set with WithPriority.
+func (e *BaseEvent) Priority() Priority {
+	if e.priority == 0 {
+		return Normal
+	}
+	return e.priority
+}
+
+// ExpiresAt returns the time after which the event is no longer delivered, or the zero
+// time if it does not expire or has not been emitted yet.
+func (e *BaseEvent) ExpiresAt() time.Time {
+	return e.expiresAt
+}
+
+// Context returns the context of the emission that produced the event. Listeners can use
+// it to stop long-running work when the emission is canceled or times out.
+func (e *BaseEvent) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// SetPayload sets the event's payload. For events emitted with several arguments it
+// replaces the first argument.
 func (e *BaseEvent) SetPayload(payload interface{}) {
 	e.mu.Lock() // Write lock
 	defer e.mu.Unlock()
 	e.payload = payload
+	if len(e.args) > 1 {
+		args := append([]interface{}(nil), e.args...) // Args handed out earlier stay unchanged.
+		args[0] = payload
+		e.args = args
+	} else {
+		e.args = nil
+	}
+}
+
+// TrySetPayload sets the event's payload. It never fails for a BaseEvent.
+func (e *BaseEvent) TrySetPayload(payload interface{}) error {
+	e.SetPayload(payload)
+	return nil
+}
+
+// TrySetAborted sets the event's aborted status. It never fails for a BaseEvent.
+func (e *BaseEvent) TrySetAborted(abort bool) error {
+	e.SetAborted(abort)
+	return nil
 }
 
 // SetAborted sets the event's aborted status.
@@ -59,3 +301,65 @@ func (e *BaseEvent) IsAborted() bool {
 	defer e.mu.RUnlock()
 	return e.aborted
 }
+
+// listenerEvent returns the event handed to listeners: the custom event this envelope
+// carries, if any, or the envelope itself.
+func (e *BaseEvent) listenerEvent() Event {
+	if e.custom != nil {
+		return e.custom
+	}
+	return e
+}
+
+// forwardedCopy returns a new event with the same arguments and envelope, used to deliver
+// the event to a related emitter without sharing its dispatch state.
+func (e *BaseEvent) forwardedCopy() *BaseEvent {
+	event := newArgsEvent(e.Topic(), e.Args())
+	event.id = e.id
+	event.timestamp = e.timestamp
+	event.metadata = e.Metadata()
+	event.priority = e.priority
+	event.ttl = e.ttl
+	event.expiresAt = e.expiresAt
+	event.custom = e.custom
+	event.trail = append([]*MemoryEmitter(nil), e.trail...)
+	return event
+}
+
+// boundCopy returns a copy of the event sharing its envelope, context and abort state,
+// and recording the listener currently being notified.
+func (e *BaseEvent) boundCopy() *BaseEvent {
+	event := e.forwardedCopy()
+	event.ctx = e.ctx
+	event.aborted = e.IsAborted()
+	event.running, event.current, event.currentOn = e.running, e.current, e.currentOn
+	return event
+}
+
+// visited reports whether the event has already been dispatched by the given emitter.
+func (e *BaseEvent) visited(m *MemoryEmitter) bool {
+	for _, seen := range e.trail {
+		if seen == m {
+			return true
+		}
+	}
+	return false
+}
+
+// deliveredCount returns how many listeners have been notified with the event.
+func (e *BaseEvent) deliveredCount() int {
+	return int(e.delivered.Load())
+}
+
+// Arg returns the i-th argument of the event converted to T. It reports false when the
+// event has fewer arguments or the argument is not a T. Events without an Args method
+// have their payload as only argument.
+func Arg[T any](evt Event, i int) (T, bool) {
+	var zero T
+	args := eventArgs(evt)
+	if i < 0 || i >= len(args) {
+		return zero, false
+	}
+	value, ok := args[i].(T)
+	return value, ok
+}
@@ -1,7 +1,9 @@
 package emitter
 
 import (
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestNewBaseEvent(t *testing.T) {
@@ -43,3 +45,106 @@ func TestBaseEventSetAbortedAndIsAborted(t *testing.T) {
 		t.Errorf("BaseEvent.Abort(false) did not unabort the event")
 	}
 }
+
+func TestBaseEventArgs(t *testing.T) {
+	single := NewBaseEvent("test_topic", "payload")
+	if args := single.Args(); len(args) != 1 || args[0] != "payload" {
+		t.Errorf("Args() = %v, want [payload]", args)
+	}
+
+	none := newArgsEvent("test_topic", nil)
+	if args := none.Args(); len(args) != 0 || none.Payload() != nil {
+		t.Errorf("Args() = %v, Payload() = %v, want no arguments", args, none.Payload())
+	}
+
+	multi := newArgsEvent("test_topic", []interface{}{"order-1", "alice", 3})
+	if multi.Payload() != "order-1" {
+		t.Errorf("Payload() = %v, want the first argument", multi.Payload())
+	}
+
+	args := multi.Args()
+	multi.SetPayload("order-2")
+	if args[0] != "order-1" {
+		t.Error("SetPayload() should not modify previously returned arguments")
+	}
+	if got := multi.Args(); len(got) != 3 || got[0] != "order-2" || got[1] != "alice" {
+		t.Errorf("Args() after SetPayload() = %v, want [order-2 alice 3]", got)
+	}
+}
+
+func TestArg(t *testing.T) {
+	event := newArgsEvent("test_topic", []interface{}{"alice", 3})
+
+	if actor, ok := Arg[string](event, 0); !ok || actor != "alice" {
+		t.Errorf("Arg[string](0) = (%q, %v), want (alice, true)", actor, ok)
+	}
+	if count, ok := Arg[int](event, 1); !ok || count != 3 {
+		t.Errorf("Arg[int](1) = (%d, %v), want (3, true)", count, ok)
+	}
+	if _, ok := Arg[int](event, 0); ok {
+		t.Error("Arg[int](0) should fail for a string argument")
+	}
+	if _, ok := Arg[string](event, 2); ok {
+		t.Error("Arg() should fail for an index out of range")
+	}
+
+	// Events without an Args method have their payload as only argument.
+	custom := &auditEvent{action: "login", actor: "bob"}
+	if actor, ok := Arg[string](custom, 0); !ok || actor != "bob" {
+		t.Errorf("Arg[string](0) of a custom event = (%q, %v), want (bob, true)", actor, ok)
+	}
+	if _, ok := Arg[string](newReadOnlyEvent(custom), 1); ok {
+		t.Error("Arg() should fail beyond the payload of a custom event")
+	}
+}
+
+func TestNewEventBuilder(t *testing.T) {
+	event := NewEvent("order.created").
+		WithPayload("order-1").
+		WithMetadata("tenant", "acme").
+		WithMetadata("trace", "abc").
+		WithTTL(time.Minute).
+		WithPriority(High)
+
+	if event.Topic() != "order.created" || event.Payload() != "order-1" {
+		t.Errorf("Event = (%s, %v), want (order.created, order-1)", event.Topic(), event.Payload())
+	}
+	if event.Priority() != High {
+		t.Errorf("Priority() = %v, want %v", event.Priority(), High)
+	}
+	if tenant, ok := event.MetadataValue("tenant"); !ok || tenant != "acme" {
+		t.Errorf("MetadataValue(tenant) = (%v, %v), want (acme, true)", tenant, ok)
+	}
+
+	metadata := event.Metadata()
+	metadata["tenant"] = "changed"
+	if tenant, _ := event.MetadataValue("tenant"); tenant != "acme" {
+		t.Error("Metadata() should return a copy")
+	}
+	if !event.ExpiresAt().IsZero() {
+		t.Error("ExpiresAt() should be zero before the event is emitted")
+	}
+
+	plain := NewEvent("ping")
+	if plain.Priority() != Normal || plain.Metadata() != nil {
+		t.Errorf("Defaults = (%v, %v), want (normal, nil)", plain.Priority(), plain.Metadata())
+	}
+}
+
+func TestBaseEventString(t *testing.T) {
+	evt := NewBaseEvent("order.created", nil)
+	if got := evt.String(); got != "order.created" {
+		t.Errorf("String() = %q, want %q", got, "order.created")
+	}
+
+	var seen string
+	e := NewMemoryEmitter(WithEventIDGenerator(func() string { return "42" }))
+	e.On("order.*", func(evt Event) error {
+		seen = fmt.Sprint(evt)
+		return nil
+	}, WithReadOnlyEvent())
+	e.EmitSync("order.created", nil)
+	if seen != "order.created#42" {
+		t.Errorf("listener formatted the event as %q, want %q", seen, "order.created#42")
+	}
+}
@@ -0,0 +1,84 @@
+package emitter
+
+import "context"
+
+// WithEventLoop processes every emission on a single goroutine of the emitter, in the
+// order the emissions were made, so that listeners never run concurrently and their
+// state needs no locks. Emit queues the emission on the loop, and EmitSync queues it and
+// waits for it to complete. The loop marks the context of the emissions it runs, so that
+// listeners emitting synchronously with the context of their event, as with
+// EmitSyncWithContext(evt.(*BaseEvent).Context(), ...), have the emission processed right
+// away; EmitSync with another context would wait for the listener itself. Emit from a
+// listener is still queued behind the pending emissions.
+//
+// The loop replaces the emitter's pool, and emitters rebuilt with NewMemoryEmitterFrom
+// get a loop of their own. Close waits for the queued emissions, so a listener closing
+// the emitter calls CloseWithContext with the context of its event instead: the loop then
+// stops once they are processed.
+func WithEventLoop() EmitterOption {
+	return func(m Emitter) {
+		m.SetPoolFactory(func() Pool { return newEventLoop() })
+	}
+}
+
+// eventLoop is the Pool of an emitter configured with WithEventLoop.
+type eventLoop struct {
+	exec *SerialExecutor
+}
+
+// loopKey is the context key under which an event loop marks the emissions it runs.
+type loopKey struct{}
+
+// newEventLoop starts an event loop.
+func newEventLoop() *eventLoop {
+	return &eventLoop{exec: NewSerialExecutor()}
+}
+
+// Submit queues task on the loop.
+func (l *eventLoop) Submit(task func()) {
+	l.exec.Execute(task)
+}
+
+// Running returns 1, for the loop's goroutine.
+func (l *eventLoop) Running() int {
+	return 1
+}
+
+// Release stops the loop once the queued tasks completed.
+func (l *eventLoop) Release() {
+	l.exec.Close()
+}
+
+// mark returns ctx marked as the context of an emission running on the loop.
+func (l *eventLoop) mark(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loopKey{}, l)
+}
+
+// onLoop reports whether ctx is the context of an emission running on the loop, or
+// derives from one.
+func (l *eventLoop) onLoop(ctx context.Context) bool {
+	loop, _ := ctx.Value(loopKey{}).(*eventLoop)
+	return loop == l
+}
+
+// emitSyncOnLoop runs a synchronous emission on the emitter's event loop, if it has one
+// and ctx is not the context of an emission on the loop, and reports whether it did.
+func (m *MemoryEmitter) emitSyncOnLoop(ctx context.Context, event *BaseEvent, cfg emitConfig) ([]error, bool) {
+	loop, ok := m.Pool.(*eventLoop)
+	if !ok || loop.onLoop(ctx) {
+		return nil, false
+	}
+
+	var errs []error
+	done := make(chan struct{})
+	if !loop.exec.queue(func() {
+		defer close(done)
+		errs = m.emitSync(loop.mark(m.markPoolTask(ctx)), event, cfg)
+	}) {
+		err := &EmitError{Op: OpEmit, Topic: event.Topic(), EventID: event.id, Err: ErrEmitterClosed}
+		cfg.complete(event.Topic(), 0, []error{err})
+		return []error{err}, true
+	}
+	<-done
+	return errs, true
+}
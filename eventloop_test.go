@@ -0,0 +1,93 @@
+package emitter
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithEventLoop(t *testing.T) {
+	e := NewMemoryEmitter(WithEventLoop())
+	defer e.Close()
+
+	// The listener's state is not synchronized: the race detector checks that the loop
+	// never runs it concurrently.
+	var seen []interface{}
+	var active, overlaps atomic.Int32
+	e.On("counter.*", func(evt Event) error {
+		if active.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		defer active.Add(-1)
+		seen = append(seen, evt.Payload())
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 25; j++ {
+				e.Emit("counter.add", j)
+			}
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 10; i++ {
+		e.Emit("counter.ordered", i)
+	}
+
+	// EmitSync waits behind the emissions queued before it.
+	if errs := e.EmitSync("counter.done", "done"); len(errs) != 0 {
+		t.Fatalf("EmitSync() = %v, want no error", errs)
+	}
+	if len(seen) != 111 || seen[110] != "done" {
+		t.Fatalf("listener saw %d events before EmitSync returned, want 111 ending with done", len(seen))
+	}
+	for i, payload := range seen[100:110] {
+		if payload != i {
+			t.Fatalf("ordered events = %v, want them in emission order", seen[100:110])
+		}
+	}
+	if n := overlaps.Load(); n != 0 {
+		t.Errorf("listener calls overlapped %d times, want none", n)
+	}
+}
+
+func TestWithEventLoopNestedEmitSync(t *testing.T) {
+	e := NewMemoryEmitter(WithEventLoop())
+	defer e.Close()
+
+	var seen []string
+	e.On("order.*", func(evt Event) error {
+		seen = append(seen, evt.Topic())
+		if evt.Topic() == "order.created" {
+			e.EmitSyncWithContext(evt.(*BaseEvent).Context(), "order.audited")
+		}
+		return nil
+	})
+
+	e.EmitSync("order.created")
+	if len(seen) != 2 || seen[1] != "order.audited" {
+		t.Errorf("listener saw %v, want the nested emission processed right away", seen)
+	}
+}
+
+func TestWithEventLoopCloseFromListener(t *testing.T) {
+	e := NewMemoryEmitter(WithEventLoop())
+	var closeErr error
+	e.On("shutdown", func(evt Event) error {
+		closeErr = e.CloseWithContext(evt.(*BaseEvent).Context())
+		return nil
+	})
+
+	e.EmitSync("shutdown")
+	if !errors.Is(closeErr, ErrListenerDeadlock) {
+		t.Errorf("CloseWithContext() from a listener error = %v, want %v", closeErr, ErrListenerDeadlock)
+	}
+	if errs := e.EmitSync("shutdown"); len(errs) != 1 || !errors.Is(errs[0], ErrEmitterClosed) {
+		t.Errorf("EmitSync() after Close = %v, want %v", errs, ErrEmitterClosed)
+	}
+}
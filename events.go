@@ -0,0 +1,80 @@
+//go:build go1.23
+
+package emitter
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Events returns an iterator over the events matching topicPattern, as read-only views,
+// for consuming a topic with a range loop:
+//
+//	for evt := range e.Events(ctx, "order.**") {
+//		process(evt)
+//	}
+//
+// Each iteration subscribes its own listener when it starts and removes it when the loop
+// exits, ctx is done or the emitter is closed. Events emitted while the loop body runs
+// are buffered without blocking the emitter, so a body that falls behind a busy topic
+// grows the buffer. The iteration yields nothing if the subscription fails, for example
+// because the emitter is closed.
+func (m *MemoryEmitter) Events(ctx context.Context, topicPattern string) iter.Seq[Event] {
+	return func(yield func(Event) bool) {
+		q := &eventQueue{ready: make(chan struct{}, 1)}
+		id, err := m.On(topicPattern, q.push, WithReadOnlyEvent())
+		if err != nil {
+			return
+		}
+		defer func() { _ = m.Off(topicPattern, id) }()
+
+		for {
+			for _, evt := range q.drain() {
+				if !yield(evt) {
+					return
+				}
+			}
+			select {
+			case <-q.ready:
+			case <-ctx.Done():
+				return
+			case <-m.background.done():
+				for _, evt := range q.drain() {
+					if !yield(evt) {
+						return
+					}
+				}
+				return
+			}
+		}
+	}
+}
+
+// eventQueue buffers the events received by the listener of an Events iteration.
+type eventQueue struct {
+	mu     sync.Mutex
+	events []Event
+	ready  chan struct{} // Signaled when events are pushed.
+}
+
+// push buffers the event and wakes the iteration up.
+func (q *eventQueue) push(evt Event) error {
+	q.mu.Lock()
+	q.events = append(q.events, evt)
+	q.mu.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// drain returns the buffered events and empties the queue.
+func (q *eventQueue) drain() []Event {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	events := q.events
+	q.events = nil
+	return events
+}
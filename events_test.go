@@ -0,0 +1,69 @@
+//go:build go1.23
+
+package emitter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	e := NewMemoryEmitter()
+	done := make(chan []interface{}, 1)
+	go func() {
+		var payloads []interface{}
+		for evt := range e.Events(context.Background(), "order.**") {
+			payloads = append(payloads, evt.Payload())
+			if len(payloads) == 2 {
+				break
+			}
+		}
+		done <- payloads
+	}()
+	waitForListener(t, e, "order.**")
+
+	e.EmitSync("order.created", 1)
+	e.EmitSync("user.created", 2)
+	e.EmitSync("order.shipped", 3)
+
+	payloads := <-done
+	if len(payloads) != 2 || payloads[0] != 1 || payloads[1] != 3 {
+		t.Errorf("Events() yielded %v, want [1 3]", payloads)
+	}
+	if topic, _ := e.GetTopic("order.**"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after the loop exited, want 0", topic.ListenerCount())
+	}
+}
+
+func TestEventsCanceled(t *testing.T) {
+	e := NewMemoryEmitter()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	for range e.Events(ctx, "never") {
+		t.Error("Events() yielded an event, want none")
+	}
+	if topic, _ := e.GetTopic("never"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after cancellation, want 0", topic.ListenerCount())
+	}
+}
+
+func TestEventsClosed(t *testing.T) {
+	e := NewMemoryEmitter()
+	done := make(chan int, 1)
+	go func() {
+		count := 0
+		for range e.Events(context.Background(), "order.*") {
+			count++
+		}
+		done <- count
+	}()
+	waitForListener(t, e, "order.*")
+
+	e.EmitSync("order.created")
+	e.Close()
+	if count := <-done; count != 1 {
+		t.Errorf("Events() yielded %d events before Close, want 1", count)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/kaptinlin/emitter"
 )
@@ -13,7 +12,10 @@ func main() {
 
 	// High-priority listener for order validation
 	validateOrderListener := func(evt emitter.Event) error {
-		orderID := evt.Payload().(string)
+		orderID, ok := emitter.PayloadAs[string](evt)
+		if !ok {
+			return fmt.Errorf("order.created: unexpected payload %v", evt.Payload())
+		}
 		// Perform validation logic...
 		fmt.Printf("Validating order: %s\n", orderID)
 		// Simulate order validation failure
@@ -30,7 +32,7 @@ func main() {
 			fmt.Println("Payment processing skipped due to previous validation failure.")
 			return nil
 		}
-		orderID := evt.Payload().(string)
+		orderID := emitter.MustPayload[string](evt)
 		// Process payment logic...
 		fmt.Printf("Processing payment for order: %s\n", orderID)
 		return nil
@@ -42,7 +44,7 @@ func main() {
 			fmt.Println("Confirmation email not sent due to event abort.")
 			return nil
 		}
-		orderID := evt.Payload().(string)
+		orderID := emitter.MustPayload[string](evt)
 		// Send email logic...
 		fmt.Printf("Sending confirmation email for order: %s\n", orderID)
 		return nil
@@ -53,11 +55,14 @@ func main() {
 	e.On("order.created", processPaymentListener, emitter.WithPriority(emitter.Normal))
 	e.On("order.created", sendConfirmationEmailListener, emitter.WithPriority(emitter.Low))
 
-	// Emit events for order creation
+	// Emit events for order creation within a scope so we can wait for them
 	fmt.Println("Emitting event for order creation...")
-	e.Emit("order.created", "order123") // This order will fail validation
-	e.Emit("order.created", "order456") // This order will pass validation
+	scope := emitter.NewEmissionScope()
+	e.Go(scope, "order.created", "order123") // This order will fail validation
+	e.Go(scope, "order.created", "order456") // This order will pass validation
 
-	// Allow time for events to be processed
-	time.Sleep(1 * time.Second) // Replace with proper synchronization in production
+	// Wait for all events to be processed
+	if err := scope.Wait(); err != nil {
+		fmt.Println("Order processing failed:", err)
+	}
 }
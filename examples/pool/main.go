@@ -26,16 +26,17 @@ func main() {
 	// Subscribe the listener to a topic
 	e.On("user.signup", timeConsumingListener)
 
-	// Emit several events concurrently
+	// Emit several events concurrently within a scope
+	scope := emitter.NewEmissionScope()
 	for i := 0; i < 10; i++ {
-		go func(index int) {
-			payload := fmt.Sprintf("User #%d", index)
-			e.Emit("user.signup", payload)
-		}(i)
+		payload := fmt.Sprintf("User #%d", i)
+		e.Go(scope, "user.signup", payload)
 	}
 
 	// Wait for all events to be processed before shutting down
-	time.Sleep(10 * time.Second)
+	if err := scope.Wait(); err != nil {
+		fmt.Println("Processing failed:", err)
+	}
 
 	// Release the resources used by the pool
 	pool.Release()
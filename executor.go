@@ -0,0 +1,135 @@
+package emitter
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// Executor runs listener calls handed off by the emitter, for listeners that must run
+// somewhere else than the emitting goroutine: a dedicated goroutine serializing their
+// calls, a pool of their own, or the main loop of a UI framework.
+type Executor interface {
+	// Execute runs task, now or later, on the executor's goroutines.
+	Execute(task func())
+}
+
+// ExecutorFunc adapts a function to the Executor interface. A Pool can be used as an
+// executor with ExecutorFunc(pool.Submit).
+type ExecutorFunc func(task func())
+
+// Execute calls f(task).
+func (f ExecutorFunc) Execute(task func()) {
+	f(task)
+}
+
+// WithExecutor hands the listener's calls to exec instead of calling it while notifying
+// the topic, independently of the emitter's pool. The emission goes on without waiting
+// for the listener, so the listener cannot abort it, and its errors and panics are
+// reported to the error handler, the error topic and the panic topic, if set, rather than
+// to the emitting caller. The listener receives a copy of the event's envelope, so that
+// CurrentSubscription returns its own handle; combine it with WithClonedEvent for
+// listeners changing the contents of the event's payload.
+func WithExecutor(exec Executor) ListenerOption {
+	return func(item *listenerItem) {
+		item.executor = exec
+	}
+}
+
+// withHandOff wraps the listener so that its calls go to the executor set with
+// WithExecutor, if any.
+func (m *MemoryEmitter) withHandOff(topicName, listenerID string) ListenerOption {
+	return func(item *listenerItem) {
+		if item.executor == nil {
+			return
+		}
+		exec, listener := item.executor, item.listener
+		item.listener = func(evt Event) error {
+			evt = boundEvent(evt)
+			exec.Execute(func() { m.callHandedOff(listener, evt, topicName, listenerID) })
+			return nil
+		}
+	}
+}
+
+// callHandedOff calls a listener on its executor, reporting its error or panic.
+func (m *MemoryEmitter) callHandedOff(listener Listener, evt Event, topicName, listenerID string) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.reportListenerPanic(&ListenerPanic{Topic: evt.Topic(), ListenerID: listenerID, Event: evt, Value: r, Stack: debug.Stack()})
+		}
+	}()
+	if err := listener(evt); err != nil {
+		m.reportDeferredError(evt, topicName, listenerID, err)
+	}
+}
+
+// SerialExecutor is an Executor running tasks one at a time, in the order they were
+// handed to it, on a dedicated goroutine. Handing it tasks never blocks.
+type SerialExecutor struct {
+	mu      sync.Mutex
+	tasks   []func()
+	ready   chan struct{} // Signaled when tasks are queued.
+	closing bool
+	done    chan struct{} // Closed once the goroutine exits.
+}
+
+// NewSerialExecutor creates a SerialExecutor and starts its goroutine. Call Close to
+// stop it.
+func NewSerialExecutor() *SerialExecutor {
+	s := &SerialExecutor{ready: make(chan struct{}, 1), done: make(chan struct{})}
+	go s.run()
+	return s
+}
+
+// Execute queues task. Tasks queued after Close are dropped.
+func (s *SerialExecutor) Execute(task func()) {
+	s.queue(task)
+}
+
+// queue queues task unless the executor is closing, and reports whether it did.
+func (s *SerialExecutor) queue(task func()) bool {
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return false
+	}
+	s.tasks = append(s.tasks, task)
+	s.mu.Unlock()
+	s.signal()
+	return true
+}
+
+// Close stops the executor once the queued tasks ran, and waits for them.
+func (s *SerialExecutor) Close() {
+	s.mu.Lock()
+	s.closing = true
+	s.mu.Unlock()
+	s.signal()
+	<-s.done
+}
+
+// signal wakes the goroutine up.
+func (s *SerialExecutor) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// run executes the queued tasks until the executor is closed.
+func (s *SerialExecutor) run() {
+	defer close(s.done)
+	for range s.ready {
+		s.mu.Lock()
+		tasks, closing := s.tasks, s.closing
+		s.tasks = nil
+		s.mu.Unlock()
+
+		for _, task := range tasks {
+			task()
+		}
+		if closing {
+			return
+		}
+	}
+}
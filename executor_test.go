@@ -0,0 +1,110 @@
+package emitter
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestWithExecutor(t *testing.T) {
+	exec := NewSerialExecutor()
+	e := NewMemoryEmitter()
+	var seen []interface{}
+	e.On("order.*", func(evt Event) error {
+		seen = append(seen, evt.Payload())
+		return nil
+	}, WithExecutor(exec))
+
+	for i := 0; i < 100; i++ {
+		if errs := e.EmitSync("order.created", i); len(errs) != 0 {
+			t.Fatalf("EmitSync() = %v, want no error", errs)
+		}
+	}
+	exec.Close()
+
+	if len(seen) != 100 {
+		t.Fatalf("listener saw %d events, want 100", len(seen))
+	}
+	for i, payload := range seen {
+		if payload != i {
+			t.Fatalf("event %d has payload %v, want events in order", i, payload)
+		}
+	}
+}
+
+func TestWithExecutorReportsErrorsAndPanics(t *testing.T) {
+	errRejected := errors.New("rejected")
+	var mu sync.Mutex
+	var reported []error
+	var panics []interface{}
+	e := NewMemoryEmitter(
+		WithErrorHandler(func(evt Event, err error) error {
+			mu.Lock()
+			reported = append(reported, err)
+			mu.Unlock()
+			return nil
+		}),
+		WithPanicHandler(func(value interface{}) {
+			mu.Lock()
+			panics = append(panics, value)
+			mu.Unlock()
+		}),
+	)
+	inline := ExecutorFunc(func(task func()) { task() })
+	e.On("order.rejected", func(Event) error { return errRejected }, WithExecutor(inline))
+	e.On("order.failed", func(Event) error { panic("boom") }, WithExecutor(inline))
+
+	e.EmitSync("order.rejected")
+	e.EmitSync("order.failed")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 1 || !errors.Is(reported[0], errRejected) {
+		t.Errorf("error handler received %v, want %v", reported, errRejected)
+	}
+	if len(panics) != 1 || panics[0] != "boom" {
+		t.Errorf("panic handler received %v, want [boom]", panics)
+	}
+}
+
+func TestWithExecutorCurrentSubscription(t *testing.T) {
+	var tasks []func()
+	later := ExecutorFunc(func(task func()) { tasks = append(tasks, task) })
+	e := NewMemoryEmitter()
+
+	var calls int
+	once, _ := e.On("order.created", func(evt Event) error {
+		calls++
+		return CurrentSubscription(evt).Unsubscribe()
+	}, WithExecutor(later), WithPriority(High))
+	other, _ := e.On("order.created", func(Event) error { return nil }, WithExecutor(later))
+
+	e.EmitSync("order.created")
+	for _, task := range tasks {
+		task() // Runs once the emission moved on to the other listener.
+	}
+	tasks = nil
+	e.EmitSync("order.created")
+	for _, task := range tasks {
+		task()
+	}
+
+	if calls != 1 {
+		t.Errorf("listener called %d times, want it to remove itself after the first call", calls)
+	}
+	topic, _ := e.GetTopic("order.created")
+	if _, ok := topic.listeners[other]; !ok || len(topic.listeners) != 1 {
+		t.Errorf("listeners = %v, want only %s after %s unsubscribed", topic.listeners, other, once)
+	}
+}
+
+func TestSerialExecutorDropsTasksAfterClose(t *testing.T) {
+	exec := NewSerialExecutor()
+	exec.Close()
+	ran := false
+	exec.Execute(func() { ran = true })
+	exec.Close()
+	if ran {
+		t.Error("task queued after Close ran")
+	}
+}
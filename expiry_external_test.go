@@ -0,0 +1,121 @@
+package emitter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+func TestWithListenerTTL(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	calls := 0
+	var expired string
+	id, err := em.On("upload.progress", func(e emitter.Event) error {
+		calls++
+		return nil
+	}, emitter.WithListenerTTL(time.Minute), emitter.WithOnExpire(func(topic, listenerID string) {
+		expired = topic + "/" + listenerID
+	}))
+	if err != nil {
+		t.Fatalf("On() failed with error: %v", err)
+	}
+
+	em.EmitSync("upload.progress", 50)
+	clock.Advance(time.Minute)
+	em.EmitSync("upload.progress", 100)
+
+	if calls != 1 {
+		t.Errorf("Listener called %d times, want 1", calls)
+	}
+	if expired != "upload.progress/"+id {
+		t.Errorf("Expiry callback received %q, want upload.progress/%s", expired, id)
+	}
+}
+
+func TestWithTTL(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	calls := 0
+	em.On("node.heartbeat", func(e emitter.Event) error {
+		calls++
+		return nil
+	}, emitter.WithTTL(time.Minute))
+
+	em.EmitSync("node.heartbeat")
+	clock.Advance(time.Minute)
+	em.EmitSync("node.heartbeat")
+
+	if calls != 1 {
+		t.Errorf("Listener called %d times, want 1", calls)
+	}
+}
+
+func TestWithExpireAt(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := emittertest.NewFakeClock(start)
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	em.On("job.done", func(e emitter.Event) error { return nil },
+		emitter.WithExpireAt(start.Add(time.Hour)), emitter.WithListenerTTL(2*time.Hour))
+
+	clock.Advance(time.Hour)
+
+	if topic, _ := em.GetTopic("job.done"); topic.ListenerCount() != 0 {
+		t.Error("Listener should expire at the earlier of its TTL and expiry time")
+	}
+}
+
+func TestExpiringListenerRemovedWithOff(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	expired := false
+	id, _ := em.On("job.done", func(e emitter.Event) error { return nil },
+		emitter.WithListenerTTL(time.Minute), emitter.WithOnExpire(func(string, string) {
+			expired = true
+		}))
+	if err := em.Off("job.done", id); err != nil {
+		t.Fatalf("Off() failed with error: %v", err)
+	}
+
+	if clock.PendingTimers() != 0 {
+		t.Error("Removing the listener should stop its expiry timer")
+	}
+	clock.Advance(time.Minute)
+	if expired {
+		t.Error("Expiry callback should not run for listeners removed with Off")
+	}
+}
+
+func TestListenerTTLWithMaxCalls(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	heartbeats := 0
+	expired := false
+	em.On("node.heartbeat", func(e emitter.Event) error {
+		heartbeats++
+		return nil
+	}, emitter.WithMaxCalls(3), emitter.WithListenerTTL(time.Minute), emitter.WithOnExpire(func(string, string) {
+		expired = true
+	}))
+
+	for i := 0; i < 5; i++ {
+		em.EmitSync("node.heartbeat")
+	}
+	if heartbeats != 3 {
+		t.Errorf("Listener called %d times, want 3", heartbeats)
+	}
+	if clock.PendingTimers() != 0 {
+		t.Error("Exhausting the listener should stop its expiry timer")
+	}
+	clock.Advance(time.Minute)
+	if expired {
+		t.Error("Expiry callback should not run for listeners exhausted by WithMaxCalls")
+	}
+}
@@ -0,0 +1,172 @@
+package emitter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Explanation describes how a registered pattern relates to an emitted topic.
+type Explanation struct {
+	Pattern   string         `json:"pattern"`          // Registered topic name or pattern.
+	Matched   bool           `json:"matched"`          // Whether events on the topic reach the pattern's listeners.
+	Listeners int            `json:"listeners"`        // Number of listeners subscribed to the pattern.
+	Reason    string         `json:"reason,omitempty"` // Why the pattern does not match; empty when it does.
+	Segments  []SegmentMatch `json:"segments"`         // Pattern segments aligned with the subject segments they cover.
+}
+
+// SegmentMatch aligns one pattern segment with the subject segments it covers. A
+// multi-segment wildcard can cover none or several of them.
+type SegmentMatch struct {
+	Pattern string `json:"pattern"`
+	Subject string `json:"subject"` // Covered subject segments, joined with dots.
+	Matched bool   `json:"matched"`
+}
+
+// Explain reports, for every registered pattern in sorted order, whether an event
+// emitted on eventName would reach its listeners. Patterns that do not match carry a
+// reason and the segment alignment up to the first mismatch, which helps debugging
+// wildcard surprises such as "event.**" not matching "event".
+func (m *MemoryEmitter) Explain(eventName string) []Explanation {
+	snapshot := m.topics.load()
+	patterns := make([]string, 0, len(snapshot.entries))
+	for pattern := range snapshot.entries {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	explanations := make([]Explanation, 0, len(patterns))
+	for _, pattern := range patterns {
+		topic := snapshot.entries[pattern].topic
+		topic.mu.RLock()
+		listeners := len(topic.listeners)
+		topic.mu.RUnlock()
+
+		explanation := explainMatch(snapshot.profile, pattern, eventName)
+		explanation.Listeners = listeners
+		explanations = append(explanations, explanation)
+	}
+	return explanations
+}
+
+// ExplainMatch describes whether the topic matches the pattern under the default
+// matching rules and, if not, why.
+func ExplainMatch(pattern, topic string) Explanation {
+	return explainMatch(MatchDefault, pattern, topic)
+}
+
+// Explain describes whether the topic matches the pattern under the profile and, if
+// not, why.
+func (p MatchProfile) Explain(pattern, topic string) Explanation {
+	return explainMatch(p, pattern, topic)
+}
+
+// explainMatch follows the same rules as profile.Match, recording the alignment of the
+// successful path or of the path that got furthest before failing.
+func explainMatch(profile MatchProfile, pattern, subject string) Explanation {
+	e := Explanation{Pattern: pattern, Matched: profile.Match(pattern, subject), Segments: []SegmentMatch{}}
+	patternParts, subjectParts := splitTopic(pattern), splitTopic(subject)
+
+	if profile == MatchDefault {
+		if pattern == SingleWildcard && subject == "" {
+			e.Segments = append(e.Segments, SegmentMatch{Pattern: pattern, Matched: true})
+			return e
+		}
+		if len(patternParts) > 1 && patternParts[len(patternParts)-1] == MultiWildcard && len(subjectParts) == 1 && subjectParts[0] == patternParts[0] {
+			e.Segments = append(e.Segments, SegmentMatch{Pattern: patternParts[0], Subject: subjectParts[0], Matched: true})
+			e.Reason = fmt.Sprintf("%q requires at least one segment after %q", pattern, subjectParts[0])
+			return e
+		}
+	}
+
+	x := &matchExplainer{rules: profile.rules(), patternParts: patternParts, subjectParts: subjectParts, depth: -1}
+	if x.walk(0, 0) {
+		e.Segments = append(e.Segments, x.path...)
+	} else {
+		e.Segments = append(e.Segments, x.failedPath...)
+		e.Reason = x.reason
+	}
+	return e
+}
+
+// matchExplainer walks the pattern against the subject like matchRules.match, keeping
+// the alignment of the current path and of the deepest failure seen.
+type matchExplainer struct {
+	rules                      matchRules
+	patternParts, subjectParts []string
+	path                       []SegmentMatch
+	failedPath                 []SegmentMatch
+	reason                     string
+	depth                      int // Rank of the deepest failure.
+}
+
+// walk reports whether the pattern segments from p match the subject segments from s.
+func (x *matchExplainer) walk(p, s int) bool {
+	patternParts, subjectParts := x.patternParts, x.subjectParts
+	if p == len(patternParts) {
+		if s == len(subjectParts) {
+			return true
+		}
+		rest := strings.Join(subjectParts[s:], ".")
+		return x.fail(p, s, SegmentMatch{Subject: rest},
+			fmt.Sprintf("subject segments %q are left over after the pattern ends", rest))
+	}
+
+	segment := patternParts[p]
+	if x.rules.isMulti(patternParts, p) {
+		for i := s + x.rules.multiMin; i <= len(subjectParts); i++ {
+			if !x.rules.covers(subjectParts[s:i]) {
+				return x.fail(p, i-1, SegmentMatch{Pattern: segment, Subject: strings.Join(subjectParts[s:i], ".")},
+					fmt.Sprintf("wildcard %q does not match empty segments", segment))
+			}
+			if x.step(SegmentMatch{Pattern: segment, Subject: strings.Join(subjectParts[s:i], "."), Matched: true}, p+1, i) {
+				return true
+			}
+		}
+		if s+x.rules.multiMin > len(subjectParts) {
+			return x.fail(p, -1, SegmentMatch{Pattern: segment},
+				fmt.Sprintf("wildcard %q has no subject segment left to match", segment))
+		}
+		return false
+	}
+	if s == len(subjectParts) {
+		return x.fail(p, -1, SegmentMatch{Pattern: segment},
+			fmt.Sprintf("pattern segment %q has no subject segment left to match", segment))
+	}
+	switch segment {
+	case SingleWildcard:
+		if !x.rules.covers(subjectParts[s : s+1]) {
+			return x.fail(p, s, SegmentMatch{Pattern: segment},
+				fmt.Sprintf("wildcard %q does not match empty segments", segment))
+		}
+		return x.step(SegmentMatch{Pattern: segment, Subject: subjectParts[s], Matched: true}, p+1, s+1)
+	case subjectParts[s]:
+		return x.step(SegmentMatch{Pattern: segment, Subject: subjectParts[s], Matched: true}, p+1, s+1)
+	default:
+		return x.fail(p, s, SegmentMatch{Pattern: segment, Subject: subjectParts[s]},
+			fmt.Sprintf("subject segment %q does not match pattern segment %q", subjectParts[s], segment))
+	}
+}
+
+// step extends the path with segment and continues the walk from p and s.
+func (x *matchExplainer) step(segment SegmentMatch, p, s int) bool {
+	mark := len(x.path)
+	x.path = append(x.path, segment)
+	if x.walk(p, s) {
+		return true
+	}
+	x.path = x.path[:mark]
+	return false
+}
+
+// fail records the failure at pattern segment p and subject segment s if it got further
+// than any before, and returns false. Failures are ranked by pattern progress, then by
+// subject progress; running out of subject segments, reported with s of -1, ranks
+// below a mismatch at the same pattern segment, which explains more.
+func (x *matchExplainer) fail(p, s int, segment SegmentMatch, reason string) bool {
+	if depth := p*(len(x.subjectParts)+2) + s + 1; depth > x.depth {
+		x.depth, x.reason = depth, reason
+		x.failedPath = append(append([]SegmentMatch{}, x.path...), segment)
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package emitter
+
+import (
+	"testing"
+)
+
+func TestExplainMatch(t *testing.T) {
+	tests := []struct {
+		pattern, topic string
+		matched        bool
+		reason         string
+		segments       int
+	}{
+		{"event.*", "event.created", true, "", 2},
+		{"event.**", "event.a.b", true, "", 2},
+		{"**.done", "job.step.done", true, "", 2},
+		{"event.**", "event", false, `"event.**" requires at least one segment after "event"`, 1},
+		{"event.*", "event.a.b", false, `subject segments "b" are left over after the pattern ends`, 3},
+		{"event.*.done", "event.a", false, `pattern segment "done" has no subject segment left to match`, 3},
+		{"order.created", "order.updated", false, `subject segment "updated" does not match pattern segment "created"`, 2},
+		{"**.done", "job.step.failed", false, `subject segment "failed" does not match pattern segment "done"`, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.topic, func(t *testing.T) {
+			e := ExplainMatch(tt.pattern, tt.topic)
+			if e.Matched != tt.matched || e.Matched != MatchTopic(tt.pattern, tt.topic) {
+				t.Errorf("Matched = %v, want %v", e.Matched, tt.matched)
+			}
+			if e.Reason != tt.reason {
+				t.Errorf("Reason = %q, want %q", e.Reason, tt.reason)
+			}
+			if len(e.Segments) != tt.segments {
+				t.Errorf("Segments = %+v, want %d", e.Segments, tt.segments)
+			}
+		})
+	}
+}
+
+func TestExplainMatchFailedSegment(t *testing.T) {
+	e := ExplainMatch("order.*.created", "order.eu.updated")
+	want := []SegmentMatch{
+		{Pattern: "order", Subject: "order", Matched: true},
+		{Pattern: "*", Subject: "eu", Matched: true},
+		{Pattern: "created", Subject: "updated"},
+	}
+	if len(e.Segments) != len(want) {
+		t.Fatalf("Segments = %+v, want %+v", e.Segments, want)
+	}
+	for i := range want {
+		if e.Segments[i] != want[i] {
+			t.Errorf("Segments[%d] = %+v, want %+v", i, e.Segments[i], want[i])
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	e := NewMemoryEmitter()
+	listener := func(evt Event) error { return nil }
+	e.On("event.**", listener)
+	e.On("event.**", listener)
+	e.On("event", listener)
+
+	explanations := e.Explain("event")
+	if len(explanations) != 2 {
+		t.Fatalf("Explain returned %d explanations, want 2", len(explanations))
+	}
+	if got := explanations[0]; got.Pattern != "event" || !got.Matched || got.Listeners != 1 {
+		t.Errorf("explanations[0] = %+v", got)
+	}
+	if got := explanations[1]; got.Pattern != "event.**" || got.Matched || got.Listeners != 2 || got.Reason == "" {
+		t.Errorf("explanations[1] = %+v", got)
+	}
+}
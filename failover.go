@@ -0,0 +1,287 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Reserved topics on which a TeeEmitter reports the connection state of its
+// FailoverPublisher. Their payload is a *ConnectionEvent, and they are only dispatched
+// to local listeners.
+const (
+	TopicBackendDisconnected = "emitter.backend.disconnected"
+	TopicBackendReconnected  = "emitter.backend.reconnected"
+	TopicBackendOverflow     = "emitter.backend.overflow"
+)
+
+// Defaults of a FailoverPublisher.
+const (
+	defaultMinReconnectBackoff = 100 * time.Millisecond
+	defaultMaxReconnectBackoff = 30 * time.Second
+	defaultPublishQueueSize    = 1024
+)
+
+// ConnectionEvent is the payload of the events published on the reserved backend topics.
+type ConnectionEvent struct {
+	Err      error     // Failure that disconnected the backend, for TopicBackendDisconnected.
+	Attempts int       // Reconnection attempts it took, for TopicBackendReconnected.
+	Queued   int       // Envelopes waiting for the backend when the event was published.
+	Dropped  *Envelope // Envelope dropped from the full queue, for TopicBackendOverflow.
+}
+
+// Connector is implemented by Publishers that can re-establish their connection to the
+// backend. A FailoverPublisher calls Connect before publishing its queued envelopes
+// again.
+type Connector interface {
+	// Connect opens a new connection to the backend.
+	Connect(ctx context.Context) error
+}
+
+// OverflowPolicy decides what a FailoverPublisher does with an envelope published while
+// its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest drops the oldest queued envelope to make room for the new one.
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowDropNewest drops the new envelope.
+	OverflowDropNewest
+	// OverflowReject refuses the new envelope with ErrPublishQueueFull, so that the
+	// emitting caller sees the failure.
+	OverflowReject
+)
+
+// FailoverPublisher is a Publisher riding out outages of the backend. Once publishing
+// fails, it queues the failed envelope and those published after it, and tries to
+// reconnect with exponential backoff. When it succeeds, it publishes the queued
+// envelopes in order and resumes publishing directly. Publishers implementing Connector
+// are reconnected with Connect; others are probed by publishing the oldest queued
+// envelope.
+//
+// Given to NewTeeEmitter, it schedules its reconnections on the tee's clock and reports
+// its state on the reserved backend topics of the tee.
+type FailoverPublisher struct {
+	publisher  Publisher
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	capacity   int
+	overflow   OverflowPolicy
+
+	mu        sync.Mutex
+	afterFunc func(time.Duration, func()) Timer            // Schedules reconnection attempts.
+	notify    func(topic string, payload *ConnectionEvent) // Reports state changes, if attached to a tee.
+	connected bool
+	queue     []*Envelope // Envelopes waiting for the backend, oldest first.
+	attempts  int         // Failed reconnection attempts since the backend was lost.
+	timer     Timer       // Pending reconnection attempt.
+	closed    bool
+}
+
+// FailoverOption defines a function type for FailoverPublisher configuration options.
+type FailoverOption func(*FailoverPublisher)
+
+// WithReconnectBackoff sets the delay before the first reconnection attempt, doubled
+// after each failed attempt up to maxDelay. The defaults are 100ms and 30s.
+func WithReconnectBackoff(minDelay, maxDelay time.Duration) FailoverOption {
+	return func(f *FailoverPublisher) {
+		f.minBackoff, f.maxBackoff = minDelay, maxDelay
+	}
+}
+
+// WithPublishQueue bounds the number of envelopes queued while the backend is
+// unavailable and sets what happens to those published beyond it. The default is 1024
+// envelopes with OverflowDropOldest; a size of zero or less leaves the queue unbounded.
+func WithPublishQueue(size int, policy OverflowPolicy) FailoverOption {
+	return func(f *FailoverPublisher) {
+		f.capacity, f.overflow = size, policy
+	}
+}
+
+// NewFailoverPublisher creates a FailoverPublisher publishing with publisher, which is
+// assumed to be connected.
+func NewFailoverPublisher(publisher Publisher, opts ...FailoverOption) *FailoverPublisher {
+	f := &FailoverPublisher{
+		publisher:  publisher,
+		minBackoff: defaultMinReconnectBackoff,
+		maxBackoff: defaultMaxReconnectBackoff,
+		capacity:   defaultPublishQueueSize,
+		afterFunc:  RealClock().AfterFunc,
+		connected:  true,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Publish sends the envelope to the backend, or queues it while the backend is
+// unavailable. It only fails when the queue is full under OverflowReject.
+func (f *FailoverPublisher) Publish(ctx context.Context, env *Envelope) error {
+	f.mu.Lock()
+	if !f.connected || len(f.queue) > 0 {
+		dropped, err := f.enqueueLocked(env)
+		queued, notify := len(f.queue), f.notify
+		f.mu.Unlock()
+		reportDropped(notify, dropped, queued)
+		return err
+	}
+	f.mu.Unlock()
+
+	err := f.publisher.Publish(ctx, env)
+	if err == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	dropped, queueErr := f.enqueueLocked(env)
+	lost := f.connected
+	if lost {
+		f.connected = false
+		f.scheduleLocked()
+	}
+	queued, notify := len(f.queue), f.notify
+	f.mu.Unlock()
+	if lost && notify != nil {
+		notify(TopicBackendDisconnected, &ConnectionEvent{Err: err, Queued: queued})
+	}
+	reportDropped(notify, dropped, queued)
+	return queueErr
+}
+
+// Connected reports whether envelopes are published directly to the backend.
+func (f *FailoverPublisher) Connected() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.connected
+}
+
+// Queued returns the number of envelopes waiting for the backend.
+func (f *FailoverPublisher) Queued() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.queue)
+}
+
+// Close stops reconnecting to the backend. The envelopes still queued are not published.
+func (f *FailoverPublisher) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	return nil
+}
+
+// attachTee schedules the reconnections on the tee's clock and reports the connection
+// state on the tee.
+func (f *FailoverPublisher) attachTee(t *TeeEmitter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.afterFunc = t.afterFunc
+	f.notify = func(topic string, payload *ConnectionEvent) {
+		t.publishLocal(topic, payload)
+	}
+}
+
+// enqueueLocked queues the envelope under the overflow policy. It returns the envelope
+// dropped to respect the queue's bound, if any. Callers must hold f.mu.
+func (f *FailoverPublisher) enqueueLocked(env *Envelope) (*Envelope, error) {
+	var dropped *Envelope
+	if f.capacity > 0 && len(f.queue) >= f.capacity {
+		switch f.overflow {
+		case OverflowDropNewest:
+			return env, nil
+		case OverflowReject:
+			return nil, ErrPublishQueueFull
+		default:
+			dropped = f.queue[0]
+			f.queue = f.queue[1:]
+		}
+	}
+	f.queue = append(f.queue, env)
+	return dropped, nil
+}
+
+// scheduleLocked schedules the next reconnection attempt, backing off exponentially with
+// the number of failed attempts. Callers must hold f.mu.
+func (f *FailoverPublisher) scheduleLocked() {
+	if f.closed {
+		return
+	}
+	delay := f.minBackoff
+	for i := 0; i < f.attempts && delay < f.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > f.maxBackoff {
+		delay = f.maxBackoff
+	}
+	f.timer = f.afterFunc(delay, f.reconnect)
+}
+
+// reconnect tries to reconnect to the backend and publish the queued envelopes, and
+// schedules another attempt if it fails.
+func (f *FailoverPublisher) reconnect() {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return
+	}
+	f.attempts++
+	attempts := f.attempts
+	f.mu.Unlock()
+
+	var err error
+	if connector, ok := f.publisher.(Connector); ok {
+		err = connector.Connect(context.Background())
+	}
+	if err == nil {
+		err = f.flush()
+	}
+
+	f.mu.Lock()
+	if err != nil {
+		f.scheduleLocked()
+		f.mu.Unlock()
+		return
+	}
+	f.attempts = 0
+	notify := f.notify
+	f.mu.Unlock()
+	if notify != nil {
+		notify(TopicBackendReconnected, &ConnectionEvent{Attempts: attempts})
+	}
+}
+
+// flush publishes the queued envelopes in order, and marks the backend connected once
+// the queue is empty.
+func (f *FailoverPublisher) flush() error {
+	for {
+		f.mu.Lock()
+		if len(f.queue) == 0 {
+			f.connected = true
+			f.mu.Unlock()
+			return nil
+		}
+		env := f.queue[0]
+		f.mu.Unlock()
+
+		if err := f.publisher.Publish(context.Background(), env); err != nil {
+			return err
+		}
+
+		f.mu.Lock()
+		if len(f.queue) > 0 && f.queue[0] == env { // Unless dropped as the oldest meanwhile.
+			f.queue = f.queue[1:]
+		}
+		f.mu.Unlock()
+	}
+}
+
+// reportDropped reports an envelope dropped from the full queue, if any.
+func reportDropped(notify func(string, *ConnectionEvent), dropped *Envelope, queued int) {
+	if dropped != nil && notify != nil {
+		notify(TopicBackendOverflow, &ConnectionEvent{Queued: queued, Dropped: dropped})
+	}
+}
@@ -0,0 +1,112 @@
+package emitter_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+// flakyBroker is a Publisher whose connection can be cut and restored.
+type flakyBroker struct {
+	mu        sync.Mutex
+	up        bool
+	connects  int
+	published []string
+}
+
+func (b *flakyBroker) Publish(_ context.Context, env *emitter.Envelope) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.up {
+		return errors.New("connection reset")
+	}
+	b.published = append(b.published, string(env.Payload))
+	return nil
+}
+
+func (b *flakyBroker) Connect(context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.connects++
+	if !b.up {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (b *flakyBroker) setUp(up bool) {
+	b.mu.Lock()
+	b.up = up
+	b.mu.Unlock()
+}
+
+func TestFailoverPublisher(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	broker := &flakyBroker{up: true}
+	publisher := emitter.NewFailoverPublisher(broker,
+		emitter.WithReconnectBackoff(time.Second, 4*time.Second),
+		emitter.WithPublishQueue(2, emitter.OverflowDropOldest))
+	tee := emitter.NewTeeEmitter(publisher, emitter.WithTeeEmitterOptions(emitter.WithClock(clock)))
+	defer tee.Close()
+
+	var states []string
+	tee.On("emitter.backend.*", func(evt emitter.Event) error {
+		states = append(states, evt.Topic())
+		return nil
+	})
+
+	tee.EmitSync("order.created", 1)
+	broker.setUp(false)
+	for i := 2; i <= 4; i++ {
+		if errs := tee.EmitSync("order.created", i); len(errs) != 0 {
+			t.Fatalf("EmitSync() during the outage = %v, want the event queued", errs)
+		}
+	}
+	if publisher.Connected() || publisher.Queued() != 2 {
+		t.Fatalf("Connected() = %v with %d queued, want disconnected with 2 queued", publisher.Connected(), publisher.Queued())
+	}
+
+	clock.Advance(time.Second)     // First attempt fails.
+	clock.Advance(2 * time.Second) // Second attempt fails.
+	broker.setUp(true)
+	clock.Advance(3 * time.Second) // Not due yet: backoff is 4s.
+	if publisher.Connected() {
+		t.Fatal("reconnected before the backoff elapsed")
+	}
+	clock.Advance(time.Second)
+	if !publisher.Connected() || publisher.Queued() != 0 {
+		t.Fatalf("Connected() = %v with %d queued, want reconnected and flushed", publisher.Connected(), publisher.Queued())
+	}
+	tee.EmitSync("order.created", 5)
+
+	if want := []string{"1", "3", "4", "5"}; !reflect.DeepEqual(broker.published, want) {
+		t.Errorf("broker received %v, want %v", broker.published, want)
+	}
+	if broker.connects != 3 {
+		t.Errorf("Connect() called %d times, want 3", broker.connects)
+	}
+	want := []string{emitter.TopicBackendDisconnected, emitter.TopicBackendOverflow, emitter.TopicBackendReconnected}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("state events = %v, want %v", states, want)
+	}
+}
+
+func TestFailoverPublisherReject(t *testing.T) {
+	broker := &flakyBroker{}
+	publisher := emitter.NewFailoverPublisher(broker, emitter.WithPublishQueue(1, emitter.OverflowReject))
+	defer publisher.Close()
+
+	ctx := context.Background()
+	if err := publisher.Publish(ctx, &emitter.Envelope{Topic: "a"}); err != nil {
+		t.Fatalf("Publish() error = %v, want the envelope queued", err)
+	}
+	if err := publisher.Publish(ctx, &emitter.Envelope{Topic: "b"}); !errors.Is(err, emitter.ErrPublishQueueFull) {
+		t.Errorf("Publish() error = %v, want %v", err, emitter.ErrPublishQueueFull)
+	}
+}
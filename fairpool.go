@@ -0,0 +1,155 @@
+package emitter
+
+import "sync"
+
+// TopicSubmitter is implemented by pools that schedule tasks by topic. Emitters submit
+// asynchronous emissions to such pools with the topic of the event, so that a burst of
+// emissions on one topic cannot hold back the others.
+type TopicSubmitter interface {
+	// SubmitForTopic queues the task of an emission on the given topic.
+	SubmitForTopic(task func(), topic string)
+	// TrySubmitForTopic queues the task unless the pool is saturated and reports whether it did.
+	TrySubmitForTopic(task func(), topic string) bool
+}
+
+// FairPool wraps a Pool so that the emissions of busy topics share its workers fairly.
+// Queued tasks are kept in one FIFO queue per topic, and workers take the next task of
+// each topic in turn, round-robin. A topic emitting thousands of events then delays an
+// emission on another topic by at most one task per topic ahead of it, rather than by
+// its whole backlog, which shortens the tail latency of quiet topics sharing the pool.
+//
+// Emissions on the same topic still start in the order they were made. Fairness applies
+// between emissions: the listener chain of one emission runs as a single task.
+type FairPool struct {
+	pool   Pool
+	mu     sync.Mutex
+	queues map[string]*topicQueue
+	ring   []*topicQueue // Topics with queued tasks, in the order workers serve them.
+	queued int
+}
+
+// topicQueue holds the queued tasks of a topic in a FairPool.
+type topicQueue struct {
+	topic string
+	tasks []func()
+}
+
+// NewFairPool wraps pool with fair scheduling across topics.
+func NewFairPool(pool Pool) *FairPool {
+	return &FairPool{pool: pool, queues: make(map[string]*topicQueue)}
+}
+
+// Submit queues a task that is not tied to a topic. Such tasks share one queue.
+func (p *FairPool) Submit(task func()) {
+	p.SubmitForTopic(task, "")
+}
+
+// SubmitForTopic queues the task behind the queued tasks of the same topic.
+func (p *FairPool) SubmitForTopic(task func(), topic string) {
+	p.mu.Lock()
+	p.pushLocked(task, topic)
+	p.mu.Unlock()
+	p.pool.Submit(p.runNext)
+}
+
+// TrySubmit queues a task that is not tied to a topic unless the wrapped pool is saturated.
+func (p *FairPool) TrySubmit(task func()) bool {
+	return p.TrySubmitForTopic(task, "")
+}
+
+// TrySubmitForTopic queues the task unless the wrapped pool is saturated. Pools that
+// cannot refuse tasks always accept it.
+func (p *FairPool) TrySubmitForTopic(task func(), topic string) bool {
+	pool, ok := p.pool.(TrySubmitter)
+	if !ok {
+		p.SubmitForTopic(task, topic)
+		return true
+	}
+
+	// Hold the lock while submitting so that no worker can take the task before it is
+	// known whether the wrapped pool accepted it.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	q := p.pushLocked(task, topic)
+	if pool.TrySubmit(p.runNext) {
+		return true
+	}
+	q.tasks = q.tasks[:len(q.tasks)-1]
+	p.queued--
+	if len(q.tasks) == 0 {
+		p.dropLocked(q)
+	}
+	return false
+}
+
+// Running returns the number of running workers of the wrapped pool.
+func (p *FairPool) Running() int {
+	return p.pool.Running()
+}
+
+// Waiting returns the number of tasks that have not started yet.
+func (p *FairPool) Waiting() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.queued
+}
+
+// Capacity returns the queue capacity of the wrapped pool, or zero if it does not report one.
+func (p *FairPool) Capacity() int {
+	if metrics, ok := p.pool.(QueueMetrics); ok {
+		return metrics.Capacity()
+	}
+	return 0
+}
+
+// Release releases the wrapped pool, which runs the tasks still queued.
+func (p *FairPool) Release() {
+	p.pool.Release()
+}
+
+// pushLocked adds a task to the queue of its topic and returns the queue. Callers must
+// hold p.mu.
+func (p *FairPool) pushLocked(task func(), topic string) *topicQueue {
+	q, ok := p.queues[topic]
+	if !ok {
+		q = &topicQueue{topic: topic}
+		p.queues[topic] = q
+		p.ring = append(p.ring, q)
+	}
+	q.tasks = append(q.tasks, task)
+	p.queued++
+	return q
+}
+
+// dropLocked forgets the empty queue of a topic. Callers must hold p.mu.
+func (p *FairPool) dropLocked(q *topicQueue) {
+	delete(p.queues, q.topic)
+	for i, candidate := range p.ring {
+		if candidate == q {
+			p.ring = append(p.ring[:i], p.ring[i+1:]...)
+			return
+		}
+	}
+}
+
+// runNext runs the next task of the topic whose turn it is, and moves that topic to the
+// back of the ring. It is what the wrapped pool executes.
+func (p *FairPool) runNext() {
+	p.mu.Lock()
+	if len(p.ring) == 0 {
+		p.mu.Unlock()
+		return
+	}
+	q := p.ring[0]
+	task := q.tasks[0]
+	q.tasks[0] = nil
+	q.tasks = q.tasks[1:]
+	p.queued--
+	p.ring = append(p.ring[1:], q)
+	if len(q.tasks) == 0 {
+		p.dropLocked(q)
+	}
+	p.mu.Unlock()
+
+	task()
+}
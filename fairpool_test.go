@@ -0,0 +1,95 @@
+package emitter
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFairPoolInterleavesTopics(t *testing.T) {
+	inner := newGatedPool(16)
+	pool := NewFairPool(inner)
+	e := NewMemoryEmitter(WithPool(pool))
+
+	var mu sync.Mutex
+	var order []interface{}
+	e.On("**", func(evt Event) error {
+		mu.Lock()
+		order = append(order, evt.Payload())
+		mu.Unlock()
+		return nil
+	})
+
+	for i := 1; i <= 3; i++ {
+		e.Emit("bulk.import", i)
+	}
+	e.Emit("user.login", "login")
+	e.Emit("bulk.import", 4)
+	e.Emit("user.logout", "logout")
+	if waiting := pool.Waiting(); waiting != 6 {
+		t.Errorf("Waiting() = %d, want 6", waiting)
+	}
+
+	close(inner.gate)
+	if err := e.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := []interface{}{1, "login", "logout", 2, 3, 4}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("emission order = %v, want %v", order, want)
+	}
+}
+
+func TestFairPoolTryEmitSaturated(t *testing.T) {
+	inner := newGatedPool(1)
+	pool := NewFairPool(inner)
+	e := NewMemoryEmitter(WithPool(pool))
+
+	if ok, err := e.TryEmit("job.run", 1); !ok || err != nil {
+		t.Fatalf("TryEmit() = %v, %v, want the emission accepted", ok, err)
+	}
+	if ok, _ := e.TryEmit("job.run", 2); ok {
+		t.Error("TryEmit() on a saturated pool accepted the emission")
+	}
+	if waiting := pool.Waiting(); waiting != 1 {
+		t.Errorf("Waiting() = %d after a refused emission, want 1", waiting)
+	}
+	close(inner.gate)
+	e.Close()
+}
+
+// BenchmarkFairPoolLatency measures how long an emission on a quiet topic waits behind
+// a backlog of slow emissions on a busy topic, with a FIFO pool and with a FairPool.
+func BenchmarkFairPoolLatency(b *testing.B) {
+	const backlog = 200
+	pools := map[string]func() Pool{
+		"FIFO": func() Pool { return NewPondPool(4, 2*backlog) },
+		"Fair": func() Pool { return NewFairPool(NewPondPool(4, 2*backlog)) },
+	}
+	for _, name := range []string{"FIFO", "Fair"} {
+		newPool := pools[name]
+		b.Run(name, func(b *testing.B) {
+			var latency time.Duration
+			for i := 0; i < b.N; i++ {
+				e := NewMemoryEmitter(WithPool(newPool()))
+				e.On("bulk.import", func(Event) error {
+					time.Sleep(50 * time.Microsecond)
+					return nil
+				})
+				e.On("user.login", func(Event) error { return nil })
+
+				for j := 0; j < backlog; j++ {
+					e.Emit("bulk.import", j)
+				}
+				start := time.Now()
+				<-e.Emit("user.login")
+				latency += time.Since(start)
+				e.Close()
+			}
+			b.ReportMetric(float64(latency.Microseconds())/float64(b.N), "µs/quiet-emit")
+		})
+	}
+}
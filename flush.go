@@ -0,0 +1,61 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+)
+
+// inflightTracker counts asynchronous emissions that have been queued or are running,
+// and lets callers wait until none are left.
+type inflightTracker struct {
+	mu      sync.Mutex
+	count   int
+	waiters []chan struct{}
+}
+
+// add records a new in-flight emission.
+func (t *inflightTracker) add() {
+	t.mu.Lock()
+	t.count++
+	t.mu.Unlock()
+}
+
+// done records the completion of an in-flight emission and wakes waiters once idle.
+func (t *inflightTracker) done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.count--
+	if t.count == 0 {
+		for _, waiter := range t.waiters {
+			close(waiter)
+		}
+		t.waiters = nil
+	}
+}
+
+// idle returns a channel that is closed once no emissions are in flight.
+func (t *inflightTracker) idle() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch := make(chan struct{})
+	if t.count == 0 {
+		close(ch)
+		return ch
+	}
+	t.waiters = append(t.waiters, ch)
+	return ch
+}
+
+// Flush blocks until every asynchronous emission that is queued or running has
+// completed, without closing the emitter. Emissions started while waiting extend the
+// wait. It returns ctx.Err() if the context ends first.
+func (m *MemoryEmitter) Flush(ctx context.Context) error {
+	select {
+	case <-m.inflight.idle():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
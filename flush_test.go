@@ -0,0 +1,70 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlushWaitsForInFlightEmissions(t *testing.T) {
+	for name, emitter := range map[string]*MemoryEmitter{
+		"goroutines": NewMemoryEmitter(),
+		"pool":       NewMemoryEmitter(WithPool(NewPondPool(2, 100))),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var processed int32
+			emitter.On("batch.item", func(e Event) error {
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&processed, 1)
+				return nil
+			})
+
+			for i := 0; i < 8; i++ {
+				emitter.Emit("batch.item", i)
+			}
+
+			if err := emitter.Flush(context.Background()); err != nil {
+				t.Fatalf("Flush() failed with error: %v", err)
+			}
+			if got := atomic.LoadInt32(&processed); got != 8 {
+				t.Errorf("Processed %d emissions after Flush(), want 8", got)
+			}
+
+			// The emitter stays usable after a flush.
+			emitter.Emit("batch.item", 8)
+			if err := emitter.Flush(context.Background()); err != nil {
+				t.Fatalf("Flush() failed with error: %v", err)
+			}
+			if got := atomic.LoadInt32(&processed); got != 9 {
+				t.Errorf("Processed %d emissions after second Flush(), want 9", got)
+			}
+		})
+	}
+}
+
+func TestFlushWhenIdle(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	if err := emitter.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() on an idle emitter failed with error: %v", err)
+	}
+}
+
+func TestFlushRespectsContext(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	release := make(chan struct{})
+	emitter.On("slow", func(e Event) error {
+		<-release
+		return nil
+	})
+	defer close(release)
+
+	emitter.Emit("slow", nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := emitter.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Flush() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,61 @@
+package emitter
+
+import (
+	"errors"
+	"sync"
+)
+
+// EmissionGroup runs functions as part of a group the caller can wait on. It is
+// satisfied by *errgroup.Group from golang.org/x/sync and by *EmissionScope.
+type EmissionGroup interface {
+	Go(fn func() error)
+}
+
+// EmissionScope is a minimal EmissionGroup that waits for every emission started in it
+// and reports all of their errors, rather than only the first one.
+type EmissionScope struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewEmissionScope creates an empty EmissionScope.
+func NewEmissionScope() *EmissionScope {
+	return &EmissionScope{}
+}
+
+// Go runs fn in a new goroutine and records its error, if any.
+func (s *EmissionScope) Go(fn func() error) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := fn(); err != nil {
+			s.mu.Lock()
+			s.errs = append(s.errs, err)
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// Wait blocks until every function started with Go has returned and joins their errors.
+func (s *EmissionScope) Wait() error {
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.errs...)
+}
+
+// Go emits an event asynchronously as part of the given group, so the caller can wait
+// for all emissions started in a scope. The group's function returns the joined errors
+// of the emission once every listener has been notified.
+func (m *MemoryEmitter) Go(group EmissionGroup, eventName string, args ...interface{}) {
+	errChan := m.Emit(eventName, args...)
+	group.Go(func() error {
+		var errs []error
+		for err := range errChan {
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	})
+}
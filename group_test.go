@@ -0,0 +1,75 @@
+package emitter
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGoWithEmissionScope(t *testing.T) {
+	emitter := NewMemoryEmitter(WithPool(NewPondPool(4, 100)))
+
+	var processed int32
+	emitter.On("task.run", func(e Event) error {
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&processed, 1)
+		return nil
+	})
+
+	scope := NewEmissionScope()
+	for i := 0; i < 10; i++ {
+		emitter.Go(scope, "task.run", i)
+	}
+
+	if err := scope.Wait(); err != nil {
+		t.Fatalf("Wait() failed with error: %v", err)
+	}
+	if got := atomic.LoadInt32(&processed); got != 10 {
+		t.Errorf("Processed %d emissions, want 10", got)
+	}
+}
+
+func TestEmissionScopeCollectsAllErrors(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	errFirst := errors.New("first failure")
+	errSecond := errors.New("second failure")
+	emitter.On("task.first", func(e Event) error { return errFirst })
+	emitter.On("task.second", func(e Event) error { return errSecond })
+
+	scope := NewEmissionScope()
+	emitter.Go(scope, "task.first", nil)
+	emitter.Go(scope, "task.second", nil)
+
+	err := scope.Wait()
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("Wait() error = %v, want both listener errors", err)
+	}
+}
+
+// firstErrorGroup mimics errgroup.Group to show any EmissionGroup can be used.
+type firstErrorGroup struct {
+	done chan error
+}
+
+func (g *firstErrorGroup) Go(fn func() error) {
+	go func() { g.done <- fn() }()
+}
+
+func TestGoWithCustomGroup(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	emitter.On("task.run", func(e Event) error { return nil })
+
+	group := &firstErrorGroup{done: make(chan error, 1)}
+	emitter.Go(group, "task.run", nil)
+
+	select {
+	case err := <-group.done:
+		if err != nil {
+			t.Errorf("Group function returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test timed out waiting for the group function")
+	}
+}
@@ -0,0 +1,104 @@
+package emitter
+
+// ListenerHandle refers to a subscribed listener.
+type ListenerHandle struct {
+	topic    string
+	id       string
+	priority Priority
+	remove   func() error
+}
+
+// Subscribe adds a listener like On, but returns a handle to it rather than its ID, so
+// that callers need not keep the topic and ID together to unsubscribe later:
+//
+//	sub, err := e.Subscribe("order.*", onOrder)
+//	...
+//	defer sub.Unsubscribe()
+func (m *MemoryEmitter) Subscribe(topicName string, listener Listener, opts ...ListenerOption) (*ListenerHandle, error) {
+	var item *listenerItem
+	var priority Priority
+	opts = append(opts[:len(opts):len(opts)], func(added *listenerItem) {
+		item, priority = added, added.priority
+	})
+	id, err := m.On(topicName, listener, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ListenerHandle{topic: topicName, id: id, priority: priority, remove: item.remove}, nil
+}
+
+// Topic returns the topic name or pattern the listener is subscribed to.
+func (h *ListenerHandle) Topic() string {
+	if h == nil {
+		return ""
+	}
+	return h.topic
+}
+
+// ID returns the ID of the listener.
+func (h *ListenerHandle) ID() string {
+	if h == nil {
+		return ""
+	}
+	return h.id
+}
+
+// Priority returns the priority of the listener when the handle was created. Priority
+// decay may lower it afterwards.
+func (h *ListenerHandle) Priority() Priority {
+	if h == nil {
+		return 0
+	}
+	return h.priority
+}
+
+// Unsubscribe removes the listener. Called from inside the listener, the removal takes
+// effect once the current emission finishes. A nil handle returns ErrNoCurrentListener.
+func (h *ListenerHandle) Unsubscribe() error {
+	if h == nil {
+		return ErrNoCurrentListener
+	}
+	return h.remove()
+}
+
+// CurrentSubscription returns the handle of the listener handling evt, so that a
+// listener can unsubscribe itself without capturing its own ID, which is not known yet
+// when the listener is created:
+//
+//	e.On("job.done", func(evt emitter.Event) error {
+//		if finished(evt) {
+//			return emitter.CurrentSubscription(evt).Unsubscribe()
+//		}
+//		return nil
+//	})
+//
+// It returns nil when evt is not being handled by a listener, for example once the
+// listener returned, or for custom Event types passed to EmitEvent.
+func CurrentSubscription(evt Event) *ListenerHandle {
+	var base *BaseEvent
+	switch e := evt.(type) {
+	case *BaseEvent:
+		base = e
+	case *readOnlyEvent:
+		base, _ = e.Event.(*BaseEvent)
+	}
+	if base == nil || base.current == nil {
+		return nil
+	}
+	return &ListenerHandle{topic: base.currentOn.Name, id: base.running, priority: base.current.priority, remove: base.current.remove}
+}
+
+// boundEvent returns a copy of evt bound to the listener currently handling it, for
+// listeners called once the emission moved on to other listeners, so that
+// CurrentSubscription still returns their own handle. Custom events are returned as is.
+func boundEvent(evt Event) Event {
+	switch e := evt.(type) {
+	case *BaseEvent:
+		return e.boundCopy()
+	case *readOnlyEvent:
+		if base, ok := e.Event.(*BaseEvent); ok {
+			return newReadOnlyEvent(base.boundCopy())
+		}
+	}
+	return evt
+}
@@ -0,0 +1,95 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurrentSubscriptionUnsubscribe(t *testing.T) {
+	e := NewMemoryEmitter(WithLifecycleEvents())
+	var removed []string
+	e.On(TopicListenerRemoved, func(evt Event) error {
+		removed = append(removed, evt.Payload().(*LifecycleEvent).ListenerID)
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		opts []ListenerOption
+	}{
+		{"plain", nil},
+		{"read-only", []ListenerOption{WithReadOnlyEvent()}},
+		{"cloned", []ListenerOption{WithClonedEvent()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removed = nil
+			calls := 0
+			var handle *ListenerHandle
+			id, _ := e.On("job.*", func(evt Event) error {
+				calls++
+				handle = CurrentSubscription(evt)
+				return handle.Unsubscribe()
+			}, tt.opts...)
+
+			if errs := e.EmitSync("job.done"); len(errs) != 0 {
+				t.Fatalf("EmitSync() errors = %v", errs)
+			}
+			e.EmitSync("job.done")
+
+			if calls != 1 {
+				t.Errorf("Listener called %d times, want 1", calls)
+			}
+			if handle.ID() != id || handle.Topic() != "job.*" {
+				t.Errorf("Handle = %s on %s, want %s on job.*", handle.ID(), handle.Topic(), id)
+			}
+			if len(removed) != 1 || removed[0] != id {
+				t.Errorf("Removal events = %v, want [%s]", removed, id)
+			}
+		})
+	}
+}
+
+func TestCurrentSubscriptionOutsideListener(t *testing.T) {
+	evt := NewBaseEvent("job.done", nil)
+	if handle := CurrentSubscription(evt); handle != nil {
+		t.Errorf("CurrentSubscription() = %+v outside a listener, want nil", handle)
+	}
+	if err := CurrentSubscription(evt).Unsubscribe(); !errors.Is(err, ErrNoCurrentListener) {
+		t.Errorf("Unsubscribe() error = %v, want %v", err, ErrNoCurrentListener)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	e := NewMemoryEmitter()
+	var calls int
+	sub, err := e.Subscribe("order.*", func(Event) error {
+		calls++
+		return nil
+	}, WithPriority(High))
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if sub.Topic() != "order.*" || sub.ID() == "" || sub.Priority() != High {
+		t.Errorf("handle = (%q, %q, %v), want (%q, an ID, %v)", sub.Topic(), sub.ID(), sub.Priority(), "order.*", High)
+	}
+
+	e.EmitSync("order.created")
+	if err := sub.Unsubscribe(); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	e.EmitSync("order.created")
+	if calls != 1 {
+		t.Errorf("listener called %d times, want 1", calls)
+	}
+	if err := sub.Unsubscribe(); !errors.Is(err, ErrListenerNotFound) {
+		t.Errorf("second Unsubscribe() error = %v, want %v", err, ErrListenerNotFound)
+	}
+
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := e.Subscribe("order.*", func(Event) error { return nil }); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("Subscribe() after Close error = %v, want %v", err, ErrEmitterClosed)
+	}
+}
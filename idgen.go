@@ -0,0 +1,199 @@
+package emitter
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IDGenerator produces unique identifiers for listeners and events. The generators in
+// this package can be plugged into the emitter through their NewID method, e.g.
+// WithIDGenerator(gen.NewID) or WithEventIDGenerator(gen.NewID).
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc adapts an ordinary function to the IDGenerator interface.
+type IDGeneratorFunc func() string
+
+// NewID calls f.
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// SequentialIDGenerator produces monotonically increasing IDs such as "evt-1", "evt-2".
+// Its output is deterministic, which makes it convenient in tests.
+type SequentialIDGenerator struct {
+	prefix  string
+	counter atomic.Uint64
+}
+
+// NewSequentialIDGenerator creates a SequentialIDGenerator whose IDs start with prefix.
+func NewSequentialIDGenerator(prefix string) *SequentialIDGenerator {
+	return &SequentialIDGenerator{prefix: prefix}
+}
+
+// NewID returns the next ID in the sequence.
+func (g *SequentialIDGenerator) NewID() string {
+	return g.prefix + strconv.FormatUint(g.counter.Add(1), 10)
+}
+
+// crockfordAlphabet is the Base32 alphabet used by ULIDs.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator produces lexicographically sortable ULIDs. IDs generated within the same
+// millisecond increment the random component, so they stay strictly ordered; once the
+// random component cannot be incremented any further, generating another ID in that
+// millisecond fails with ErrULIDOverflow.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	lastMS  uint64
+	entropy [10]byte
+}
+
+// NewULIDGenerator creates a ULIDGenerator.
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{now: time.Now}
+}
+
+// NewID returns a new 26 character ULID. It panics when the ULID cannot be generated.
+func (g *ULIDGenerator) NewID() string {
+	id, err := g.Next()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Next returns a new 26 character ULID.
+func (g *ULIDGenerator) Next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.now().UnixMilli())
+	if ms > g.lastMS {
+		if _, err := rand.Read(g.entropy[:]); err != nil {
+			return "", err
+		}
+		g.lastMS = ms
+	} else if !incrementEntropy(&g.entropy) {
+		// Same (or earlier) millisecond, whose IDs are used up.
+		return "", fmt.Errorf("%w: more than 2^80 IDs in millisecond %d", ErrULIDOverflow, g.lastMS)
+	}
+
+	var raw [16]byte
+	raw[0] = byte(g.lastMS >> 40)
+	raw[1] = byte(g.lastMS >> 32)
+	raw[2] = byte(g.lastMS >> 24)
+	raw[3] = byte(g.lastMS >> 16)
+	raw[4] = byte(g.lastMS >> 8)
+	raw[5] = byte(g.lastMS)
+	copy(raw[6:], g.entropy[:])
+	return encodeULID(raw), nil
+}
+
+// incrementEntropy increments the random component of a ULID to keep IDs generated
+// within the same millisecond monotonic. It leaves the entropy unchanged and reports
+// false when the entropy is already at its maximum.
+func incrementEntropy(entropy *[10]byte) bool {
+	next := *entropy
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			*entropy = next
+			return true
+		}
+	}
+	return false
+}
+
+// encodeULID encodes 128 bits as 26 Crockford Base32 characters.
+func encodeULID(raw [16]byte) string {
+	hi := binary.BigEndian.Uint64(raw[:8])
+	lo := binary.BigEndian.Uint64(raw[8:])
+
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// Snowflake layout: 41 bits of milliseconds since the epoch, 10 bits of node ID and
+// 12 bits of per-millisecond sequence.
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxNode      = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+// SnowflakeEpoch is the reference time of snowflake IDs (2024-01-01T00:00:00Z).
+var SnowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SnowflakeIDGenerator produces roughly time-ordered 63-bit IDs that are unique across
+// up to 1024 nodes, each generating up to 4096 IDs per millisecond.
+type SnowflakeIDGenerator struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	node     int64
+	lastMS   int64
+	sequence int64
+}
+
+// NewSnowflakeIDGenerator creates a SnowflakeIDGenerator for the given node ID, which
+// must be between 0 and 1023.
+func NewSnowflakeIDGenerator(node int64) (*SnowflakeIDGenerator, error) {
+	if node < 0 || node > snowflakeMaxNode {
+		return nil, fmt.Errorf("%w: must be between 0 and %d, got %d", ErrInvalidNodeID, snowflakeMaxNode, node)
+	}
+	return &SnowflakeIDGenerator{now: time.Now, node: node}, nil
+}
+
+// NewID returns the next snowflake ID in decimal form.
+func (g *SnowflakeIDGenerator) NewID() string {
+	id, err := g.Next()
+	if err != nil {
+		panic(err)
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+// Next returns the next snowflake ID. When the sequence of the current millisecond is
+// exhausted it waits for the next millisecond, without blocking the other callers.
+func (g *SnowflakeIDGenerator) Next() (int64, error) {
+	g.mu.Lock()
+	for {
+		now := g.now()
+		ms := now.Sub(SnowflakeEpoch).Milliseconds()
+		if ms < 0 {
+			g.mu.Unlock()
+			return 0, fmt.Errorf("%w: %v", ErrClockBeforeEpoch, now)
+		}
+		if ms < g.lastMS {
+			ms = g.lastMS // Clock moved backwards; keep IDs monotonic.
+		}
+
+		switch {
+		case ms > g.lastMS:
+			g.lastMS, g.sequence = ms, 0
+		case g.sequence < snowflakeMaxSequence:
+			g.sequence++
+		default:
+			g.mu.Unlock()
+			time.Sleep(time.Millisecond / 10)
+			g.mu.Lock()
+			continue
+		}
+		id := ms<<(snowflakeNodeBits+snowflakeSequenceBits) | g.node<<snowflakeSequenceBits | g.sequence
+		g.mu.Unlock()
+		return id, nil
+	}
+}
@@ -0,0 +1,148 @@
+package emitter
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEncodeULID(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  [16]byte
+		want string
+	}{
+		{"zero", [16]byte{}, "00000000000000000000000000"},
+		{"one", [16]byte{15: 1}, "00000000000000000000000001"},
+		{"max", [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeULID(tt.raw); got != tt.want {
+				t.Errorf("encodeULID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestULIDGeneratorMonotonic(t *testing.T) {
+	ms := int64(1_700_000_000_000)
+	g := NewULIDGenerator()
+	g.now = func() time.Time { return time.UnixMilli(ms) }
+
+	prev := g.NewID()
+	if len(prev) != 26 || !strings.HasPrefix(prev, "01HF7YAT00") {
+		t.Fatalf("NewID() = %q, want 26 characters starting with the encoded time", prev)
+	}
+	for i := 0; i < 1000; i++ {
+		if i == 500 {
+			ms -= 10 // The clock moving backwards keeps IDs ordered.
+		}
+		id := g.NewID()
+		if id <= prev {
+			t.Fatalf("NewID() = %q after %q, want strictly increasing IDs", id, prev)
+		}
+		prev = id
+	}
+
+	ms += 100
+	if id := g.NewID(); id <= prev || strings.HasPrefix(id, prev[:10]) {
+		t.Errorf("NewID() = %q after %q, want a later time component", id, prev)
+	}
+}
+
+func TestULIDGeneratorOverflow(t *testing.T) {
+	g := NewULIDGenerator()
+	g.now = func() time.Time { return time.UnixMilli(1_700_000_000_000) }
+	if _, err := g.Next(); err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+
+	g.entropy = [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}
+	last, err := g.Next()
+	if err != nil || !strings.HasSuffix(last, "ZZZZZZZZZZZZZZZZ") {
+		t.Fatalf("Next() = %q, %v, want the last ID of the millisecond", last, err)
+	}
+	if _, err := g.Next(); !errors.Is(err, ErrULIDOverflow) {
+		t.Errorf("Next() error = %v, want %v", err, ErrULIDOverflow)
+	}
+}
+
+func TestSnowflakeIDGeneratorNodeID(t *testing.T) {
+	for _, node := range []int64{-1, snowflakeMaxNode + 1} {
+		if _, err := NewSnowflakeIDGenerator(node); !errors.Is(err, ErrInvalidNodeID) {
+			t.Errorf("NewSnowflakeIDGenerator(%d) error = %v, want %v", node, err, ErrInvalidNodeID)
+		}
+	}
+	for _, node := range []int64{0, snowflakeMaxNode} {
+		g, err := NewSnowflakeIDGenerator(node)
+		if err != nil {
+			t.Fatalf("NewSnowflakeIDGenerator(%d) error = %v", node, err)
+		}
+		id, _ := g.Next()
+		if got := id >> snowflakeSequenceBits & snowflakeMaxNode; got != node {
+			t.Errorf("ID %d carries node %d, want %d", id, got, node)
+		}
+	}
+}
+
+func TestSnowflakeIDGeneratorClock(t *testing.T) {
+	var ms atomic.Int64
+	ms.Store(10)
+	g, _ := NewSnowflakeIDGenerator(1)
+	g.now = func() time.Time { return SnowflakeEpoch.Add(time.Duration(ms.Load()) * time.Millisecond) }
+	timeOf := func(id int64) int64 { return id >> (snowflakeNodeBits + snowflakeSequenceBits) }
+
+	first, _ := g.Next()
+	ms.Store(5) // The clock moves backwards.
+	second, _ := g.Next()
+	if second <= first || timeOf(second) != 10 {
+		t.Errorf("Next() = %d after %d, want a later ID of millisecond 10", second, first)
+	}
+
+	ms.Store(-1)
+	if _, err := g.Next(); !errors.Is(err, ErrClockBeforeEpoch) {
+		t.Errorf("Next() error = %v, want %v", err, ErrClockBeforeEpoch)
+	}
+}
+
+func TestSnowflakeIDGeneratorSequenceRollover(t *testing.T) {
+	var ms atomic.Int64
+	ms.Store(10)
+	g, _ := NewSnowflakeIDGenerator(1)
+	g.now = func() time.Time { return SnowflakeEpoch.Add(time.Duration(ms.Load()) * time.Millisecond) }
+
+	var prev int64
+	for i := 0; i <= snowflakeMaxSequence; i++ {
+		id, _ := g.Next()
+		if id <= prev {
+			t.Fatalf("Next() = %d after %d, want strictly increasing IDs", id, prev)
+		}
+		prev = id
+	}
+
+	next := make(chan int64, 1)
+	go func() {
+		id, _ := g.Next()
+		next <- id
+	}()
+	select {
+	case id := <-next:
+		t.Fatalf("Next() = %d with the sequence exhausted, want it to wait for the next millisecond", id)
+	case <-time.After(20 * time.Millisecond):
+	}
+	// The waiting call does not hold the generator.
+	withinTimeout(t, func() {
+		g.mu.Lock()
+		g.mu.Unlock()
+	})
+
+	ms.Store(11)
+	withinTimeout(t, func() {
+		if id := <-next; id != 11<<(snowflakeNodeBits+snowflakeSequenceBits)|1<<snowflakeSequenceBits {
+			t.Errorf("Next() = %d, want the first ID of millisecond 11", id)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package emitter
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ListenerInfo describes a registered listener.
+type ListenerInfo struct {
+	Route
+	Source string `json:"source,omitempty"` // File and line that registered the listener, recorded with WithListenerSources.
+}
+
+// Listeners describes every registered listener, ordered by pattern and then by
+// priority. Source locations are only known for listeners registered while the emitter
+// was configured with WithListenerSources.
+func (m *MemoryEmitter) Listeners() []ListenerInfo {
+	infos := []ListenerInfo{}
+	m.eachListener(func(id, pattern string, item *listenerItem) {
+		infos = append(infos, ListenerInfo{Route: item.route(id, pattern), Source: item.source})
+	})
+	return infos
+}
+
+// packageDir is the directory of the emitter package's source files.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerSource returns the file and line of the first caller outside the emitter
+// package, so that listeners registered through helpers such as ImportRoutes or
+// Attachable are attributed to the code using them. Test files of the package count as
+// callers.
+func callerSource() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package emitter
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+func TestListenerSources(t *testing.T) {
+	noop := func(Event) error { return nil }
+
+	e := NewMemoryEmitter(WithListenerSources())
+	_, file, line, _ := runtime.Caller(0)
+	e.On("order.created", noop, WithPriority(High))
+	handlers := NewHandlerRegistry()
+	handlers.Register("audit", noop)
+	e.ImportRoutes(RoutingTable{Routes: []Route{{Pattern: "order.*", Handler: "audit"}}}, handlers)
+
+	infos := e.Listeners()
+	if len(infos) != 2 {
+		t.Fatalf("Listeners() returned %d listeners, want 2", len(infos))
+	}
+	want := map[string]string{
+		"order.created": filepath.Base(file) + ":" + strconv.Itoa(line+1),
+		"order.*":       filepath.Base(file) + ":" + strconv.Itoa(line+4), // Attributed to the ImportRoutes call.
+	}
+	for _, info := range infos {
+		if got := filepath.Base(info.Source); got != want[info.Pattern] {
+			t.Errorf("Source of %s = %q, want %q", info.Pattern, got, want[info.Pattern])
+		}
+	}
+	if infos[1].Priority != High || infos[1].ID == "" {
+		t.Errorf("Listener info = %+v, want ID and high priority", infos[1])
+	}
+
+	plain := NewMemoryEmitter()
+	plain.On("order.created", noop)
+	if source := plain.Listeners()[0].Source; source != "" {
+		t.Errorf("Source without WithListenerSources = %q, want empty", source)
+	}
+}
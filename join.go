@@ -0,0 +1,131 @@
+package emitter
+
+import (
+	"sync"
+	"time"
+)
+
+// JoinResult holds the events gathered by a join registered with OnJoin.
+type JoinResult struct {
+	Events  map[string]Event // Read-only views of the first event received on each topic, keyed as given to OnJoin.
+	Missing []string         // Topics without an event when the window closed, in the order given to OnJoin.
+}
+
+// JoinOption configures a join registered with OnJoin.
+type JoinOption func(*joinConfig)
+
+// joinConfig holds the settings of a join.
+type joinConfig struct {
+	onTimeout func(JoinResult)
+}
+
+// WithJoinTimeout calls onTimeout with the partial result of a join whose window closed
+// before an event was received on each of its topics, instead of dropping it. The
+// result lists the topics that received no event in Missing. onTimeout runs on a timer
+// goroutine.
+func WithJoinTimeout(onTimeout func(JoinResult)) JoinOption {
+	return func(cfg *joinConfig) {
+		cfg.onTimeout = onTimeout
+	}
+}
+
+// OnJoin combines the events of several topics, which may be patterns. A join opens
+// with the first event received on any of the topics and completes once an event was
+// received on each of them within window, counted from that first event. onJoin is then
+// called with the events, on the goroutine of the emission completing the join, and its
+// error is returned to that emission. Further events on a topic that already received
+// one are ignored until the join completes. A join whose window closes first is
+// dropped, or passed to the WithJoinTimeout callback, and the next event opens a new
+// one.
+//
+// OnJoin returns the IDs of the listeners it registered, in the order of topics, which
+// OffMany removes. If a subscription fails, the others are removed and the error is
+// returned.
+func (m *MemoryEmitter) OnJoin(topics []string, window time.Duration, onJoin func(JoinResult) error, opts ...JoinOption) ([]string, error) {
+	if onJoin == nil {
+		return nil, &SubscriptionError{Op: OpOn, Err: ErrNilListener}
+	}
+	j := &join{emitter: m, topics: topics, window: window, onJoin: onJoin}
+	for _, opt := range opts {
+		opt(&j.cfg)
+	}
+
+	ids := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		topic := topic
+		id, err := m.On(topic, func(evt Event) error {
+			return j.receive(topic, evt)
+		}, WithReadOnlyEvent())
+		if err != nil {
+			_ = m.OffMany(topics[:len(ids)], ids)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// join gathers the events of the topics of an OnJoin call.
+type join struct {
+	emitter *MemoryEmitter
+	topics  []string
+	window  time.Duration
+	onJoin  func(JoinResult) error
+	cfg     joinConfig
+
+	mu     sync.Mutex
+	events map[string]Event // Events of the open join, or nil if none is open.
+	timer  Timer            // Closes the window of the open join.
+	round  uint64           // Number of joins opened so far.
+}
+
+// receive adds the event to the open join, opening one if needed, and calls onJoin once
+// it is complete.
+func (j *join) receive(topic string, evt Event) error {
+	j.mu.Lock()
+	if j.events == nil {
+		j.events = make(map[string]Event, len(j.topics))
+		j.round++
+		round := j.round
+		j.timer = j.emitter.afterFunc(j.window, func() { j.expire(round) })
+	}
+	if _, ok := j.events[topic]; ok {
+		j.mu.Unlock()
+		return nil
+	}
+	j.events[topic] = evt
+	if len(j.events) < len(j.topics) {
+		j.mu.Unlock()
+		return nil
+	}
+	events := j.events
+	j.events = nil
+	j.timer.Stop()
+	j.mu.Unlock()
+
+	return j.onJoin(JoinResult{Events: events})
+}
+
+// expire closes the window of the join opened in the given round, unless it completed
+// already, and passes its partial result to the timeout callback, if any.
+func (j *join) expire(round uint64) {
+	j.mu.Lock()
+	if j.events == nil || j.round != round {
+		j.mu.Unlock()
+		return
+	}
+	events := j.events
+	j.events = nil
+	j.mu.Unlock()
+
+	if j.cfg.onTimeout == nil {
+		return
+	}
+	var missing []string
+	for _, topic := range j.topics {
+		if _, ok := events[topic]; !ok {
+			missing = append(missing, topic)
+		}
+	}
+	j.cfg.onTimeout(JoinResult{Events: events, Missing: missing})
+}
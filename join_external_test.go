@@ -0,0 +1,93 @@
+package emitter_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+func TestOnJoin(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+	defer em.Close()
+
+	errJoin := errors.New("join failed")
+	var joined []map[string]interface{}
+	topics := []string{"order.paid", "order.packed.*"}
+	ids, err := em.OnJoin(topics, time.Minute, func(result emitter.JoinResult) error {
+		payloads := map[string]interface{}{}
+		for topic, evt := range result.Events {
+			payloads[topic] = evt.Payload()
+		}
+		joined = append(joined, payloads)
+		return errJoin
+	})
+	if err != nil {
+		t.Fatalf("OnJoin() error = %v", err)
+	}
+
+	em.EmitSync("order.paid", 1)
+	em.EmitSync("order.paid", 2)
+	clock.Advance(30 * time.Second)
+	errs := em.EmitSync("order.packed.eu", 3)
+	if len(errs) != 1 || !errors.Is(errs[0], errJoin) {
+		t.Errorf("EmitSync() completing the join = %v, want %v", errs, errJoin)
+	}
+
+	want := []map[string]interface{}{{"order.paid": 1, "order.packed.*": 3}}
+	if !reflect.DeepEqual(joined, want) {
+		t.Errorf("joins = %v, want %v", joined, want)
+	}
+
+	if err := em.OffMany(topics, ids); err != nil {
+		t.Fatalf("OffMany() error = %v", err)
+	}
+	em.EmitSync("order.paid", 4)
+	em.EmitSync("order.packed.us", 5)
+	if len(joined) != 1 {
+		t.Errorf("joins = %v after OffMany, want no new join", joined)
+	}
+}
+
+func TestOnJoinTimeout(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+	defer em.Close()
+
+	var partial []emitter.JoinResult
+	joins := 0
+	_, err := em.OnJoin([]string{"a", "b", "c"}, time.Minute, func(emitter.JoinResult) error {
+		joins++
+		return nil
+	}, emitter.WithJoinTimeout(func(result emitter.JoinResult) {
+		partial = append(partial, result)
+	}))
+	if err != nil {
+		t.Fatalf("OnJoin() error = %v", err)
+	}
+
+	em.EmitSync("b", "late")
+	clock.Advance(time.Minute)
+	if len(partial) != 1 {
+		t.Fatalf("timeout callback called %d times, want 1", len(partial))
+	}
+	if got := partial[0].Events["b"].Payload(); got != "late" || len(partial[0].Events) != 1 {
+		t.Errorf("partial events = %v, want only b", partial[0].Events)
+	}
+	if want := []string{"a", "c"}; !reflect.DeepEqual(partial[0].Missing, want) {
+		t.Errorf("Missing = %v, want %v", partial[0].Missing, want)
+	}
+
+	// The next event opens a new join.
+	em.EmitSync("a")
+	em.EmitSync("b")
+	em.EmitSync("c")
+	clock.Advance(time.Minute)
+	if joins != 1 || len(partial) != 1 {
+		t.Errorf("joins = %d, timeouts = %d, want one complete join and no new timeout", joins, len(partial))
+	}
+}
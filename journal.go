@@ -0,0 +1,68 @@
+package emitter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// maxJournalLine bounds the size of a single journal entry read by ReadJournal.
+const maxJournalLine = 16 << 20
+
+// Journal appends events to a writer as newline-delimited JSON envelopes, the format
+// read by ReadJournal and the emitterjournal command.
+type Journal struct {
+	mu    sync.Mutex
+	w     io.Writer
+	codec Codec
+}
+
+// NewJournal creates a Journal writing to w with payloads encoded by JSONCodec.
+func NewJournal(w io.Writer) *Journal {
+	return &Journal{w: w, codec: JSONCodec{}}
+}
+
+// Append writes the event to the journal.
+func (j *Journal) Append(evt Event) error {
+	env, err := NewEnvelope(evt, j.codec)
+	if err != nil {
+		return err
+	}
+	line, err := env.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, err = j.w.Write(append(line, '\n'))
+	return err
+}
+
+// Listener returns a listener appending every event it receives to the journal. Write
+// errors are returned to the emitter like any listener error.
+func (j *Journal) Listener() Listener {
+	return j.Append
+}
+
+// ReadJournal reads the envelopes of a journal in order and calls fn for each of them.
+// It stops at the first error returned by fn.
+func ReadJournal(r io.Reader, fn func(*Envelope) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJournalLine)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var env Envelope
+		if err := json.Unmarshal(scanner.Bytes(), &env); err != nil {
+			return fmt.Errorf("journal line %d: %w", line, err)
+		}
+		if err := fn(&env); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,51 @@
+package emitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJournalRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	journal := NewJournal(&buf)
+
+	emitter := NewMemoryEmitter()
+	emitter.On("order.*", journal.Listener(), WithReadOnlyEvent())
+	emitter.EmitSync("order.created", map[string]string{"id": "o-1"})
+	emitter.EmitSync("order.paid", "o-1")
+
+	var topics []string
+	var payloads []string
+	err := ReadJournal(&buf, func(env *Envelope) error {
+		topics = append(topics, env.Topic)
+		payloads = append(payloads, string(env.Payload))
+		if env.Timestamp.IsZero() {
+			t.Errorf("Journal entry %s has no timestamp", env.Topic)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadJournal() failed with error: %v", err)
+	}
+
+	if strings.Join(topics, ",") != "order.created,order.paid" {
+		t.Errorf("Journal topics = %v", topics)
+	}
+	if payloads[0] != `{"id":"o-1"}` || payloads[1] != `"o-1"` {
+		t.Errorf("Journal payloads = %v", payloads)
+	}
+}
+
+func TestReadJournalMalformed(t *testing.T) {
+	input := `{"topic":"a","contentType":"application/json","payload":1}` + "\n\nnot json\n"
+
+	err := ReadJournal(strings.NewReader(input), func(*Envelope) error { return nil })
+
+	var syntaxErr *json.SyntaxError
+	if err == nil || !strings.Contains(err.Error(), "journal line 3") || !errors.As(err, &syntaxErr) {
+		t.Errorf("ReadJournal() = %v, want a syntax error on line 3", err)
+	}
+}
@@ -0,0 +1,44 @@
+package emitter
+
+import "context"
+
+// Reserved topics on which an emitter configured with WithLifecycleEvents reports changes
+// to its own state. Their payload is a *LifecycleEvent.
+const (
+	TopicListenerAdded   = "emitter.listener.added"
+	TopicListenerRemoved = "emitter.listener.removed"
+	TopicTopicCreated    = "emitter.topic.created"
+	TopicTopicRemoved    = "emitter.topic.removed"
+	TopicEmitterClosed   = "emitter.closed"
+)
+
+// LifecycleEvent is the payload of the events published on the reserved lifecycle topics.
+type LifecycleEvent struct {
+	Topic      string // Topic or pattern the change applies to; empty for TopicEmitterClosed.
+	ListenerID string // ID of the added or removed listener, if any.
+}
+
+// isLifecycleTopic reports whether name is one of the reserved lifecycle topics.
+func isLifecycleTopic(name string) bool {
+	switch name {
+	case TopicListenerAdded, TopicListenerRemoved, TopicTopicCreated, TopicTopicRemoved, TopicEmitterClosed:
+		return true
+	}
+	return false
+}
+
+// publishLifecycle synchronously emits a lifecycle event when lifecycle events are
+// enabled. Changes to the lifecycle topics themselves are not reported, so subscribing
+// to them does not produce events of its own.
+func (m *MemoryEmitter) publishLifecycle(lifecycleTopic, topic, listenerID string) {
+	if !m.lifecycleEvents || isLifecycleTopic(topic) {
+		return
+	}
+	m.publish(lifecycleTopic, &LifecycleEvent{Topic: topic, ListenerID: listenerID})
+}
+
+// publish synchronously emits an event generated by the emitter itself, discarding the
+// errors of its listeners.
+func (m *MemoryEmitter) publish(topic string, payload interface{}) {
+	m.emitEvent(context.Background(), NewBaseEvent(topic, payload), nil)
+}
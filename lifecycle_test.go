@@ -0,0 +1,58 @@
+package emitter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithLifecycleEvents(t *testing.T) {
+	emitter := NewMemoryEmitter(WithLifecycleEvents())
+
+	var got []string
+	record := func(e Event) error {
+		change := e.Payload().(*LifecycleEvent)
+		got = append(got, e.Topic()+" "+change.Topic)
+		return nil
+	}
+	for _, topic := range []string{TopicListenerAdded, TopicListenerRemoved, TopicTopicCreated, TopicEmitterClosed} {
+		if _, err := emitter.On(topic, record); err != nil {
+			t.Fatalf("On(%q) failed with error: %v", topic, err)
+		}
+	}
+	if len(got) != 0 {
+		t.Fatalf("Subscribing to lifecycle topics produced events: %v", got)
+	}
+
+	id, _ := emitter.On("user.created", func(e Event) error { return nil })
+	emitter.On("user.created", func(e Event) error { return nil })
+	if err := emitter.Off("user.created", id); err != nil {
+		t.Fatalf("Off() failed with error: %v", err)
+	}
+	emitter.Close()
+
+	want := []string{
+		TopicTopicCreated + " user.created",
+		TopicListenerAdded + " user.created",
+		TopicListenerAdded + " user.created",
+		TopicListenerRemoved + " user.created",
+		TopicEmitterClosed + " ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lifecycle events = %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleEventsDisabled(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	called := false
+	emitter.On(TopicListenerAdded, func(e Event) error {
+		called = true
+		return nil
+	})
+	emitter.On("user.created", func(e Event) error { return nil })
+
+	if called {
+		t.Error("Lifecycle events should not be published unless enabled")
+	}
+}
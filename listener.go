@@ -1,14 +1,65 @@
 package emitter
 
+import (
+	"sync/atomic"
+	"time"
+)
+
 // Listener is a function type that can handle events of any type.
 type Listener func(Event) error
 
 // listenerItem stores a listener along with its unique identifier and priority.
 type listenerItem struct {
-	listener Listener
-	priority Priority
+	listener  Listener
+	priority  Priority
+	readOnly  bool                                  // Whether the listener receives a read-only view of events.
+	cloned    bool                                  // Whether the listener receives its own copy of events.
+	canAbort  abortPolicy                           // Whether the listener may abort event propagation.
+	envelope  bool                                  // Whether the listener receives the emitter's *BaseEvent envelope.
+	ttl       time.Duration                         // Time after subscribing at which the listener expires.
+	expireAt  time.Time                             // Time at which the listener expires.
+	onExpire  func(topic string, listenerID string) // Called once the listener expired.
+	expiry    Timer                                 // Pending removal of an expiring listener.
+	filter    func(Event) bool                      // Selects the events the listener is called for, if set.
+	maxCalls  int64                                 // Number of calls after which the listener is removed.
+	calls     atomic.Int64                          // Number of calls claimed by emissions so far.
+	remove    func() error                          // Unsubscribes the listener.
+	handler   string                                // Name of the handler in a HandlerRegistry, if known.
+	group     string                                // Label grouping related listeners in routing tables.
+	source    string                                // File and line of the code that registered the listener, if recorded.
+	errs      *listenerErrors                       // Receives the listener's errors, for OnWithErrors.
+	health    *listenerHealth                       // Tracks failures and slow calls under a DecayPolicy.
+	batch     *batcher                              // Buffers events for a BatchListener, for OnBatch.
+	batchMax  int                                   // Maximum batch size set with WithBatch.
+	batchFor  time.Duration                         // Maximum batch wait set with WithBatch.
+	executor  Executor                              // Runs the listener's calls, if set with WithExecutor.
+	rateLimit float64                               // Calls per second allowed by WithRateLimit, if positive.
+	rateBurst int                                   // Calls allowed at once by WithRateLimit.
+	rateQueue int                                   // Events beyond the rate limit that are queued rather than dropped.
+	removed   atomic.Bool                           // Whether the listener's removal is pending.
+}
+
+// deadline returns the time at which the listener expires, or the zero time if it
+// does not expire. With both a TTL and an expiry time, the earlier one applies.
+func (item *listenerItem) deadline(now time.Time) time.Time {
+	deadline := item.expireAt
+	if item.ttl > 0 {
+		if byTTL := now.Add(item.ttl); deadline.IsZero() || byTTL.Before(deadline) {
+			deadline = byTTL
+		}
+	}
+	return deadline
 }
 
+// abortPolicy records whether a listener was explicitly allowed or denied to abort.
+type abortPolicy int8
+
+const (
+	abortDefault abortPolicy = iota // Allowed unless the topic has designated aborters.
+	abortAllowed
+	abortDenied
+)
+
 type ListenerOption func(*listenerItem)
 
 func WithPriority(priority Priority) ListenerOption {
@@ -16,3 +67,122 @@ func WithPriority(priority Priority) ListenerOption {
 		item.priority = priority
 	}
 }
+
+// WithReadOnlyEvent hands the listener a read-only view of each event: SetPayload and
+// SetAborted are ignored, and the EventMutator methods return ErrReadOnlyEvent. Use it
+// for observers that must not accidentally change shared event state.
+func WithReadOnlyEvent() ListenerOption {
+	return func(item *listenerItem) {
+		item.readOnly = true
+	}
+}
+
+// WithClonedEvent hands the listener its own copy of each event, made with CloneEvent,
+// so that changes to the payload are not seen by other listeners. Aborting the copy
+// still stops the propagation of the original event.
+func WithClonedEvent() ListenerOption {
+	return func(item *listenerItem) {
+		item.cloned = true
+	}
+}
+
+// withEnvelope hands the listener the *BaseEvent envelope of each emission instead of the
+// event given to EmitEvent, so emitters chained together can follow its trail.
+func withEnvelope() ListenerOption {
+	return func(item *listenerItem) {
+		item.envelope = true
+	}
+}
+
+// WithCanAbort sets whether the listener may abort event propagation. Once any listener
+// of a topic is registered with WithCanAbort(true), only such designated listeners may
+// abort that topic's events. Abort attempts by other listeners are undone and reported
+// as ErrAbortNotPermitted errors.
+func WithCanAbort(canAbort bool) ListenerOption {
+	return func(item *listenerItem) {
+		if canAbort {
+			item.canAbort = abortAllowed
+		} else {
+			item.canAbort = abortDenied
+		}
+	}
+}
+
+// WithListenerTTL unsubscribes the listener once the duration has elapsed after it was
+// subscribed with Emitter.On, measured on the emitter's clock.
+func WithListenerTTL(ttl time.Duration) ListenerOption {
+	return func(item *listenerItem) {
+		item.ttl = ttl
+	}
+}
+
+// WithTTL is a shorter name for WithListenerTTL.
+func WithTTL(ttl time.Duration) ListenerOption {
+	return WithListenerTTL(ttl)
+}
+
+// WithExpireAt unsubscribes the listener at the given time on the emitter's clock.
+func WithExpireAt(at time.Time) ListenerOption {
+	return func(item *listenerItem) {
+		item.expireAt = at
+	}
+}
+
+// WithOnExpire registers a callback invoked after an expiring listener was unsubscribed
+// because its TTL or expiry time was reached. It is not called for listeners removed
+// with Off.
+func WithOnExpire(onExpire func(topic string, listenerID string)) ListenerOption {
+	return func(item *listenerItem) {
+		item.onExpire = onExpire
+	}
+}
+
+// WithMaxCalls unsubscribes the listener after it has been called n times. Concurrent
+// emissions never call it more than n times in total.
+func WithMaxCalls(n int) ListenerOption {
+	return func(item *listenerItem) {
+		item.maxCalls = int64(n)
+	}
+}
+
+// WithFilter calls the listener only for the events the predicate accepts. The
+// predicate receives a read-only view of each event before the listener would be
+// called; skipped events do not count towards WithMaxCalls, and the listener cannot
+// abort them.
+func WithFilter(filter func(Event) bool) ListenerOption {
+	return func(item *listenerItem) {
+		item.filter = filter
+	}
+}
+
+// WithHandlerName records the name under which the listener is registered in a
+// HandlerRegistry, so that ExportRoutes can reference it and ImportRoutes can bind it
+// again.
+func WithHandlerName(name string) ListenerOption {
+	return func(item *listenerItem) {
+		item.handler = name
+	}
+}
+
+// WithGroup labels the listener with a group, such as the feature or team it belongs
+// to. Groups are carried in routing tables for configuration tooling to organize routes.
+func WithGroup(group string) ListenerOption {
+	return func(item *listenerItem) {
+		item.group = group
+	}
+}
+
+// withSource records the call site that registered the listener.
+func withSource(source string) ListenerOption {
+	return func(item *listenerItem) {
+		item.source = source
+	}
+}
+
+// withRemove sets how the listener unsubscribes itself once exhausted or through
+// CurrentSubscription, so that emitters can report the removal like any other.
+func withRemove(remove func() error) ListenerOption {
+	return func(item *listenerItem) {
+		item.remove = remove
+	}
+}
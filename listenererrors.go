@@ -0,0 +1,81 @@
+package emitter
+
+import "sync"
+
+// OnWithErrors subscribes a listener like On and also returns a channel receiving every
+// error the listener reports, across all emissions, as an *EmitError. It lets the owner
+// of a listener alert on its failures alone. The errors are still reported to the
+// emitting callers as usual.
+//
+// The channel is buffered with the emitter's error channel buffer size; errors arriving
+// while it is full are dropped rather than blocking emissions. It is closed once the
+// listener is removed, including when the emitter is closed.
+func (m *MemoryEmitter) OnWithErrors(topicName string, listener Listener, opts ...ListenerOption) (string, <-chan error, error) {
+	stream := &listenerErrors{ch: make(chan error, m.errChanBufferSize)}
+	opts = append(opts[:len(opts):len(opts)], withErrorStream(stream))
+	id, err := m.On(topicName, listener, opts...)
+	if err != nil {
+		stream.close()
+		return "", stream.ch, err
+	}
+	return id, stream.ch, nil
+}
+
+// closeErrorStreams closes the error streams of the topic's listeners, once the topic
+// was dropped by closing its emitter.
+func (t *Topic) closeErrorStreams() {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, item := range t.listeners {
+		if item.errs != nil {
+			item.errs.close()
+		}
+	}
+}
+
+// withErrorStream sends the errors reported by the listener to stream.
+func withErrorStream(stream *listenerErrors) ListenerOption {
+	return func(item *listenerItem) {
+		item.errs = stream
+	}
+}
+
+// emitError wraps an error reported by the listener while notifying pattern, and sends
+// it to the listener's error stream, if any.
+func (item *listenerItem) emitError(event Event, pattern, id string, err error) error {
+	emitErr := &EmitError{Op: OpNotify, Topic: event.Topic(), Pattern: pattern, ListenerID: id, EventID: eventID(event), Err: err}
+	if item.errs != nil {
+		item.errs.send(emitErr)
+	}
+	return emitErr
+}
+
+// listenerErrors is the error stream of a listener subscribed with OnWithErrors.
+type listenerErrors struct {
+	mu     sync.Mutex
+	ch     chan error
+	closed bool
+}
+
+// send delivers err unless the stream is full or closed.
+func (s *listenerErrors) send(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.ch <- err:
+	default:
+	}
+}
+
+// close closes the stream's channel once.
+func (s *listenerErrors) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
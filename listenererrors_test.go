@@ -0,0 +1,76 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOnWithErrors(t *testing.T) {
+	e := NewMemoryEmitter()
+	errRejected := errors.New("rejected")
+	id, errs, err := e.OnWithErrors("order.*", func(evt Event) error {
+		if evt.Topic() == "order.failed" {
+			return errRejected
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.On("order.*", func(evt Event) error { return errors.New("other listener") })
+
+	e.EmitSync("order.created")
+	e.EmitSync("order.failed")
+	<-e.Emit("order.failed")
+
+	for i := 0; i < 2; i++ {
+		got := <-errs
+		var emitErr *EmitError
+		if !errors.As(got, &emitErr) || emitErr.ListenerID != id || emitErr.Topic != "order.failed" || !errors.Is(got, errRejected) {
+			t.Errorf("error %d = %v, want the listener's rejection", i, got)
+		}
+	}
+	select {
+	case got := <-errs:
+		t.Errorf("unexpected error %v", got)
+	default:
+	}
+
+	if err := e.Off("order.*", id); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-errs; ok {
+		t.Error("error channel still open after Off")
+	}
+}
+
+func TestOnWithErrorsDropsWhenFull(t *testing.T) {
+	e := NewMemoryEmitter(WithErrChanBufferSize(1))
+	_, errs, _ := e.OnWithErrors("job", func(evt Event) error { return errors.New("failed") })
+
+	for i := 0; i < 3; i++ {
+		if got := e.EmitSync("job"); len(got) != 1 {
+			t.Fatalf("EmitSync returned %v, want the listener error", got)
+		}
+	}
+	if len(errs) != 1 {
+		t.Errorf("channel holds %d errors, want 1", len(errs))
+	}
+
+	e.Close()
+	<-errs
+	if _, ok := <-errs; ok {
+		t.Error("error channel still open after Close")
+	}
+}
+
+func TestOnWithErrorsInvalid(t *testing.T) {
+	e := NewMemoryEmitter()
+	_, errs, err := e.OnWithErrors("job", nil)
+	if !errors.Is(err, ErrNilListener) {
+		t.Errorf("error = %v, want ErrNilListener", err)
+	}
+	if _, ok := <-errs; ok {
+		t.Error("error channel open for a failed subscription")
+	}
+}
@@ -0,0 +1,107 @@
+package emitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// LogFormat selects how a log listener renders event payloads.
+type LogFormat int
+
+const (
+	LogFormatText LogFormat = iota // Payloads rendered with fmt's %+v verb.
+	LogFormatJSON                  // Payloads rendered as JSON.
+)
+
+// LogOption configures a listener created with NewLogListener.
+type LogOption func(*logConfig)
+
+// logConfig holds the settings of a log listener.
+type logConfig struct {
+	payloadLimit int // Maximum rendered payload length in bytes, if positive.
+	sampleEvery  int // Logs one out of every sampleEvery events, if greater than one.
+}
+
+// WithLogPayloadLimit truncates rendered payloads to at most limit bytes.
+func WithLogPayloadLimit(limit int) LogOption {
+	return func(cfg *logConfig) {
+		cfg.payloadLimit = limit
+	}
+}
+
+// WithLogSampling logs only one out of every n events, starting with the first.
+func WithLogSampling(n int) LogOption {
+	return func(cfg *logConfig) {
+		cfg.sampleEvery = n
+	}
+}
+
+// NewLogListener returns a listener that logs every event it receives at the given
+// level, with its topic, ID and payload. It is meant as a quick debugging tap:
+//
+//	e.On("**", emitter.NewLogListener(nil, slog.LevelDebug, emitter.LogFormatJSON,
+//		emitter.WithLogPayloadLimit(256)), emitter.WithReadOnlyEvent())
+//
+// A nil logger logs to slog.Default(). The listener never returns an error.
+func NewLogListener(logger *slog.Logger, level slog.Level, format LogFormat, opts ...LogOption) Listener {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	var cfg logConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var seen atomic.Uint64
+	return func(evt Event) error {
+		if cfg.sampleEvery > 1 && (seen.Add(1)-1)%uint64(cfg.sampleEvery) != 0 {
+			return nil
+		}
+
+		ctx := eventContext(evt)
+		if !logger.Enabled(ctx, level) {
+			return nil
+		}
+
+		attrs := []slog.Attr{slog.String("topic", evt.Topic())}
+		if identified, ok := evt.(interface{ ID() string }); ok && identified.ID() != "" {
+			attrs = append(attrs, slog.String("id", identified.ID()))
+		}
+		attrs = append(attrs, slog.String("payload", truncatePayload(renderPayload(evt, format), cfg.payloadLimit)))
+		logger.LogAttrs(ctx, level, "event", attrs...)
+		return nil
+	}
+}
+
+// renderPayload renders the payload of evt, or all its arguments if it has several.
+func renderPayload(evt Event, format LogFormat) string {
+	var value interface{} = evt.Payload()
+	if args := eventArgs(evt); len(args) > 1 {
+		value = args
+	}
+
+	if format == LogFormatJSON {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("!json(%v)", err)
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("%+v", value)
+}
+
+// truncatePayload shortens s to at most limit bytes without splitting a UTF-8 sequence,
+// marking the cut with an ellipsis.
+func truncatePayload(s string, limit int) string {
+	if limit <= 0 || len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…"
+}
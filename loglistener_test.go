@@ -0,0 +1,65 @@
+package emitter
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestNewLogListener(t *testing.T) {
+	var buf bytes.Buffer
+	emitter := NewMemoryEmitter(WithEventIDGenerator(func() string { return "evt-1" }))
+	emitter.On("**", NewLogListener(newTestLogger(&buf), slog.LevelDebug, LogFormatJSON))
+
+	emitter.EmitSync("order.created", map[string]int{"total": 42})
+
+	want := `level=DEBUG msg=event topic=order.created id=evt-1 payload="{\"total\":42}"` + "\n"
+	if buf.String() != want {
+		t.Errorf("Logged %q, want %q", buf.String(), want)
+	}
+}
+
+func TestLogListenerTruncationAndSampling(t *testing.T) {
+	var buf bytes.Buffer
+	listener := NewLogListener(newTestLogger(&buf), slog.LevelInfo, LogFormatText,
+		WithLogPayloadLimit(5), WithLogSampling(2))
+
+	for _, payload := range []string{"héllo world", "skipped", "third"} {
+		listener(NewBaseEvent("greeting", payload))
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Logged %d lines, want 2: %q", len(lines), buf.String())
+	}
+	if !strings.HasSuffix(lines[0], "payload=héll…") {
+		t.Errorf("First line = %q, want the payload truncated to 5 bytes", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "payload=third") {
+		t.Errorf("Second line = %q, want the third event", lines[1])
+	}
+}
+
+func TestLogListenerDisabledLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	NewLogListener(logger, slog.LevelDebug, LogFormatText)(NewBaseEvent("topic", "payload"))
+
+	if buf.Len() != 0 {
+		t.Errorf("Logged %q below the logger's level", buf.String())
+	}
+}
@@ -1,33 +1,77 @@
 package emitter
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
+	"runtime/pprof"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// closedErrChan is returned by Emit when errors are silenced. It is closed and empty, so
+// callers ranging over it return immediately without a channel being allocated per emission.
+var closedErrChan = func() chan error {
+	ch := make(chan error)
+	close(ch)
+	return ch
+}()
+
 // MemoryEmitter is an in-memory implementation of the Emitter interface. It provides
 // facilities for adding and removing listeners, emitting events, and configuring
 // the behavior of event handling within the application.
 type MemoryEmitter struct {
-	topics            sync.Map                 // Stores topics with concurrent access support.
-	errorHandler      func(Event, error) error // Handles errors that occur during event handling.
-	idGenerator       func() string            // Generates unique IDs for listeners.
-	panicHandler      PanicHandler             // Handles panics that occur during event handling.
-	Pool              Pool                     // Manages concurrent execution of event handlers.
-	closed            atomic.Value             // Indicates whether the emitter is closed.
-	errChanBufferSize int                      // Size of the buffer for the error channel in Emit.
+	topics              *topicRegistry                  // Stores topics in a copy-on-write registry for lock-free reads.
+	errorHandler        func(Event, error) error        // Handles errors that occur during event handling.
+	idGenerator         func() string                   // Generates unique IDs for listeners.
+	eventIDGenerator    func() string                   // Generates unique IDs for events, if set.
+	panicHandler        PanicHandler                    // Handles panics that occur during event handling.
+	panicPolicy         PanicPolicy                     // Decides how emissions continue after a listener panicked.
+	Pool                Pool                            // Manages concurrent execution of event handlers.
+	poolFactory         func() Pool                     // Creates the pool, for emitters made with NewMemoryEmitterFrom.
+	closed              atomic.Value                    // Indicates whether the emitter is closed.
+	closeMu             sync.RWMutex                    // Orders subscriptions before or after Close.
+	errChanBufferSize   int                             // Size of the buffer for the error channel in Emit.
+	propagate           func(*BaseEvent, func(error))   // Forwards dispatched events to related emitters.
+	emissionTimeout     time.Duration                   // Maximum duration of a single emission, if positive.
+	listenerBudget      int                             // Maximum listeners notified per emission, if positive.
+	profilerLabels      bool                            // Whether asynchronous emissions run with pprof labels.
+	decay               *DecayPolicy                    // Policy demoting failing or slow listeners, if any.
+	wildcardLimit       int                             // Maximum number of wildcard patterns On accepts, if positive.
+	wildcardWarnCount   int                             // Wildcard pattern count above which wildcardWarnHandler is called.
+	wildcardWarnHandler func(pattern string, count int) // Reports wildcard patterns beyond wildcardWarnCount.
+	chainWarnLength     int                             // Listener count above which chainWarnHandler is called.
+	chainWarnHandler    func(topic string, length int)  // Reports listener chains that grew too long.
+	inflight            inflightTracker                 // Tracks queued and running asynchronous emissions.
+	background          backgroundWork                  // Tracks timers and other background work stopped by Close.
+	pauses              topicPauses                     // Topics paused with PauseTopic.
+	suppressions        []*suppression                  // Topics dropping unchanged payloads, set with SetSuppressUnchanged.
+	retained            retainedEvents                  // Events emitted with WithRetained.
+	middleware          emitMiddleware                  // Middleware installed with Use.
+	anyTopic            atomic.Pointer[Topic]           // Listeners added with OnAny, once there were any.
+	schedules           scheduledEmissions              // Emissions persisted to the ScheduleStore.
+	topicConfigs        topicConfigs                    // Delivery configurations set with SetTopicConfig.
+	forwarder           Forwarder                       // Carries dispatched events to another system, if set.
+	clock               Clock                           // Provides the current time and schedules delayed work.
+	silentErrors        bool                            // Discards listener errors instead of reporting them to callers.
+	errorTopic          string                          // Topic unhandled listener errors are published on, if set.
+	panicTopic          string                          // Topic recovered panics are published on, if set.
+	lifecycleEvents     bool                            // Publishes events on the reserved lifecycle topics.
+	listenerSources     bool                            // Records the call site registering each listener.
 }
 
 // NewMemoryEmitter initializes a new MemoryEmitter with optional configuration options.
 // Default configurations are applied, which can be overridden by the provided options.
 func NewMemoryEmitter(opts ...EmitterOption) *MemoryEmitter {
 	m := &MemoryEmitter{
-		topics:            sync.Map{},
+		topics:            newTopicRegistry(),
 		errorHandler:      DefaultErrorHandler,
 		idGenerator:       DefaultIDGenerator,
 		panicHandler:      DefaultPanicHandler,
 		errChanBufferSize: 10,
+		clock:             RealClock(),
 	}
 
 	m.closed.Store(false)
@@ -44,117 +88,705 @@ func NewMemoryEmitter(opts ...EmitterOption) *MemoryEmitter {
 // to configure the listener's behavior. It returns a unique ID for the listener and an error, if any.
 func (m *MemoryEmitter) On(topicName string, listener Listener, opts ...ListenerOption) (string, error) {
 	if listener == nil {
-		return "", ErrNilListener
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: ErrNilListener}
 	}
 
 	if !isValidTopicName(topicName) {
-		return "", ErrInvalidTopicName
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: ErrInvalidTopicName}
+	}
+
+	var stream *snapshotStream
+	if m.retained.used.Load() {
+		// Hold back the events emitted while retained events are delivered.
+		stream = &snapshotStream{listener: listener}
+		listener = stream.receive
 	}
 
-	topic := m.EnsureTopic(topicName)
 	listenerID := m.idGenerator()
-	topic.AddListener(listenerID, listener, opts...)
+	var topic *Topic
+	opts = m.subscriptionOptions(topicName, listenerID, func() error {
+		return m.Off(topicName, listenerID)
+	}, func() *Topic { return topic }, opts)
+
+	// Hold off Close until the listener is added, so that On either fails with
+	// ErrEmitterClosed or registers a listener that Close then removes.
+	m.closeMu.RLock()
+	if m.closed.Load().(bool) {
+		m.closeMu.RUnlock()
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: ErrEmitterClosed}
+	}
+	topic, created, err := m.topics.ensureWithin(topicName, m.wildcardLimit)
+	if err != nil {
+		m.closeMu.RUnlock()
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: err}
+	}
+	item := topic.addListener(listenerID, listener, opts...)
+	m.closeMu.RUnlock()
+
+	if created {
+		m.topicCreated(topicName)
+	}
+	if deadline := item.deadline(m.clock.Now()); !deadline.IsZero() {
+		m.scheduleExpiry(topic, topicName, listenerID, item, deadline)
+	}
+
+	if m.chainWarnHandler != nil && m.chainWarnLength > 0 {
+		if length := topic.ListenerCount(); length > m.chainWarnLength {
+			m.chainWarnHandler(topicName, length)
+		}
+	}
+
+	m.publishLifecycle(TopicListenerAdded, topicName, listenerID)
+	if stream != nil {
+		m.deliverRetained(topicName, listenerID, stream)
+	}
 	return listenerID, nil
 }
 
+// subscriptionOptions appends the options the emitter sets on every listener it adds to
+// opts: how the listener unsubscribes itself with remove, its executor hand-off and rate
+// limit, and, if enabled, its source and health tracking. topic returns the topic the
+// listener was added to.
+func (m *MemoryEmitter) subscriptionOptions(topicName, listenerID string, remove func() error, topic func() *Topic, opts []ListenerOption) []ListenerOption {
+	opts = append(opts[:len(opts):len(opts)], withRemove(remove), m.withHandOff(topicName, listenerID), m.withThrottle(topicName, listenerID))
+	if m.listenerSources {
+		opts = append(opts, withSource(callerSource()))
+	}
+	if policy := m.decay; policy != nil {
+		opts = append(opts, withHealth(&listenerHealth{policy: policy, demote: func(reason string) {
+			m.demoteListener(topic(), topicName, listenerID, policy.Floor, reason)
+		}}))
+	}
+	return opts
+}
+
+// scheduleExpiry unsubscribes an expiring listener once its deadline is reached and then
+// invokes its expiry callback, if any.
+func (m *MemoryEmitter) scheduleExpiry(topic *Topic, topicName, listenerID string, item *listenerItem, deadline time.Time) {
+	timer := m.afterFunc(deadline.Sub(m.clock.Now()), func() {
+		if err := item.remove(); err != nil {
+			return // Already removed with Off or by closing the emitter.
+		}
+		if item.onExpire != nil {
+			item.onExpire(topicName, listenerID)
+		}
+	})
+	topic.setExpiry(item, timer)
+}
+
 // Off unsubscribes a listener from a topic using the listener's unique ID. It returns
 // an error if the listener cannot be found or if there is a problem with unsubscribing.
 func (m *MemoryEmitter) Off(topicName string, listenerID string) error {
 	topic, err := m.GetTopic(topicName)
 	if err != nil {
-		return err
+		return &SubscriptionError{Op: OpOff, Topic: topicName, ListenerID: listenerID, Err: err}
+	}
+
+	if err := topic.RemoveListener(listenerID); err != nil {
+		return &SubscriptionError{Op: OpOff, Topic: topicName, ListenerID: listenerID, Err: err}
+	}
+
+	m.publishLifecycle(TopicListenerRemoved, topicName, listenerID)
+	return nil
+}
+
+// OnMany subscribes the listener to each of the topic names or patterns, with the same
+// options, and returns the listener IDs in the same order. Each subscription is
+// independent, so options such as WithMaxCalls count the calls of each topic separately.
+// If a subscription fails, the listener is unsubscribed from the topics it was added to
+// and the error is returned.
+func (m *MemoryEmitter) OnMany(topicNames []string, listener Listener, opts ...ListenerOption) ([]string, error) {
+	ids := make([]string, 0, len(topicNames))
+	for _, topicName := range topicNames {
+		id, err := m.On(topicName, listener, opts...)
+		if err != nil {
+			_ = m.OffMany(topicNames[:len(ids)], ids)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// OffMany unsubscribes the listeners registered with OnMany, taking the topic names and
+// the listener IDs it returned. It removes as many of them as possible and returns the
+// joined errors of those that could not be removed.
+func (m *MemoryEmitter) OffMany(topicNames []string, listenerIDs []string) error {
+	n := len(topicNames)
+	if len(listenerIDs) > n {
+		n = len(listenerIDs)
+	}
+	var errs []error
+	for i := 0; i < n; i++ {
+		var topicName, listenerID string
+		if i < len(topicNames) {
+			topicName = topicNames[i]
+		}
+		if i < len(listenerIDs) {
+			listenerID = listenerIDs[i]
+		}
+		if err := m.Off(topicName, listenerID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OffAll unsubscribes every listener of a topic, which stays registered. Like Off, it
+// takes the exact topic name or pattern the listeners subscribed to.
+func (m *MemoryEmitter) OffAll(topicName string) error {
+	topic, err := m.GetTopic(topicName)
+	if err != nil {
+		return &SubscriptionError{Op: OpOff, Topic: topicName, Err: err}
+	}
+	for _, listenerID := range topic.removeAll() {
+		m.publishLifecycle(TopicListenerRemoved, topicName, listenerID)
+	}
+	return nil
+}
+
+// RemoveTopic unsubscribes every listener of a topic and removes the topic itself, taking
+// the exact topic name or pattern the listeners subscribed to. It returns an error
+// wrapping ErrTopicNotFound if no such topic is registered.
+func (m *MemoryEmitter) RemoveTopic(topicName string) error {
+	// Exclude On while removing, so that no listener is added to the dropped topic.
+	m.closeMu.Lock()
+	topic, ok := m.topics.remove(topicName)
+	m.closeMu.Unlock()
+	if !ok {
+		return &SubscriptionError{Op: OpOff, Topic: topicName, Err: ErrTopicNotFound}
 	}
 
-	return topic.RemoveListener(listenerID)
+	for _, listenerID := range topic.removeAll() {
+		m.publishLifecycle(TopicListenerRemoved, topicName, listenerID)
+	}
+	m.publishLifecycle(TopicTopicRemoved, topicName, "")
+	return nil
+}
+
+// Reset unsubscribes every listener and removes every topic without closing the
+// emitter, which keeps its configuration and accepts new listeners right away.
+func (m *MemoryEmitter) Reset() {
+	// Exclude On while clearing, so that no listener is added to a dropped topic.
+	m.closeMu.Lock()
+	topics := m.topics.clear()
+	m.closeMu.Unlock()
+
+	for _, topic := range topics {
+		for _, listenerID := range topic.removeAll() {
+			m.publishLifecycle(TopicListenerRemoved, topic.Name, listenerID)
+		}
+	}
 }
 
 // Emit asynchronously dispatches an event to all the subscribers of the event's topic.
 // It returns a channel that will receive any errors encountered during event handling.
-func (m *MemoryEmitter) Emit(eventName string, payload interface{}) <-chan error {
-	errChan := make(chan error, m.errChanBufferSize)
+//
+// A single argument becomes the event's payload. With several arguments, listeners read
+// them all with Event.Args or Arg, and Payload returns the first. Arguments that are
+// EmitOption values configure the emission instead.
+func (m *MemoryEmitter) Emit(eventName string, args ...interface{}) <-chan error {
+	return m.EmitWithContext(context.Background(), eventName, args...)
+}
+
+// EmitWithContext behaves like Emit but ties the emission to ctx. Between listeners the
+// emission checks ctx, so canceling it stops the remaining listeners from being notified.
+func (m *MemoryEmitter) EmitWithContext(ctx context.Context, eventName string, args ...interface{}) <-chan error {
+	args, cfg := splitEmitArgs(args)
+	return m.emitAsync(ctx, newArgsEvent(eventName, args), cfg)
+}
+
+// EmitEvent asynchronously dispatches a pre-constructed event, such as one built with
+// NewEvent, keeping its metadata, TTL and priority. The event is emitted with its own
+// context, if it has one. An event must not be emitted more than once.
+func (m *MemoryEmitter) EmitEvent(evt Event, opts ...EmitOption) <-chan error {
+	event := toBaseEvent(evt)
+	return m.emitAsync(event.Context(), event, newEmitConfig(opts))
+}
 
+// emitAsync dispatches the event on the pool and returns the channel receiving its errors.
+func (m *MemoryEmitter) emitAsync(ctx context.Context, event *BaseEvent, cfg emitConfig) <-chan error {
+	return m.emitWith(ctx, event, cfg, func(task func()) error {
+		m.submit(task, event.Priority(), event.Topic())
+		return nil
+	})
+}
+
+// emitWith hands the emission's task to submit and returns the channel receiving its
+// errors. When submit refuses the task, its error is the only one reported.
+func (m *MemoryEmitter) emitWith(ctx context.Context, event *BaseEvent, cfg emitConfig, submit func(task func()) error) <-chan error {
 	// Before starting new goroutine, check if Emitter is closed
 	if m.closed.Load().(bool) {
-		errChan <- ErrEmitterClosed
-		close(errChan)
-		return errChan
+		return m.refuse(event, cfg, ErrEmitterClosed)
+	}
+
+	if m.silentErrors {
+		m.emitDetached(ctx, event, cfg, submit)
+		return closedErrChan
+	}
+
+	errChan := make(chan error, m.errChanBufferSize)
+	m.inflight.add()
+	err := submit(func() {
+		defer m.inflight.done()
+		defer close(errChan)
+		// Recover panics outside the listener chain, such as in event ID generators or
+		// completion callbacks, so that they never escape into the pool's workers.
+		defer m.recoverPanic(event)
+		m.runEmission(ctx, event, errChan, cfg)
+	})
+	if err != nil {
+		m.inflight.done()
+		return m.refuse(event, cfg, err)
+	}
+	return errChan
+}
+
+// EmitDetached asynchronously dispatches an event like Emit, without creating a channel
+// for its errors, which saves an allocation per emission for callers that would ignore
+// it. Errors left by the error handler are discarded; observe them with WithOnComplete
+// among the arguments or with WithErrorTopic.
+func (m *MemoryEmitter) EmitDetached(eventName string, args ...interface{}) {
+	args, cfg := splitEmitArgs(args)
+	event := newArgsEvent(eventName, args)
+	if m.closed.Load().(bool) {
+		cfg.complete(eventName, 0, []error{&EmitError{Op: OpEmit, Topic: eventName, Err: ErrEmitterClosed}})
+		return
+	}
+	m.emitDetached(context.Background(), event, cfg, func(task func()) error {
+		m.submit(task, event.Priority(), event.Topic())
+		return nil
+	})
+}
+
+// emitDetached hands the emission's task to submit without reporting errors to the
+// caller. When submit refuses the task, its error goes to the completion callback.
+func (m *MemoryEmitter) emitDetached(ctx context.Context, event *BaseEvent, cfg emitConfig, submit func(task func()) error) {
+	m.inflight.add()
+	err := submit(func() {
+		defer m.inflight.done()
+		defer m.recoverPanic(event)
+		m.runEmission(ctx, event, nil, cfg)
+	})
+	if err != nil {
+		m.inflight.done()
+		cfg.complete(event.Topic(), 0, []error{&EmitError{Op: OpEmit, Topic: event.Topic(), EventID: event.id, Err: err}})
+	}
+}
+
+// refuse reports an emission that could not be started and returns a closed channel
+// holding err.
+func (m *MemoryEmitter) refuse(event *BaseEvent, cfg emitConfig, err error) <-chan error {
+	err = &EmitError{Op: OpEmit, Topic: event.Topic(), EventID: event.id, Err: err}
+	errChan := make(chan error, 1)
+	errChan <- err
+	close(errChan)
+	cfg.complete(event.Topic(), 0, []error{err})
+	return errChan
+}
+
+// submit runs task on the pool, or on a new goroutine when no pool is configured.
+// Pools implementing PrioritySubmitter schedule it with the given priority, and pools
+// implementing TopicSubmitter with the topic of the emission.
+func (m *MemoryEmitter) submit(task func(), priority Priority, topic string) {
+	switch pool := m.Pool.(type) {
+	case nil:
+		go task()
+	case PrioritySubmitter:
+		pool.SubmitWithPriority(task, priority)
+	case TopicSubmitter:
+		pool.SubmitForTopic(task, topic)
+	default:
+		pool.Submit(task)
 	}
+}
 
+// trySubmit runs task on the pool unless the pool is saturated and reports whether it
+// was accepted. Pools that cannot refuse tasks always accept it.
+func (m *MemoryEmitter) trySubmit(task func(), priority Priority, topic string) bool {
+	switch pool := m.Pool.(type) {
+	case PrioritySubmitter:
+		return pool.TrySubmitWithPriority(task, priority)
+	case TopicSubmitter:
+		return pool.TrySubmitForTopic(task, topic)
+	case TrySubmitter:
+		return pool.TrySubmit(task)
+	default:
+		m.submit(task, priority, topic)
+		return true
+	}
+}
+
+// runEmission performs an asynchronous emission, streaming errors to errChan and
+// reporting the outcome to the emission's completion callback, if any. A nil errChan
+// means the caller does not receive errors.
+func (m *MemoryEmitter) runEmission(ctx context.Context, event *BaseEvent, errChan chan<- error, cfg emitConfig) {
 	if m.Pool != nil {
-		m.Pool.Submit(func() {
-			defer close(errChan)
-			m.handleEvents(eventName, payload, func(err error) {
-				errChan <- err
-			})
+		ctx = m.markPoolTask(ctx)
+	}
+	if loop, ok := m.Pool.(*eventLoop); ok {
+		ctx = loop.mark(ctx)
+	}
+	if m.profilerLabels {
+		pprof.Do(ctx, profilerLabels(event), func(ctx context.Context) {
+			m.runLabeledEmission(ctx, event, errChan, cfg)
 		})
-	} else {
-		go func() {
-			defer close(errChan)
-			m.handleEvents(eventName, payload, func(err error) {
-				errChan <- err
-			})
-		}()
+		return
 	}
+	m.runLabeledEmission(ctx, event, errChan, cfg)
+}
 
-	return errChan
+// runLabeledEmission performs the emission of runEmission once the goroutine carries
+// its profiler labels, if enabled.
+func (m *MemoryEmitter) runLabeledEmission(ctx context.Context, event *BaseEvent, errChan chan<- error, cfg emitConfig) {
+	event.forced, event.retain, event.completion = cfg.force, cfg.retain, cfg
+	if errChan == nil && cfg.onComplete == nil {
+		m.emitEvent(ctx, event, nil)
+		if !event.held {
+			cfg.complete(event.Topic(), event.deliveredCount(), nil)
+		}
+		return
+	}
+
+	var errs []error
+	m.emitEvent(ctx, event, func(err error) {
+		if errChan != nil {
+			errChan <- err
+		}
+		if cfg.onComplete != nil {
+			errs = append(errs, err)
+		}
+	})
+	if !event.held {
+		cfg.complete(event.Topic(), event.deliveredCount(), errs)
+	}
 }
 
 // EmitSync dispatches an event synchronously to all subscribers of the event's topic and
 // collects any errors that occurred. This method will block until all notifications are completed.
-func (m *MemoryEmitter) EmitSync(eventName string, payload interface{}) []error {
+// Arguments are interpreted as for Emit.
+func (m *MemoryEmitter) EmitSync(eventName string, args ...interface{}) []error {
+	return m.EmitSyncWithContext(context.Background(), eventName, args...)
+}
+
+// EmitSyncWithContext behaves like EmitSync but ties the emission to ctx, checking it
+// between listeners so that long listener chains can be preempted.
+func (m *MemoryEmitter) EmitSyncWithContext(ctx context.Context, eventName string, args ...interface{}) []error {
+	args, cfg := splitEmitArgs(args)
+	return m.emitSync(ctx, newArgsEvent(eventName, args), cfg)
+}
+
+// EmitEventSync synchronously dispatches a pre-constructed event and returns the errors
+// reported by its listeners. The event can be inspected afterwards, for example to see
+// whether a listener aborted it.
+func (m *MemoryEmitter) EmitEventSync(evt Event, opts ...EmitOption) []error {
+	event := toBaseEvent(evt)
+	return m.emitSync(event.Context(), event, newEmitConfig(opts))
+}
+
+// emitSync dispatches the event on the calling goroutine and collects its errors.
+func (m *MemoryEmitter) emitSync(ctx context.Context, event *BaseEvent, cfg emitConfig) []error {
 	if m.closed.Load().(bool) {
-		return []error{ErrEmitterClosed}
+		err := &EmitError{Op: OpEmit, Topic: event.Topic(), EventID: event.id, Err: ErrEmitterClosed}
+		cfg.complete(event.Topic(), 0, []error{err})
+		return []error{err}
+	}
+	if errs, ok := m.emitSyncOnLoop(ctx, event, cfg); ok {
+		return errs
+	}
+	event.forced, event.retain, event.completion = cfg.force, cfg.retain, cfg
+
+	if m.silentErrors && cfg.onComplete == nil {
+		m.emitEvent(ctx, event, nil)
+		if !event.held {
+			cfg.complete(event.Topic(), event.deliveredCount(), nil)
+		}
+		return nil
 	}
 
 	var errs []error
-	m.handleEvents(eventName, payload, func(err error) {
+	m.emitEvent(ctx, event, func(err error) {
 		errs = append(errs, err)
 	})
+	if !event.held {
+		cfg.complete(event.Topic(), event.deliveredCount(), errs)
+	}
+	if m.silentErrors {
+		return nil
+	}
 	return errs
 }
 
-// handleEvents is an internal method that processes an event and notifies all
-// registered listeners. It takes care of error handling and panic recovery.
-func (m *MemoryEmitter) handleEvents(topicName string, payload interface{}, errorHandler func(error)) {
-	defer func() {
-		if r := recover(); r != nil && m.panicHandler != nil {
-			m.panicHandler(r)
+// toBaseEvent returns evt as a *BaseEvent. Other Event implementations are wrapped in an
+// envelope that hands them to listeners unchanged and adopts the ID, timestamp, metadata,
+// priority, expiry and context they expose.
+func toBaseEvent(evt Event) *BaseEvent {
+	if base, ok := evt.(*BaseEvent); ok {
+		return base
+	}
+
+	event := newArgsEvent(evt.Topic(), eventArgs(evt))
+	event.custom = evt
+	if v, ok := evt.(interface{ ID() string }); ok {
+		event.id = v.ID()
+	}
+	if v, ok := evt.(interface{ Timestamp() time.Time }); ok {
+		event.timestamp = v.Timestamp()
+	}
+	if v, ok := evt.(MetadataCarrier); ok {
+		event.metadata = v.Metadata()
+	}
+	if v, ok := evt.(interface{ Priority() Priority }); ok {
+		event.priority = v.Priority()
+	}
+	if v, ok := evt.(interface{ ExpiresAt() time.Time }); ok {
+		event.expiresAt = v.ExpiresAt()
+	}
+	if v, ok := evt.(interface{ Context() context.Context }); ok {
+		event.ctx = v.Context()
+	}
+	return event
+}
+
+// emitEvent is an internal method that processes an event and notifies all registered
+// listeners. It stamps the event with a timestamp, an ID and an expiry unless they are
+// already set, and takes care of error handling and panic recovery. Errors left by the
+// error handler are passed to errorHandler, or discarded when it is nil.
+func (m *MemoryEmitter) emitEvent(ctx context.Context, event *BaseEvent, errorHandler func(error)) {
+	if event.timestamp.IsZero() {
+		event.timestamp = m.clock.Now()
+	}
+	if event.id == "" && m.eventIDGenerator != nil {
+		event.id = m.eventIDGenerator()
+	}
+	if event.ttl > 0 && event.expiresAt.IsZero() {
+		event.expiresAt = event.timestamp.Add(event.ttl)
+	}
+	if chain := m.middleware.chain.Load(); chain != nil {
+		for _, err := range (*chain)(ctx, event) {
+			if errorHandler != nil {
+				errorHandler(err)
+			}
+		}
+		return
+	}
+	m.deliverChecked(ctx, event, errorHandler)
+}
+
+// deliverChecked notifies the listeners of a stamped event unless its topic is paused or
+// its payload is unchanged.
+func (m *MemoryEmitter) deliverChecked(ctx context.Context, event *BaseEvent, errorHandler func(error)) {
+	if m.holdPaused(event, errorHandler) || m.unchanged(event) {
+		return
+	}
+	m.deliverEvent(ctx, event, errorHandler)
+}
+
+// deliverEvent notifies the listeners of a stamped event.
+func (m *MemoryEmitter) deliverEvent(ctx context.Context, event *BaseEvent, errorHandler func(error)) {
+	if cancel := m.prepareEmission(ctx, event); cancel != nil {
+		defer cancel()
+	}
+	if event.retain {
+		m.retain(event)
+	}
+	m.handleEvent(event, errorHandler)
+}
+
+// prepareEmission attaches the context and checkpoint state to the event when the
+// emission needs them. It returns a cancel function to release an emission timeout.
+func (m *MemoryEmitter) prepareEmission(ctx context.Context, event *BaseEvent) context.CancelFunc {
+	var cancel context.CancelFunc
+	if m.emissionTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, m.emissionTimeout)
+	}
+	event.ctx = ctx
+	event.checkpoint = newEmission(ctx, m.listenerBudget, event.expiresAt, m.clock)
+	return cancel
+}
+
+// handleEvent dispatches an already constructed event to the matching listeners and then
+// hands it to the propagation hook and the forwarder, if any. Panics are recovered and
+// reported.
+func (m *MemoryEmitter) handleEvent(event *BaseEvent, errorHandler func(error)) {
+	defer m.recoverPanic(event)
+
+	m.dispatch(event, errorHandler)
+
+	if event.listenerEvent().IsAborted() {
+		return
+	}
+	if m.propagate != nil {
+		m.propagate(event, errorHandler)
+	}
+	if m.forwarder != nil {
+		m.forward(event, errorHandler)
+	}
+}
+
+// dispatch notifies the listeners of every topic matching the event's topic, passing
+// listener errors through the configured error handler. Topics matched after a listener
+// aborted the event are skipped.
+func (m *MemoryEmitter) dispatch(event *BaseEvent, errorHandler func(error)) {
+	event.trail = append(event.trail, m)
+	event.recoverer = nil
+	if m.panicPolicy == PanicRecover {
+		event.recoverer = m
+	}
+	event.redeliveries = m.redeliveries(event.Topic())
+
+	topicName := event.Topic()
+	// Split the emitted topic once and reuse its segments for every registered pattern.
+	subjectParts := splitTopic(topicName)
+	now := m.clock.Now()
+	notify := func(topic *Topic) {
+		if event.listenerEvent().IsAborted() {
+			return // Aborted by a listener of a topic matched earlier.
 		}
-	}()
-
-	event := NewBaseEvent(topicName, payload)
-	m.topics.Range(func(key, value interface{}) bool {
-		topicPattern := key.(string)
-		if matchTopicPattern(topicPattern, topicName) {
-			topic := value.(*Topic)
-			topicErrors := topic.Trigger(event)
-			for _, err := range topicErrors {
-				if m.errorHandler != nil {
-					err = m.errorHandler(event, err)
-				}
-				if err != nil {
-					errorHandler(err)
-				}
+		topic.metrics.record(m.clock, now)
+		topicErrors := topic.trigger(event.listenerEvent(), event)
+		for _, err := range topicErrors {
+			if m.errorHandler != nil {
+				err = m.errorHandler(event.listenerEvent(), err)
+			}
+			if err == nil {
+				continue
+			}
+			var emitErr *EmitError
+			if !errors.As(err, &emitErr) {
+				// The error handler replaced the listener error; keep the emission's context.
+				err = &EmitError{Op: OpNotify, Topic: topicName, Pattern: topic.Name, EventID: event.id, Err: err}
+			}
+			if m.errorTopic != "" {
+				m.publishError(event, topic.Name, err)
+			}
+			if errorHandler != nil {
+				errorHandler(err)
 			}
 		}
-		return true
+	}
+	if all := m.anyTopic.Load(); all != nil {
+		notify(all)
+	}
+	m.topics.load().match(topicName, subjectParts, notify)
+}
+
+// publishError synchronously emits an unhandled listener error on the error topic as a
+// *DispatchError. Errors raised while dispatching the error topic itself are not
+// published again, so failing error listeners cannot cause an endless loop.
+func (m *MemoryEmitter) publishError(event *BaseEvent, pattern string, err error) {
+	if event.Topic() == m.errorTopic {
+		return
+	}
+	dispatchErr := &DispatchError{
+		Topic:   event.Topic(),
+		Pattern: pattern,
+		Event:   event.listenerEvent(),
+		Err:     err,
+	}
+	var emitErr *EmitError
+	if errors.As(err, &emitErr) {
+		dispatchErr.ListenerID, dispatchErr.Err = emitErr.ListenerID, emitErr.Err
+	}
+	m.publish(m.errorTopic, dispatchErr)
+}
+
+// eventForwarder is implemented by emitters that accept events forwarded from related
+// emitters while preserving the trail of emitters the event has already visited.
+type eventForwarder interface {
+	forwardEvent(from *BaseEvent) []error
+}
+
+// forwardEvent receives an event forwarded from another emitter. Events that already
+// passed through this emitter are dropped, which is what loop protection relies on.
+func (m *MemoryEmitter) forwardEvent(from *BaseEvent) []error {
+	if from.visited(m) {
+		return nil
+	}
+	if m.closed.Load().(bool) {
+		return []error{&EmitError{Op: OpEmit, Topic: from.Topic(), EventID: from.id, Err: ErrEmitterClosed}}
+	}
+
+	event := from.forwardedCopy()
+	if cancel := m.prepareEmission(from.Context(), event); cancel != nil {
+		defer cancel()
+	}
+
+	var errs []error
+	m.handleEvent(event, func(err error) {
+		errs = append(errs, err)
 	})
+	return errs
+}
+
+// recoverPanic recovers a panic raised while processing the event and reports it to the
+// panic topic and the panic handler. Under PanicPropagate, the panic is raised again once
+// reported. It must be called directly by a deferred call.
+func (m *MemoryEmitter) recoverPanic(event *BaseEvent) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if !event.panicked {
+		m.reportPanic(event, r, debug.Stack())
+	}
+	if m.panicPolicy == PanicPropagate {
+		event.panicked = true // Enclosing recoveries of the same event only propagate it.
+		panic(r)
+	}
+}
+
+// reportPanic reports a recovered panic to the panic topic and the panic handler, and
+// returns its description. Panics raised while dispatching the panic topic itself are
+// not published again.
+func (m *MemoryEmitter) reportPanic(event *BaseEvent, value interface{}, stack []byte) *ListenerPanic {
+	recovered := &ListenerPanic{
+		Topic:      event.Topic(),
+		ListenerID: event.running,
+		Event:      event.listenerEvent(),
+		Value:      value,
+		Stack:      stack,
+	}
+	m.reportListenerPanic(recovered)
+	return recovered
+}
+
+// reportListenerPanic publishes the panic on the panic topic and passes its value to
+// the panic handler.
+func (m *MemoryEmitter) reportListenerPanic(recovered *ListenerPanic) {
+	if m.panicTopic != "" && recovered.Topic != m.panicTopic {
+		m.publish(m.panicTopic, recovered)
+	}
+	if m.panicHandler != nil {
+		m.panicHandler(recovered.Value)
+	}
 }
 
 // GetTopic retrieves a topic by its name. If the topic does not exist, it returns an error.
 func (m *MemoryEmitter) GetTopic(topicName string) (*Topic, error) {
-	topic, ok := m.topics.Load(topicName)
+	topic, ok := m.topics.get(topicName)
 	if !ok {
 		return nil, fmt.Errorf("%w: unable to find topic '%s'", ErrTopicNotFound, topicName)
 	}
-	return topic.(*Topic), nil
+	return topic, nil
 }
 
 // EnsureTopic retrieves or creates a new topic by its name. If the topic does not
-// exist, it is created and returned. This ensures that a topic is always available.
+// exist, it is created and returned. This ensures that a topic is always available,
+// even beyond the wildcard limit set with WithWildcardLimit, which only On enforces.
+// Once the emitter is closed, the returned topic is no longer registered with it.
 func (m *MemoryEmitter) EnsureTopic(topicName string) *Topic {
-	topic, _ := m.topics.LoadOrStore(topicName, NewTopic())
-	return topic.(*Topic)
+	topic, created, err := m.topics.ensureWithin(topicName, 0)
+	if err != nil {
+		// The emitter is closed: hand out a topic that is not registered.
+		topic = NewTopic()
+		topic.Name = topicName
+		return topic
+	}
+	if created {
+		m.topicCreated(topicName)
+	}
+	return topic
 }
 
 func (m *MemoryEmitter) SetErrorHandler(handler func(Event, error) error) {
@@ -169,6 +801,60 @@ func (m *MemoryEmitter) SetIDGenerator(generator func() string) {
 	}
 }
 
+// SetEventIDGenerator assigns a function that generates a unique ID for each emitted event.
+func (m *MemoryEmitter) SetEventIDGenerator(generator func() string) {
+	m.eventIDGenerator = generator
+}
+
+// SetClock assigns the clock used for event timestamps and delayed emissions.
+func (m *MemoryEmitter) SetClock(clock Clock) {
+	if clock != nil {
+		m.clock = clock
+	}
+}
+
+// SetSilentErrors controls whether listener errors are discarded after the error handler
+// has seen them instead of being returned by EmitSync or sent on the Emit channel.
+func (m *MemoryEmitter) SetSilentErrors(silent bool) {
+	m.silentErrors = silent
+}
+
+// SetErrorTopic sets the topic unhandled listener errors are published on. An empty
+// topic stops publishing them.
+func (m *MemoryEmitter) SetErrorTopic(topic string) {
+	m.errorTopic = topic
+}
+
+// SetPanicTopic sets the topic recovered panics are published on. An empty topic stops
+// publishing them.
+func (m *MemoryEmitter) SetPanicTopic(topic string) {
+	m.panicTopic = topic
+}
+
+// SetLifecycleEvents controls whether the emitter publishes events on the reserved
+// lifecycle topics.
+func (m *MemoryEmitter) SetLifecycleEvents(enabled bool) {
+	m.lifecycleEvents = enabled
+}
+
+// SetListenerSources controls whether the emitter records the call site registering each
+// listener, reported by Listeners.
+func (m *MemoryEmitter) SetListenerSources(enabled bool) {
+	m.listenerSources = enabled
+}
+
+// SetMatchProfile selects the rules matching emitted topics against wildcard patterns.
+// It applies to the topics already registered as well.
+func (m *MemoryEmitter) SetMatchProfile(profile MatchProfile) {
+	m.topics.setProfile(profile)
+}
+
+// SetProfilerLabels controls whether asynchronous emissions run with pprof labels naming
+// their topic and priority lane.
+func (m *MemoryEmitter) SetProfilerLabels(enabled bool) {
+	m.profilerLabels = enabled
+}
+
 func (m *MemoryEmitter) SetPool(pool Pool) {
 	m.Pool = pool
 }
@@ -183,23 +869,70 @@ func (m *MemoryEmitter) SetErrChanBufferSize(size int) {
 	m.errChanBufferSize = size
 }
 
+// SetEmissionTimeout bounds how long a single emission may keep notifying listeners.
+func (m *MemoryEmitter) SetEmissionTimeout(timeout time.Duration) {
+	m.emissionTimeout = timeout
+}
+
+// SetListenerBudget limits how many listeners a single emission may notify.
+func (m *MemoryEmitter) SetListenerBudget(budget int) {
+	m.listenerBudget = budget
+}
+
+// SetChainLengthWarning registers a handler called whenever a topic's listener chain
+// grows beyond the given length.
+func (m *MemoryEmitter) SetChainLengthWarning(length int, handler func(topic string, length int)) {
+	m.chainWarnLength = length
+	m.chainWarnHandler = handler
+}
+
 // Close terminates the emitter, ensuring all pending events are processed. It performs cleanup
 // and releases resources. Calling Close on an already closed emitter will result in an error.
+// Close waits for the pool's tasks, so listeners of asynchronous emissions, which run on
+// the pool, call CloseWithContext instead.
+//
+// Close is ordered with concurrent calls to On: each either completes first, and its
+// listener is removed by Close, or fails with ErrEmitterClosed.
 func (m *MemoryEmitter) Close() error {
+	return m.CloseWithContext(context.Background())
+}
+
+// CloseWithContext behaves like Close, unless ctx is the context of an emission running
+// on the emitter's pool, as when a listener passes the context of its event:
+//
+//	e.On("shutdown", func(evt emitter.Event) error {
+//		return e.CloseWithContext(evt.(*emitter.BaseEvent).Context())
+//	})
+//
+// Waiting for the pool would then wait for the caller itself, so the emitter is closed
+// without waiting, its pool is released once the emission completes, and
+// CloseWithContext returns ErrListenerDeadlock.
+func (m *MemoryEmitter) CloseWithContext(ctx context.Context) error {
 	if m.closed.Load().(bool) {
 		return ErrEmitterAlreadyClosed
 	}
 
+	if m.lifecycleEvents {
+		m.publish(TopicEmitterClosed, &LifecycleEvent{})
+	}
+
+	m.closeMu.Lock()
 	m.closed.Store(true)
+	topics := m.topics.close()
+	m.closeMu.Unlock()
 
 	// Perform cleanup operations
-	m.topics.Range(func(key, value interface{}) bool {
-		m.topics.Delete(key)
-		return true
-	})
+	if all := m.anyTopic.Load(); all != nil {
+		topics = append(topics, all)
+	}
+	for _, topic := range topics {
+		topic.closeErrorStreams()
+	}
+	m.background.stop()
+	m.dropPaused()
 
 	if m.Pool != nil {
-		m.Pool.Release()
+		return m.releasePool(ctx)
 	}
 
 	return nil
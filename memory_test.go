@@ -1,6 +1,7 @@
 package emitter
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"testing"
@@ -38,6 +39,145 @@ func TestOnOff(t *testing.T) {
 	}
 }
 
+func TestOffAll(t *testing.T) {
+	e := NewMemoryEmitter(WithLifecycleEvents())
+	var removed []string
+	e.On(TopicListenerRemoved, func(evt Event) error {
+		removed = append(removed, evt.Payload().(*LifecycleEvent).ListenerID)
+		return nil
+	})
+	first, _ := e.On("order.*", func(Event) error { return nil })
+	second, _ := e.On("order.*", func(Event) error { return nil }, WithPriority(Low))
+	other, _ := e.On("user.created", func(Event) error { return nil })
+
+	if err := e.OffAll("order.*"); err != nil {
+		t.Fatalf("OffAll() error = %v", err)
+	}
+	if topic, _ := e.GetTopic("order.*"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after OffAll, want 0", topic.ListenerCount())
+	}
+	if len(removed) != 2 || removed[0] != first || removed[1] != second {
+		t.Errorf("removed listeners = %v, want [%s %s]", removed, first, second)
+	}
+	if err := e.Off("user.created", other); err != nil {
+		t.Errorf("Off() on another topic error = %v", err)
+	}
+	if err := e.OffAll("missing"); !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("OffAll() on a missing topic error = %v, want %v", err, ErrTopicNotFound)
+	}
+}
+
+func TestOnManyOffMany(t *testing.T) {
+	e := NewMemoryEmitter()
+	var seen []string
+	audit := func(evt Event) error {
+		seen = append(seen, evt.Topic())
+		return nil
+	}
+	topics := []string{"order.*", "user.created"}
+
+	ids, err := e.OnMany(topics, audit)
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("OnMany() = %v, %v, want two IDs", ids, err)
+	}
+	e.EmitSync("order.paid")
+	e.EmitSync("user.created")
+	if len(seen) != 2 {
+		t.Errorf("listener saw %v, want both topics", seen)
+	}
+
+	if err := e.OffMany(topics, ids); err != nil {
+		t.Fatalf("OffMany() error = %v", err)
+	}
+	if err := e.OffMany(topics, ids); !errors.Is(err, ErrListenerNotFound) {
+		t.Errorf("OffMany() of removed listeners error = %v, want %v", err, ErrListenerNotFound)
+	}
+}
+
+func TestOnManyRollsBack(t *testing.T) {
+	e := NewMemoryEmitter(WithWildcardLimit(1))
+	ids, err := e.OnMany([]string{"order.*", "user.created", "audit.*"}, func(Event) error { return nil })
+	if !errors.Is(err, ErrWildcardLimitExceeded) || ids != nil {
+		t.Fatalf("OnMany() = %v, %v, want %v", ids, err, ErrWildcardLimitExceeded)
+	}
+	for _, name := range []string{"order.*", "user.created"} {
+		if topic, _ := e.GetTopic(name); topic.ListenerCount() != 0 {
+			t.Errorf("%s has %d listeners after a failed OnMany, want 0", name, topic.ListenerCount())
+		}
+	}
+}
+
+func TestRemoveTopic(t *testing.T) {
+	e := NewMemoryEmitter(WithLifecycleEvents())
+	var changes []string
+	record := func(evt Event) error {
+		changes = append(changes, evt.Topic()+" "+evt.Payload().(*LifecycleEvent).Topic)
+		return nil
+	}
+	e.On(TopicListenerRemoved, record)
+	e.On(TopicTopicRemoved, record)
+	calls := 0
+	e.On("order.*", func(Event) error {
+		calls++
+		return nil
+	})
+
+	if err := e.RemoveTopic("order.*"); err != nil {
+		t.Fatalf("RemoveTopic() error = %v", err)
+	}
+	e.EmitSync("order.created")
+	if calls != 0 {
+		t.Errorf("listener called %d times after RemoveTopic, want 0", calls)
+	}
+	if _, err := e.GetTopic("order.*"); !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("GetTopic() after RemoveTopic error = %v, want %v", err, ErrTopicNotFound)
+	}
+	want := []string{TopicListenerRemoved + " order.*", TopicTopicRemoved + " order.*"}
+	if len(changes) != 2 || changes[0] != want[0] || changes[1] != want[1] {
+		t.Errorf("lifecycle events = %v, want %v", changes, want)
+	}
+	if err := e.RemoveTopic("order.*"); !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("RemoveTopic() of a removed topic error = %v, want %v", err, ErrTopicNotFound)
+	}
+
+	e.On("order.*", func(Event) error {
+		calls++
+		return nil
+	})
+	e.EmitSync("order.created")
+	if calls != 1 {
+		t.Errorf("listener subscribed again called %d times, want 1", calls)
+	}
+}
+
+func TestReset(t *testing.T) {
+	e := NewMemoryEmitter()
+	var calls int
+	listener := func(Event) error {
+		calls++
+		return nil
+	}
+	e.On("order.created", listener)
+	e.On("order.*", listener)
+
+	e.Reset()
+	if _, err := e.GetTopic("order.*"); !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("GetTopic() after Reset error = %v, want %v", err, ErrTopicNotFound)
+	}
+	e.EmitSync("order.created")
+	if calls != 0 {
+		t.Errorf("listeners called %d times after Reset, want 0", calls)
+	}
+
+	if _, err := e.On("order.created", listener); err != nil {
+		t.Fatalf("On() after Reset error = %v", err)
+	}
+	e.EmitSync("order.created")
+	if calls != 1 {
+		t.Errorf("listener called %d times, want 1", calls)
+	}
+}
+
 // TestEmitAsyncSuccess tests the asynchronous Emit method for successful event handling.
 func TestEmitAsyncSuccess(t *testing.T) {
 	emitter := NewMemoryEmitter()
@@ -310,6 +450,47 @@ func TestMemoryEmitterClose(t *testing.T) {
 	}
 }
 
+func TestOnAfterClose(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	emitter.Close()
+
+	if _, err := emitter.On("topic1", func(e Event) error { return nil }); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("On() after Close() error = %v, want ErrEmitterClosed", err)
+	}
+	if topic := emitter.EnsureTopic("topic1"); topic == nil || topic.Name != "topic1" {
+		t.Errorf("EnsureTopic() after Close() = %v, want a detached topic", topic)
+	}
+	if _, err := emitter.GetTopic("topic1"); err == nil {
+		t.Error("EnsureTopic() after Close() registered the topic")
+	}
+}
+
+func TestOnConcurrentWithClose(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		emitter := NewMemoryEmitter()
+		var wg sync.WaitGroup
+		for j := 0; j < 8; j++ {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				for k := 0; k < 20; k++ {
+					_, err := emitter.On("topic", func(e Event) error { return nil })
+					if err != nil && !errors.Is(err, ErrEmitterClosed) {
+						t.Errorf("On() error = %v", err)
+					}
+				}
+			}(j)
+		}
+		emitter.Close()
+		wg.Wait()
+
+		// Listeners added before Close were removed with it, and none were added after.
+		if topics := emitter.topics.load().entries; len(topics) != 0 {
+			t.Fatalf("%d topics left registered on a closed emitter", len(topics))
+		}
+	}
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -326,3 +507,178 @@ func createTestListener(received chan<- string) func(Event) error {
 		return nil
 	}
 }
+
+// BenchmarkEmitSyncWildcard measures synchronous emission against a mix of exact and wildcard patterns.
+func BenchmarkEmitSyncWildcard(b *testing.B) {
+	emitter := NewMemoryEmitter()
+
+	patterns := []string{
+		"event.some.thing.run",
+		"event.some.*.*",
+		"event.some.*.run",
+		"event.some.**",
+		"**.thing.run",
+		"other.*",
+		"other.**.done",
+	}
+	for _, pattern := range patterns {
+		if _, err := emitter.On(pattern, func(e Event) error { return nil }); err != nil {
+			b.Fatalf("On() failed with error: %v", err)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		emitter.EmitSync("event.some.thing.run", "payload")
+	}
+}
+
+// BenchmarkEmitSilentErrors measures synchronous emission when errors are silenced.
+func BenchmarkEmitSilentErrors(b *testing.B) {
+	emitter := NewMemoryEmitter(WithSilentErrors())
+	emitter.On("event.some.thing.run", func(e Event) error { return nil })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		emitter.EmitSync("event.some.thing.run", "payload")
+	}
+}
+
+// BenchmarkEmitAsync compares asynchronous emission with and without an error channel.
+func BenchmarkEmitAsync(b *testing.B) {
+	run := func(b *testing.B, emit func(e *MemoryEmitter)) {
+		e := NewMemoryEmitter()
+		e.On("event.some.thing.run", func(e Event) error { return nil })
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			emit(e)
+		}
+		if err := e.Flush(context.Background()); err != nil {
+			b.Fatalf("Flush() failed with error: %v", err)
+		}
+	}
+
+	b.Run("Emit", func(b *testing.B) {
+		run(b, func(e *MemoryEmitter) { e.Emit("event.some.thing.run", "payload") })
+	})
+	b.Run("EmitDetached", func(b *testing.B) {
+		run(b, func(e *MemoryEmitter) { e.EmitDetached("event.some.thing.run", "payload") })
+	})
+}
+
+func TestEmitDetached(t *testing.T) {
+	var handled []error
+	e := NewMemoryEmitter(WithErrorHandler(func(evt Event, err error) error {
+		handled = append(handled, err)
+		return err
+	}))
+	e.On("order.created", func(Event) error { return errors.New("boom") })
+
+	done := make(chan []error, 1)
+	e.EmitDetached("order.created", "payload", WithOnComplete(func(topic string, delivered int, errs []error) {
+		done <- errs
+	}))
+	if errs := <-done; len(errs) != 1 || len(handled) != 1 {
+		t.Errorf("completion errors = %v, handled = %v, want one each", errs, handled)
+	}
+
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	e.EmitDetached("order.created", WithOnComplete(func(topic string, delivered int, errs []error) {
+		done <- errs
+	}))
+	if errs := <-done; len(errs) != 1 || !errors.Is(errs[0], ErrEmitterClosed) {
+		t.Errorf("completion errors after Close = %v, want %v", errs, ErrEmitterClosed)
+	}
+}
+
+func TestEmitMultipleArguments(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	type reason string
+	received := make(chan []interface{}, 2)
+	emitter.On("user.banned", func(e Event) error {
+		received <- e.(*BaseEvent).Args()
+		return nil
+	})
+
+	if errs := emitter.EmitSync("user.banned", "user-1", "admin", reason("spam")); len(errs) != 0 {
+		t.Fatalf("EmitSync() returned errors: %v", errs)
+	}
+	if args := <-received; len(args) != 3 || args[0] != "user-1" || args[2] != reason("spam") {
+		t.Errorf("Args() = %v, want [user-1 admin spam]", args)
+	}
+
+	// Emit options are not part of the arguments.
+	done := make(chan int, 1)
+	emitter.Emit("user.banned", "user-2", "moderator", WithOnComplete(func(topic string, delivered int, errs []error) {
+		done <- delivered
+	}))
+	if args := <-received; len(args) != 2 || args[1] != "moderator" {
+		t.Errorf("Args() = %v, want [user-2 moderator]", args)
+	}
+	if delivered := <-done; delivered != 1 {
+		t.Errorf("Completion delivered = %d, want 1", delivered)
+	}
+}
+
+func TestEmitSyncWithOnComplete(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	emitter.On("report.generated", func(e Event) error { return errors.New("listener error") })
+
+	var completed []error
+	errs := emitter.EmitSync("report.generated", nil, WithOnComplete(func(topic string, delivered int, errs []error) {
+		completed = errs
+	}))
+	if len(errs) != 1 || len(completed) != 1 {
+		t.Errorf("EmitSync() errors = %v, completion errors = %v, want one each", errs, completed)
+	}
+}
+
+func TestEmitEvent(t *testing.T) {
+	emitter := NewMemoryEmitter(WithEventIDGenerator(func() string { return "evt-1" }))
+
+	var tenant, readOnlyTenant interface{}
+	emitter.On("order.*", func(e Event) error {
+		tenant, _ = e.(*BaseEvent).MetadataValue("tenant")
+		e.SetAborted(true)
+		return nil
+	})
+	emitter.On("order.created", func(e Event) error {
+		readOnlyTenant, _ = e.(interface {
+			MetadataValue(string) (interface{}, bool)
+		}).MetadataValue("tenant")
+		return nil
+	}, WithReadOnlyEvent(), WithPriority(High))
+
+	event := NewEvent("order.created").WithPayload("order-1").WithMetadata("tenant", "acme")
+	if errs := emitter.EmitEventSync(event); len(errs) != 0 {
+		t.Fatalf("EmitEventSync() returned errors: %v", errs)
+	}
+
+	if tenant != "acme" || readOnlyTenant != "acme" {
+		t.Errorf("Listeners saw tenant (%v, %v), want acme for both", tenant, readOnlyTenant)
+	}
+	if !event.IsAborted() {
+		t.Error("EmitEventSync() should dispatch the given event so its final state is visible")
+	}
+	if event.ID() != "evt-1" || event.Timestamp().IsZero() {
+		t.Errorf("Event envelope = (%q, %v), want an ID and a timestamp", event.ID(), event.Timestamp())
+	}
+
+	done := make(chan int, 1)
+	errChan := emitter.EmitEvent(NewEvent("order.shipped").WithPayload("order-1"), WithOnComplete(func(topic string, delivered int, errs []error) {
+		done <- delivered
+	}))
+	for err := range errChan {
+		t.Errorf("EmitEvent() reported error: %v", err)
+	}
+	if delivered := <-done; delivered != 1 {
+		t.Errorf("Completion delivered = %d, want 1", delivered)
+	}
+}
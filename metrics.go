@@ -0,0 +1,122 @@
+package emitter
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateWindow is the time constant of the moving average behind topic emission rates.
+// Rates react to changes over roughly this period, like a one-minute load average.
+const rateWindow = time.Minute
+
+// TopicStats is a snapshot of a topic's emission activity.
+type TopicStats struct {
+	Emitted     uint64        // Number of events dispatched to the topic.
+	LastEmitted time.Time     // Time of the last dispatched event, or the zero time if none was.
+	Rate        float64       // Exponentially weighted moving average of events per second.
+	Wildcard    bool          // Whether the topic is a wildcard pattern; set by MemoryEmitter.Stats.
+	Evaluations uint64        // Number of subjects matched against the pattern, recorded with WithMatchCosts.
+	MatchCost   time.Duration // Total time spent matching subjects against the pattern, recorded with WithMatchCosts.
+}
+
+// EmitterStats is a snapshot of an emitter's activity.
+type EmitterStats struct {
+	Topics    map[string]TopicStats // Activity of every registered topic, indexed by name or pattern.
+	Wildcards int                   // Number of registered wildcard patterns.
+	Pool      PoolStats             // Utilization of the emitter's pool.
+}
+
+// topicMetrics records the emission activity of a topic.
+type topicMetrics struct {
+	mu      sync.Mutex
+	clock   Clock // Clock of the emitter that recorded the last event.
+	emitted uint64
+	last    time.Time
+	rate    float64 // Rate estimate as of the last event.
+}
+
+// record accounts for an event dispatched at now.
+func (tm *topicMetrics) record(clock Clock, now time.Time) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.rate = tm.decayed(now) + 1/rateWindow.Seconds()
+	tm.emitted++
+	tm.last = now
+	tm.clock = clock
+}
+
+// snapshot returns the activity recorded so far, with the rate decayed to now.
+func (tm *topicMetrics) snapshot(now time.Time) TopicStats {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return TopicStats{Emitted: tm.emitted, LastEmitted: tm.last, Rate: tm.decayed(now)}
+}
+
+// decayed returns the rate estimate decayed over the time elapsed since the last
+// event. Callers must hold tm.mu.
+func (tm *topicMetrics) decayed(now time.Time) float64 {
+	if tm.last.IsZero() {
+		return 0
+	}
+	elapsed := now.Sub(tm.last)
+	if elapsed <= 0 {
+		return tm.rate
+	}
+	return tm.rate * math.Exp(-elapsed.Seconds()/rateWindow.Seconds())
+}
+
+// now returns the current time on the clock of the emitter dispatching to the topic.
+func (tm *topicMetrics) now() time.Time {
+	tm.mu.Lock()
+	clock := tm.clock
+	tm.mu.Unlock()
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+// Stats returns the emission activity of the topic. Every event dispatched to the
+// topic counts, whether or not it had listeners.
+func (t *Topic) Stats() TopicStats {
+	return t.stats(t.metrics.now())
+}
+
+// stats returns the topic's activity with its rate decayed to now.
+func (t *Topic) stats(now time.Time) TopicStats {
+	stats := t.metrics.snapshot(now)
+	stats.Evaluations = t.matchCost.evaluations.Load()
+	stats.MatchCost = time.Duration(t.matchCost.nanos.Load())
+	return stats
+}
+
+// LastEmitted returns the time of the last event dispatched to the topic, or the zero
+// time if none was. It helps detecting topics that went silent, such as heartbeats.
+func (t *Topic) LastEmitted() time.Time {
+	return t.Stats().LastEmitted
+}
+
+// Rate returns the topic's emission rate in events per second, as an exponentially
+// weighted moving average over about a minute.
+func (t *Topic) Rate() float64 {
+	return t.Stats().Rate
+}
+
+// Stats returns the activity of every registered topic along with the pool utilization.
+// Events emitted on a subject count toward every topic whose name or pattern matches it.
+func (m *MemoryEmitter) Stats() EmitterStats {
+	now := m.clock.Now()
+	snapshot := m.topics.load()
+	stats := EmitterStats{
+		Topics:    make(map[string]TopicStats, len(snapshot.entries)),
+		Wildcards: len(snapshot.wildcards),
+		Pool:      m.PoolStats(),
+	}
+	for name, entry := range snapshot.entries {
+		topicStats := entry.topic.stats(now)
+		topicStats.Wildcard = entry.wildcard
+		stats.Topics[name] = topicStats
+	}
+	return stats
+}
@@ -0,0 +1,53 @@
+package emitter_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+func TestTopicStats(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := emittertest.NewFakeClock(start)
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	if _, err := em.On("heartbeat.*", func(emitter.Event) error { return nil }); err != nil {
+		t.Fatalf("On() failed with error: %v", err)
+	}
+	topic := em.EnsureTopic("heartbeat.*")
+	quiet := em.EnsureTopic("orders")
+
+	// One event per second for ten minutes converges to a rate of one per second.
+	for i := 0; i < 600; i++ {
+		em.EmitSync("heartbeat.service-a", i)
+		clock.Advance(time.Second)
+	}
+
+	stats := topic.Stats()
+	if stats.Emitted != 600 {
+		t.Errorf("Emitted = %d, want 600", stats.Emitted)
+	}
+	if want := start.Add(599 * time.Second); !topic.LastEmitted().Equal(want) {
+		t.Errorf("LastEmitted() = %v, want %v", topic.LastEmitted(), want)
+	}
+	if rate := topic.Rate(); math.Abs(rate-1) > 0.05 {
+		t.Errorf("Rate() = %v, want about 1", rate)
+	}
+
+	// The rate decays once the topic goes silent.
+	clock.Advance(5 * time.Minute)
+	if rate := topic.Rate(); rate > 0.01 {
+		t.Errorf("Rate() after silence = %v, want about 0", rate)
+	}
+
+	all := em.Stats()
+	if got := all.Topics["heartbeat.*"].Emitted; got != 600 {
+		t.Errorf("Stats().Topics[heartbeat.*].Emitted = %d, want 600", got)
+	}
+	if got := all.Topics["orders"]; got.Emitted != 0 || !got.LastEmitted.IsZero() || quiet.Rate() != 0 {
+		t.Errorf("Stats().Topics[orders] = %+v, want no activity", got)
+	}
+}
@@ -0,0 +1,89 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// EmitFunc delivers an event to the listeners of its topic and returns the errors of
+// the emission.
+type EmitFunc func(ctx context.Context, event *BaseEvent) []error
+
+// Middleware wraps the delivery of every emission, for cross-cutting concerns such as
+// logging, metrics, payload enrichment or authorization. It may change the context or
+// the event before calling next, skip next to drop the emission, and add errors to
+// those next returns:
+//
+//	e.Use(func(next emitter.EmitFunc) emitter.EmitFunc {
+//		return func(ctx context.Context, evt *emitter.BaseEvent) []error {
+//			start := time.Now()
+//			errs := next(ctx, evt)
+//			metrics.Observe(evt.Topic(), time.Since(start))
+//			return errs
+//		}
+//	})
+type Middleware func(next EmitFunc) EmitFunc
+
+// emitMiddleware holds the middleware installed with Use.
+type emitMiddleware struct {
+	mu    sync.Mutex
+	list  []Middleware
+	chain atomic.Pointer[EmitFunc] // Composed middleware, or nil without any.
+}
+
+// Use installs middleware around the delivery of every emission, made with Emit,
+// EmitSync or any of their variants, including the emitter's own events. Middleware
+// installed first runs outermost. It runs on the goroutine delivering the emission,
+// which is a pool worker for asynchronous emissions, and wraps paused topics and
+// suppressed payloads too. Errors returned by the chain are reported to the emission
+// like listener errors, once it returns.
+func (m *MemoryEmitter) Use(middleware ...Middleware) {
+	m.middleware.mu.Lock()
+	defer m.middleware.mu.Unlock()
+
+	m.middleware.list = append(m.middleware.list, middleware...)
+	var chain EmitFunc = m.emitThrough
+	for i := len(m.middleware.list) - 1; i >= 0; i-- {
+		chain = m.middleware.list[i](chain)
+	}
+	m.middleware.chain.Store(&chain)
+}
+
+// WithBeforeEmit calls before with each event right before it is delivered, on the
+// goroutine delivering it. Together with WithAfterEmit, it lets emitters time every
+// emission or record failures centrally. It is installed with Use.
+func WithBeforeEmit(before func(Event)) EmitterOption {
+	return func(m Emitter) {
+		m.Use(func(next EmitFunc) EmitFunc {
+			return func(ctx context.Context, event *BaseEvent) []error {
+				before(event.listenerEvent())
+				return next(ctx, event)
+			}
+		})
+	}
+}
+
+// WithAfterEmit calls after with each event and the errors of its emission once it was
+// delivered, on the goroutine delivering it. It is installed with Use.
+func WithAfterEmit(after func(Event, []error)) EmitterOption {
+	return func(m Emitter) {
+		m.Use(func(next EmitFunc) EmitFunc {
+			return func(ctx context.Context, event *BaseEvent) []error {
+				errs := next(ctx, event)
+				after(event.listenerEvent(), errs)
+				return errs
+			}
+		})
+	}
+}
+
+// emitThrough is the innermost EmitFunc of the middleware chain. It delivers the event
+// like an emission without middleware and returns its errors.
+func (m *MemoryEmitter) emitThrough(ctx context.Context, event *BaseEvent) []error {
+	var errs []error
+	m.deliverChecked(ctx, event, func(err error) {
+		errs = append(errs, err)
+	})
+	return errs
+}
@@ -0,0 +1,119 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUse(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+
+	var mu sync.Mutex
+	var trace []string
+	record := func(step string) {
+		mu.Lock()
+		trace = append(trace, step)
+		mu.Unlock()
+	}
+	errDenied := errors.New("denied")
+	e.Use(func(next EmitFunc) EmitFunc {
+		return func(ctx context.Context, evt *BaseEvent) []error {
+			record("outer:" + evt.Topic())
+			return next(ctx, evt)
+		}
+	}, func(next EmitFunc) EmitFunc {
+		return func(ctx context.Context, evt *BaseEvent) []error {
+			if strings.HasPrefix(evt.Topic(), "admin.") {
+				return []error{errDenied}
+			}
+			evt.SetPayload(strings.ToUpper(evt.Payload().(string)))
+			return next(ctx, evt)
+		}
+	})
+
+	e.On("**", func(evt Event) error {
+		record("listener:" + evt.Payload().(string))
+		return nil
+	})
+
+	if errs := e.EmitSync("user.created", "ada"); len(errs) != 0 {
+		t.Fatalf("EmitSync() = %v, want no error", errs)
+	}
+	for err := range e.Emit("user.deleted", "bob") {
+		t.Fatalf("Emit() error = %v", err)
+	}
+	if errs := e.EmitSync("admin.reset", "all"); len(errs) != 1 || !errors.Is(errs[0], errDenied) {
+		t.Errorf("EmitSync() = %v, want %v from the middleware", errs, errDenied)
+	}
+
+	want := []string{"outer:user.created", "listener:ADA", "outer:user.deleted", "listener:BOB", "outer:admin.reset"}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %v, want %v", trace, want)
+	}
+}
+
+func TestWithBeforeAndAfterEmit(t *testing.T) {
+	errListener := errors.New("listener failed")
+	var trace []string
+	var failures []error
+	e := NewMemoryEmitter(
+		WithBeforeEmit(func(evt Event) {
+			trace = append(trace, "before:"+evt.Topic())
+		}),
+		WithAfterEmit(func(evt Event, errs []error) {
+			trace = append(trace, "after:"+evt.Topic())
+			failures = append(failures, errs...)
+		}),
+	)
+	defer e.Close()
+	e.On("job.*", func(evt Event) error {
+		trace = append(trace, "listener:"+evt.Topic())
+		if evt.Topic() == "job.failed" {
+			return errListener
+		}
+		return nil
+	})
+
+	e.EmitSync("job.done")
+	e.EmitSync("job.failed")
+
+	want := []string{
+		"before:job.done", "listener:job.done", "after:job.done",
+		"before:job.failed", "listener:job.failed", "after:job.failed",
+	}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %v, want %v", trace, want)
+	}
+	if len(failures) != 1 || !errors.Is(failures[0], errListener) {
+		t.Errorf("after hook saw %v, want %v", failures, errListener)
+	}
+}
+
+func TestUseListenerErrors(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+
+	errListener := errors.New("listener failed")
+	var seen []error
+	e.Use(func(next EmitFunc) EmitFunc {
+		return func(ctx context.Context, evt *BaseEvent) []error {
+			errs := next(ctx, evt)
+			seen = append(seen, errs...)
+			return errs
+		}
+	})
+	e.On("job", func(Event) error { return errListener })
+
+	errs := e.EmitSync("job")
+	if len(errs) != 1 || !errors.Is(errs[0], errListener) {
+		t.Errorf("EmitSync() = %v, want %v", errs, errListener)
+	}
+	if len(seen) != 1 || !errors.Is(seen[0], errListener) {
+		t.Errorf("middleware saw %v, want %v", seen, errListener)
+	}
+}
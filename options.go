@@ -44,6 +44,21 @@ func WithIDGenerator(idGen func() string) EmitterOption {
 	}
 }
 
+// WithEventIDGenerator sets an ID generator for the events an Emitter dispatches. Any
+// IDGenerator can be used through its NewID method.
+func WithEventIDGenerator(idGen func() string) EmitterOption {
+	return func(m Emitter) {
+		m.SetEventIDGenerator(idGen)
+	}
+}
+
+// WithClock sets the clock an Emitter uses for event timestamps and delayed emissions.
+func WithClock(clock Clock) EmitterOption {
+	return func(m Emitter) {
+		m.SetClock(clock)
+	}
+}
+
 // WithPool sets a custom pool for an Emitter.
 func WithPool(pool Pool) EmitterOption {
 	return func(m Emitter) {
@@ -51,6 +66,15 @@ func WithPool(pool Pool) EmitterOption {
 	}
 }
 
+// WithPoolFactory sets a function creating the emitter's pool. Unlike WithPool, it lets
+// NewMemoryEmitterFrom give the rebuilt emitter a pool of its own, since closing an
+// emitter releases its pool.
+func WithPoolFactory(factory func() Pool) EmitterOption {
+	return func(m Emitter) {
+		m.SetPoolFactory(factory)
+	}
+}
+
 type PanicHandler func(interface{})
 
 func WithPanicHandler(panicHandler PanicHandler) EmitterOption {
@@ -59,8 +83,216 @@ func WithPanicHandler(panicHandler PanicHandler) EmitterOption {
 	}
 }
 
+// WithPanicPolicy sets how an emission continues after one of its listeners panicked:
+// PanicAbortEmission, the default, PanicRecover or PanicPropagate.
+func WithPanicPolicy(policy PanicPolicy) EmitterOption {
+	return func(m Emitter) {
+		m.SetPanicPolicy(policy)
+	}
+}
+
 func WithErrChanBufferSize(size int) EmitterOption {
 	return func(m Emitter) {
 		m.SetErrChanBufferSize(size)
 	}
 }
+
+// WithEmissionTimeout bounds how long a single emission may keep notifying listeners.
+// Listeners still running when the timeout expires are not interrupted, but the
+// remaining listeners in the chain are skipped.
+func WithEmissionTimeout(timeout time.Duration) EmitterOption {
+	return func(m Emitter) {
+		m.SetEmissionTimeout(timeout)
+	}
+}
+
+// WithListenerBudget limits how many listeners a single emission may notify across all
+// matching topics. Emissions exceeding the budget report ErrListenerBudgetExceeded.
+func WithListenerBudget(budget int) EmitterOption {
+	return func(m Emitter) {
+		m.SetListenerBudget(budget)
+	}
+}
+
+// WithChainLengthWarning calls handler whenever subscribing makes a topic's listener
+// chain longer than length.
+func WithChainLengthWarning(length int, handler func(topic string, length int)) EmitterOption {
+	return func(m Emitter) {
+		m.SetChainLengthWarning(length, handler)
+	}
+}
+
+// WithSilentErrors discards listener errors once the error handler has seen them. Emit
+// then returns an already closed channel and EmitSync returns nil, so emissions skip the
+// error channel and slice allocations entirely. Completion callbacks still receive errors.
+func WithSilentErrors() EmitterOption {
+	return func(m Emitter) {
+		m.SetSilentErrors(true)
+	}
+}
+
+// WithErrorTopic publishes every listener error left by the error handler as an event on
+// the given topic, with a *DispatchError payload, so that errors can be handled by
+// listeners in one place. Errors are still reported to the emitting caller as well.
+func WithErrorTopic(topic string) EmitterOption {
+	return func(m Emitter) {
+		m.SetErrorTopic(topic)
+	}
+}
+
+// WithPanicTopic publishes every panic recovered while dispatching an event on the given
+// topic, with a *ListenerPanic payload carrying the stack trace and the ID of the
+// listener that panicked. The panic handler is still called as well.
+func WithPanicTopic(topic string) EmitterOption {
+	return func(m Emitter) {
+		m.SetPanicTopic(topic)
+	}
+}
+
+// WithLifecycleEvents makes the emitter report changes to its own state on the reserved
+// topics TopicListenerAdded, TopicListenerRemoved, TopicTopicCreated, TopicTopicRemoved
+// and TopicEmitterClosed. They are opt-in to keep subscriptions free of the overhead.
+func WithLifecycleEvents() EmitterOption {
+	return func(m Emitter) {
+		m.SetLifecycleEvents(true)
+	}
+}
+
+// WithListenerSources makes the emitter record the file and line of the code that
+// registered each listener, reported by Listeners and the emitterdebug listeners
+// endpoint. It is meant for debugging, as capturing the call site slows down On.
+func WithListenerSources() EmitterOption {
+	return func(m Emitter) {
+		m.SetListenerSources(true)
+	}
+}
+
+// WithProfilerLabels runs asynchronous emissions with the pprof labels "topic" and
+// "lane", the topic and priority of the event, so that CPU profiles and goroutine dumps
+// attribute the pool's work to topics. Listeners inherit the labels, and the labeled
+// context is the event's context.
+func WithProfilerLabels() EmitterOption {
+	return func(m Emitter) {
+		m.SetProfilerLabels(true)
+	}
+}
+
+// WithWildcardLimit makes On refuse new wildcard patterns with ErrWildcardLimitExceeded
+// once limit of them are registered. Every emission evaluates every wildcard pattern,
+// so their number bounds emit latency.
+func WithWildcardLimit(limit int) EmitterOption {
+	return func(m Emitter) {
+		m.SetWildcardLimit(limit)
+	}
+}
+
+// WithWildcardWarning calls handler whenever a new wildcard pattern brings their number
+// above count.
+func WithWildcardWarning(count int, handler func(pattern string, count int)) EmitterOption {
+	return func(m Emitter) {
+		m.SetWildcardWarning(count, handler)
+	}
+}
+
+// WithMatchCosts times the matching of every emitted subject against each wildcard
+// pattern and reports the totals in TopicStats. Timing adds a little latency to every
+// emission, so it suits diagnosing wildcard-heavy deployments.
+func WithMatchCosts() EmitterOption {
+	return func(m Emitter) {
+		m.SetMatchCosts(true)
+	}
+}
+
+// WithPriorityDecay demotes listeners that repeatedly fail or are slow by one priority
+// level at a time, keeping healthy listeners ahead in the chain. Each demotion is
+// published on TopicListenerDemoted.
+func WithPriorityDecay(policy DecayPolicy) EmitterOption {
+	return func(m Emitter) {
+		m.SetPriorityDecay(&policy)
+	}
+}
+
+// WithScheduleStore persists the emissions scheduled with EmitAfter and EmitAt to store,
+// so that RestoreScheduled can schedule them again after a restart.
+func WithScheduleStore(store ScheduleStore) EmitterOption {
+	return func(m Emitter) {
+		m.SetScheduleStore(store)
+	}
+}
+
+// WithMatchProfile selects the rules matching emitted topics against wildcard patterns,
+// such as MatchStrict or MatchNATS. The default is MatchDefault.
+func WithMatchProfile(profile MatchProfile) EmitterOption {
+	return func(m Emitter) {
+		m.SetMatchProfile(profile)
+	}
+}
+
+// EmitOption defines a function type for configuring a single emission. Emit options are
+// passed among the arguments of Emit and friends and are never part of the payload.
+type EmitOption func(*emitConfig)
+
+// emitConfig holds the settings of a single emission.
+type emitConfig struct {
+	onComplete func(topic string, delivered int, errs []error)
+	release    func() // Returns pooled payloads once the emission finished.
+	force      bool   // Dispatches the emission even if its payload is unchanged.
+	retain     bool   // Keeps the event as the retained event of its topic.
+}
+
+// newEmitConfig applies the emit options to a fresh configuration.
+func newEmitConfig(opts []EmitOption) emitConfig {
+	var cfg emitConfig
+	for _, opt := range opts {
+		applied := cfg // Escapes to the heap only for emissions that carry options.
+		opt(&applied)
+		cfg = applied
+	}
+	return cfg
+}
+
+// splitEmitArgs applies the EmitOption values among args to a fresh configuration and
+// returns the remaining payload arguments. args is returned as is when it holds no options.
+func splitEmitArgs(args []interface{}) ([]interface{}, emitConfig) {
+	var cfg emitConfig
+	payloads := len(args)
+	for _, arg := range args {
+		if opt, ok := arg.(EmitOption); ok {
+			applied := cfg // Escapes to the heap only for emissions that carry options.
+			opt(&applied)
+			cfg = applied
+			payloads--
+		}
+	}
+	if payloads == len(args) {
+		return args, cfg
+	}
+
+	filtered := make([]interface{}, 0, payloads)
+	for _, arg := range args {
+		if _, ok := arg.(EmitOption); !ok {
+			filtered = append(filtered, arg)
+		}
+	}
+	return filtered, cfg
+}
+
+// complete invokes the completion callback, if one was configured, and then releases
+// the emission's pooled payloads, unless the event was retained with them.
+func (cfg emitConfig) complete(topic string, delivered int, errs []error) {
+	if cfg.onComplete != nil {
+		cfg.onComplete(topic, delivered, errs)
+	}
+	if cfg.release != nil && !cfg.retain {
+		cfg.release()
+	}
+}
+
+// WithOnComplete registers a callback invoked once an emission finishes,
+// with the number of listeners that were notified and the errors they reported. It is
+// an alternative to consuming the error channel for fire-and-forget callers.
+func WithOnComplete(onComplete func(topic string, delivered int, errs []error)) EmitOption {
+	return func(cfg *emitConfig) {
+		cfg.onComplete = onComplete
+	}
+}
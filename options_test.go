@@ -2,8 +2,10 @@ package emitter
 
 import (
 	"errors"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 // TestWithErrorHandler tests that the custom error handler is called on error.
@@ -38,7 +40,7 @@ func TestWithErrorHandler(t *testing.T) {
 	}
 
 	// Emit the event synchronously to trigger the error.
-	emitter.EmitSync("testTopic", NewBaseEvent("testTopic", "testPayload"))
+	emitter.EmitEventSync(NewBaseEvent("testTopic", "testPayload"))
 
 	// Check if the custom error handler was called.
 	if !handlerCalled {
@@ -78,7 +80,7 @@ func TestWithErrorHandlerAsync(t *testing.T) {
 	}
 
 	// Emit the event asynchronously to trigger the error.
-	errChan := emitter.Emit("testTopic", NewBaseEvent("testTopic", "testPayload"))
+	errChan := emitter.EmitEvent(NewBaseEvent("testTopic", "testPayload"))
 
 	// Wait for all errors to be processed.
 	for err := range errChan {
@@ -213,3 +215,237 @@ func TestWithIDGenerator(t *testing.T) {
 		t.Fatalf("Expected ID to be '%s', but got '%s'", customID, returnedID)
 	}
 }
+
+func TestWithOnComplete(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	listenerErr := errors.New("listener error")
+	emitter.On("report.*", func(e Event) error { return nil })
+	emitter.On("report.generated", func(e Event) error { return listenerErr })
+
+	type completion struct {
+		topic     string
+		delivered int
+		errs      []error
+	}
+	done := make(chan completion, 1)
+
+	emitter.Emit("report.generated", nil, WithOnComplete(func(topic string, delivered int, errs []error) {
+		done <- completion{topic, delivered, errs}
+	}))
+
+	select {
+	case got := <-done:
+		if got.topic != "report.generated" || got.delivered != 2 {
+			t.Errorf("Completion = (%q, %d), want (report.generated, 2)", got.topic, got.delivered)
+		}
+		if len(got.errs) != 1 || !errors.Is(got.errs[0], listenerErr) {
+			t.Errorf("Completion errors = %v, want [%v]", got.errs, listenerErr)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test timed out waiting for the completion callback")
+	}
+}
+
+func TestWithOnCompleteOnClosedEmitter(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	emitter.Close()
+
+	var gotErrs []error
+	emitter.Emit("report.generated", nil, WithOnComplete(func(topic string, delivered int, errs []error) {
+		gotErrs = errs
+	}))
+
+	if len(gotErrs) != 1 || !errors.Is(gotErrs[0], ErrEmitterClosed) {
+		t.Errorf("Completion errors = %v, want [%v]", gotErrs, ErrEmitterClosed)
+	}
+}
+
+func TestWithSilentErrors(t *testing.T) {
+	var handled []error
+	emitter := NewMemoryEmitter(WithSilentErrors(), WithErrorHandler(func(e Event, err error) error {
+		handled = append(handled, err)
+		return err
+	}))
+
+	listenerErr := errors.New("listener error")
+	emitter.On("job.failed", func(e Event) error { return listenerErr })
+
+	if errs := emitter.EmitSync("job.failed", nil); errs != nil {
+		t.Errorf("EmitSync() = %v, want nil", errs)
+	}
+	if len(handled) != 1 {
+		t.Errorf("Error handler called %d times, want 1", len(handled))
+	}
+
+	done := make(chan []error, 1)
+	errChan := emitter.Emit("job.failed", nil, WithOnComplete(func(topic string, delivered int, errs []error) {
+		done <- errs
+	}))
+	for err := range errChan {
+		t.Errorf("Emit() reported %v, want no errors", err)
+	}
+
+	select {
+	case errs := <-done:
+		if len(errs) != 1 || !errors.Is(errs[0], listenerErr) {
+			t.Errorf("Completion errors = %v, want [%v]", errs, listenerErr)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test timed out waiting for the completion callback")
+	}
+}
+
+func TestWithErrorTopic(t *testing.T) {
+	emitter := NewMemoryEmitter(WithErrorTopic("emitter.errors"), WithErrorHandler(func(e Event, err error) error {
+		if errors.Is(err, ErrEventProcessingAborted) {
+			return nil // Handled errors are not published.
+		}
+		return err
+	}))
+
+	var published []*DispatchError
+	emitter.On("emitter.errors", func(e Event) error {
+		published = append(published, e.Payload().(*DispatchError))
+		return errors.New("error listener failed") // Must not be published again.
+	})
+
+	listenerErr := errors.New("listener error")
+	emitter.On("job.*", func(e Event) error { return listenerErr })
+	emitter.On("job.*", func(e Event) error { return ErrEventProcessingAborted })
+
+	errs := emitter.EmitSync("job.failed", "payload")
+
+	if len(errs) != 1 || !errors.Is(errs[0], listenerErr) {
+		t.Errorf("EmitSync() = %v, want [%v]", errs, listenerErr)
+	}
+	if len(published) != 1 {
+		t.Fatalf("Published %d errors, want 1", len(published))
+	}
+	got := published[0]
+	if got.Topic != "job.failed" || got.Pattern != "job.*" || got.Event.Payload() != "payload" {
+		t.Errorf("DispatchError = %+v, want topic job.failed and pattern job.*", got)
+	}
+	if !errors.Is(got, listenerErr) || got.Error() != "topic job.failed: listener error" {
+		t.Errorf("DispatchError should wrap the listener error, got %q", got.Error())
+	}
+}
+
+func TestWithPanicTopic(t *testing.T) {
+	var handled interface{}
+	emitter := NewMemoryEmitter(WithPanicTopic("emitter.panics"), WithPanicHandler(func(p interface{}) {
+		handled = p
+	}))
+
+	var published []*ListenerPanic
+	emitter.On("emitter.panics", func(e Event) error {
+		published = append(published, e.Payload().(*ListenerPanic))
+		panic("panic listener failed") // Must not be published again.
+	})
+
+	listenerID, _ := emitter.On("job.run", func(e Event) error {
+		panic("boom")
+	})
+
+	emitter.EmitSync("job.run", "payload")
+
+	if len(published) != 1 {
+		t.Fatalf("Published %d panics, want 1", len(published))
+	}
+	got := published[0]
+	if got.Topic != "job.run" || got.ListenerID != listenerID || got.Value != "boom" || got.Event.Payload() != "payload" {
+		t.Errorf("ListenerPanic = %+v, want the panicking listener's details", got)
+	}
+	if !strings.Contains(string(got.Stack), "TestWithPanicTopic") {
+		t.Error("ListenerPanic stack should include the panicking call site")
+	}
+	if handled != "boom" {
+		t.Errorf("Panic handler received %v, want boom", handled)
+	}
+}
+
+func TestWithPanicPolicy(t *testing.T) {
+	tests := []struct {
+		policy    PanicPolicy
+		notified  []string
+		errs      int
+		propagate bool
+	}{
+		{PanicAbortEmission, []string{"first"}, 0, false},
+		{PanicRecover, []string{"first", "last"}, 1, false},
+		{PanicPropagate, []string{"first"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			var handled []interface{}
+			emitter := NewMemoryEmitter(WithPanicPolicy(tt.policy), WithPanicHandler(func(p interface{}) {
+				handled = append(handled, p)
+			}))
+			var notified []string
+			emitter.On("job.run", func(e Event) error {
+				notified = append(notified, "first")
+				panic("boom")
+			}, WithPriority(High))
+			emitter.On("job.run", func(e Event) error {
+				notified = append(notified, "last")
+				return nil
+			}, WithPriority(Low))
+
+			var errs []error
+			var recovered interface{}
+			func() {
+				defer func() { recovered = recover() }()
+				errs = emitter.EmitSync("job.run")
+			}()
+
+			if len(handled) != 1 || handled[0] != "boom" {
+				t.Errorf("Panic handler received %v, want boom once", handled)
+			}
+			if len(notified) != len(tt.notified) {
+				t.Errorf("Notified %v, want %v", notified, tt.notified)
+			}
+			if len(errs) != tt.errs {
+				t.Errorf("EmitSync() errors = %v, want %d", errs, tt.errs)
+			}
+			var listenerPanic *ListenerPanic
+			if tt.errs > 0 && (!errors.As(errs[0], &listenerPanic) || listenerPanic.Value != "boom") {
+				t.Errorf("EmitSync() error = %v, want a *ListenerPanic", errs[0])
+			}
+			if propagated := recovered == "boom"; propagated != tt.propagate {
+				t.Errorf("Recovered %v from EmitSync, want propagated %v", recovered, tt.propagate)
+			}
+		})
+	}
+}
+
+func TestPanicPropagateAsync(t *testing.T) {
+	var handled int
+	emitter := NewMemoryEmitter(WithPanicPolicy(PanicPropagate), WithPanicHandler(func(p interface{}) {
+		handled++
+	}))
+	emitter.On("job.run", func(e Event) error { panic("boom") })
+
+	recovered := make(chan interface{}, 1)
+	emitter.SetPool(recoveringPool{recovered})
+	emitter.Emit("job.run")
+	if r := <-recovered; r != "boom" {
+		t.Errorf("Pool recovered %v, want boom", r)
+	}
+	if handled != 1 {
+		t.Errorf("Panic handler called %d times, want 1", handled)
+	}
+}
+
+// recoveringPool runs tasks synchronously and reports the panics they raise.
+type recoveringPool struct {
+	recovered chan interface{}
+}
+
+func (p recoveringPool) Submit(task func()) {
+	defer func() { p.recovered <- recover() }()
+	task()
+}
+
+func (p recoveringPool) Running() int { return 0 }
+
+func (p recoveringPool) Release() {}
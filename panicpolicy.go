@@ -0,0 +1,51 @@
+package emitter
+
+import "runtime/debug"
+
+// PanicPolicy decides how an emission continues after one of its listeners panicked.
+// Under every policy, the panic is first published on the panic topic, if set, and
+// passed to the panic handler.
+type PanicPolicy int
+
+const (
+	// PanicAbortEmission recovers the panic and ends the emission: the remaining
+	// listeners are not notified. This is the default.
+	PanicAbortEmission PanicPolicy = iota
+	// PanicRecover recovers the panic of each listener and reports it as the listener's
+	// error, a *ListenerPanic, so the remaining listeners are still notified.
+	PanicRecover
+	// PanicPropagate raises the panic again, so that it crashes the program, or reaches
+	// the caller of EmitSync, instead of being absorbed. Pools that recover the panics of
+	// their tasks still absorb those of asynchronous emissions.
+	PanicPropagate
+)
+
+// String returns the name of the policy.
+func (p PanicPolicy) String() string {
+	switch p {
+	case PanicAbortEmission:
+		return "abort-emission"
+	case PanicRecover:
+		return "recover"
+	case PanicPropagate:
+		return "propagate"
+	default:
+		return "unknown"
+	}
+}
+
+// SetPanicPolicy sets how an emission continues after one of its listeners panicked.
+func (m *MemoryEmitter) SetPanicPolicy(policy PanicPolicy) {
+	m.panicPolicy = policy
+}
+
+// callRecovering calls the listener, reporting a panic it raises and returning it as the
+// listener's error.
+func (m *MemoryEmitter) callRecovering(item *listenerItem, event Event, base *BaseEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = m.reportPanic(base, r, debug.Stack())
+		}
+	}()
+	return item.listener(event)
+}
@@ -0,0 +1,162 @@
+package emitter
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// PauseOption configures how PauseTopic holds the events of a paused topic.
+type PauseOption func(*pausedTopic)
+
+// WithPauseBuffer buffers the events emitted while the topic is paused, so that
+// ResumeTopic delivers them, instead of refusing them. Once limit events are buffered,
+// further events are refused; a non-positive limit buffers every event. The completion
+// callback of a buffered emission, and the release of its pooled payloads, wait until
+// ResumeTopic delivered the event, or the emitter was closed.
+func WithPauseBuffer(limit int) PauseOption {
+	return func(p *pausedTopic) {
+		p.buffer, p.limit = true, limit
+	}
+}
+
+// PauseTopic stops delivering the events emitted on topics matching name, which may be
+// a pattern such as "billing.**", without removing any listener. Paused events are
+// refused with ErrTopicPaused, or buffered when WithPauseBuffer is given. Pausing a
+// topic that is already paused replaces its options and keeps its buffered events.
+// Events still buffered when the emitter is closed are dropped, completing their
+// emissions with ErrEmitterClosed.
+func (m *MemoryEmitter) PauseTopic(name string, opts ...PauseOption) {
+	m.pauses.mu.Lock()
+	defer m.pauses.mu.Unlock()
+
+	p := m.pauses.find(name)
+	if p == nil {
+		p = &pausedTopic{pattern: name}
+		m.pauses.topics = append(m.pauses.topics, p)
+		m.pauses.count.Add(1)
+	}
+	p.buffer, p.limit, p.resuming = false, 0, false
+	for _, opt := range opts {
+		opt(p)
+	}
+}
+
+// ResumeTopic resumes delivering the events of a topic paused with PauseTopic, taking
+// the same name. Buffered events are delivered first, in the order they were emitted,
+// on the calling goroutine; events emitted meanwhile are delivered after them. Errors
+// of buffered events go to the error handler and the error topic, if set. Resuming a
+// topic that is not paused does nothing.
+func (m *MemoryEmitter) ResumeTopic(name string) {
+	m.pauses.mu.Lock()
+	p := m.pauses.find(name)
+	if p == nil || p.resuming {
+		m.pauses.mu.Unlock()
+		return
+	}
+	p.resuming = true
+	for {
+		events := p.events
+		p.events = nil
+		if len(events) == 0 {
+			break
+		}
+		m.pauses.mu.Unlock()
+		for _, event := range events {
+			var errs []error
+			m.deliverEvent(event.Context(), event, func(err error) {
+				errs = append(errs, err)
+			})
+			event.completion.complete(event.Topic(), event.deliveredCount(), errs)
+		}
+		m.pauses.mu.Lock()
+		if !p.resuming {
+			m.pauses.mu.Unlock()
+			return // Paused again meanwhile.
+		}
+	}
+	m.pauses.remove(p)
+	m.pauses.mu.Unlock()
+}
+
+// holdPaused buffers or refuses the event if its topic is paused, and reports whether
+// it did.
+func (m *MemoryEmitter) holdPaused(event *BaseEvent, errorHandler func(error)) bool {
+	if m.pauses.count.Load() == 0 {
+		return false
+	}
+	profile := m.topics.load().profile
+
+	m.pauses.mu.Lock()
+	for _, p := range m.pauses.topics {
+		if p.pattern != event.Topic() && !profile.Match(p.pattern, event.Topic()) {
+			continue
+		}
+		if p.buffer && (p.limit <= 0 || len(p.events) < p.limit) {
+			event.held = true
+			p.events = append(p.events, event)
+			m.pauses.mu.Unlock()
+			return true
+		}
+		m.pauses.mu.Unlock()
+		if errorHandler != nil {
+			errorHandler(&EmitError{Op: OpEmit, Topic: event.Topic(), EventID: event.id, Err: ErrTopicPaused})
+		}
+		return true
+	}
+	m.pauses.mu.Unlock()
+	return false
+}
+
+// dropPaused drops the events buffered by paused topics when the emitter is closed,
+// completing their emissions with ErrEmitterClosed.
+func (m *MemoryEmitter) dropPaused() {
+	m.pauses.mu.Lock()
+	var dropped []*BaseEvent
+	for _, p := range m.pauses.topics {
+		dropped = append(dropped, p.events...)
+		p.events = nil
+	}
+	m.pauses.mu.Unlock()
+
+	for _, event := range dropped {
+		err := &EmitError{Op: OpEmit, Topic: event.Topic(), EventID: event.id, Err: ErrEmitterClosed}
+		event.completion.complete(event.Topic(), 0, []error{err})
+	}
+}
+
+// topicPauses holds the topics paused with PauseTopic.
+type topicPauses struct {
+	mu     sync.Mutex
+	topics []*pausedTopic // In the order they were paused.
+	count  atomic.Int32   // Number of paused topics, checked without locking.
+}
+
+// pausedTopic is a topic paused with PauseTopic.
+type pausedTopic struct {
+	pattern  string
+	buffer   bool
+	limit    int
+	events   []*BaseEvent // Buffered events, in the order they were emitted.
+	resuming bool         // Whether ResumeTopic is delivering the buffered events.
+}
+
+// find returns the paused topic with the given name, or nil. Callers must hold p.mu.
+func (p *topicPauses) find(name string) *pausedTopic {
+	for _, paused := range p.topics {
+		if paused.pattern == name {
+			return paused
+		}
+	}
+	return nil
+}
+
+// remove forgets the paused topic. Callers must hold p.mu.
+func (p *topicPauses) remove(paused *pausedTopic) {
+	for i, candidate := range p.topics {
+		if candidate == paused {
+			p.topics = append(p.topics[:i], p.topics[i+1:]...)
+			p.count.Add(-1)
+			return
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPauseTopicRefusesEvents(t *testing.T) {
+	e := NewMemoryEmitter()
+	calls := 0
+	e.On("billing.**", func(Event) error {
+		calls++
+		return nil
+	})
+
+	e.PauseTopic("billing.**")
+	errs := e.EmitSync("billing.invoice.paid")
+	if len(errs) != 1 || !errors.Is(errs[0], ErrTopicPaused) {
+		t.Errorf("EmitSync() on a paused topic = %v, want %v", errs, ErrTopicPaused)
+	}
+	if errs := e.EmitSync("user.created"); len(errs) != 0 {
+		t.Errorf("EmitSync() on another topic = %v, want no error", errs)
+	}
+
+	e.ResumeTopic("billing.**")
+	e.EmitSync("billing.invoice.paid")
+	if calls != 1 {
+		t.Errorf("listener called %d times, want 1 after resuming", calls)
+	}
+}
+
+func TestPauseTopicBuffersEvents(t *testing.T) {
+	e := NewMemoryEmitter()
+	var seen []interface{}
+	e.On("billing.*", func(evt Event) error {
+		seen = append(seen, evt.Payload())
+		if evt.Payload() == 1 {
+			// Emitted while the buffer is delivered, so it comes after it.
+			e.EmitSync("billing.charge", 4)
+		}
+		return nil
+	})
+
+	e.PauseTopic("billing.**", WithPauseBuffer(3))
+	for i := 1; i <= 4; i++ {
+		errs := e.EmitSync("billing.charge", i)
+		if i <= 3 && len(errs) != 0 {
+			t.Errorf("EmitSync(%d) = %v, want the event buffered", i, errs)
+		}
+		if i == 4 && (len(errs) != 1 || !errors.Is(errs[0], ErrTopicPaused)) {
+			t.Errorf("EmitSync(%d) beyond the buffer = %v, want %v", i, errs, ErrTopicPaused)
+		}
+	}
+	if len(seen) != 0 {
+		t.Fatalf("listener saw %v while paused, want nothing", seen)
+	}
+
+	e.ResumeTopic("billing.**")
+	if len(seen) != 4 || seen[0] != 1 || seen[1] != 2 || seen[2] != 3 || seen[3] != 4 {
+		t.Errorf("listener saw %v after resuming, want [1 2 3 4]", seen)
+	}
+
+	e.EmitSync("billing.charge", 5)
+	if len(seen) != 5 {
+		t.Errorf("listener saw %v, want events delivered directly after resuming", seen)
+	}
+}
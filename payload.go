@@ -0,0 +1,65 @@
+package emitter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsNilPayload reports whether the event has no usable payload: either no payload at
+// all, or a typed nil such as a nil pointer, map, slice, channel or function stored in
+// the payload interface.
+func IsNilPayload(evt Event) bool {
+	return isNil(evt.Payload())
+}
+
+// PayloadAs returns the event's payload converted to T. It reports false when the
+// payload is not a T, and also for nil and typed-nil payloads, so that a true result
+// always comes with a usable value. It is the safe alternative to evt.Payload().(T).
+func PayloadAs[T any](evt Event) (T, bool) {
+	var zero T
+	payload := evt.Payload()
+	if isNil(payload) {
+		return zero, false
+	}
+	value, ok := payload.(T)
+	return value, ok
+}
+
+// MustPayload returns the event's payload converted to T. It panics with an error
+// wrapping ErrNilPayload or ErrPayloadTypeMismatch when PayloadAs would report false,
+// naming the topic and the types involved.
+func MustPayload[T any](evt Event) T {
+	value, err := payloadOf[T](evt)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// payloadOf returns the payload of evt converted to T, or an error wrapping
+// ErrNilPayload or ErrPayloadTypeMismatch describing why it cannot be.
+func payloadOf[T any](evt Event) (T, error) {
+	payload := evt.Payload()
+	if isNil(payload) {
+		var zero T
+		return zero, fmt.Errorf("%w: topic %s", ErrNilPayload, evt.Topic())
+	}
+	value, ok := payload.(T)
+	if !ok {
+		return value, fmt.Errorf("%w: topic %s has a %T payload, not %s", ErrPayloadTypeMismatch, evt.Topic(), payload, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return value, nil
+}
+
+// isNil reports whether v is nil or holds a nil value of a nillable kind.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+type payloadOrder struct {
+	ID string
+}
+
+func TestPayloadAs(t *testing.T) {
+	if order, ok := PayloadAs[*payloadOrder](NewBaseEvent("order", &payloadOrder{ID: "o-1"})); !ok || order.ID != "o-1" {
+		t.Errorf("PayloadAs() = %v, %v, want order o-1", order, ok)
+	}
+	if _, ok := PayloadAs[string](NewBaseEvent("order", 42)); ok {
+		t.Error("PayloadAs() should report false for a payload of another type")
+	}
+
+	nilPayloads := []interface{}{nil, (*payloadOrder)(nil), map[string]int(nil), []byte(nil)}
+	for _, payload := range nilPayloads {
+		evt := NewBaseEvent("order", payload)
+		if !IsNilPayload(evt) {
+			t.Errorf("IsNilPayload(%#v) = false, want true", payload)
+		}
+		if _, ok := PayloadAs[*payloadOrder](evt); ok {
+			t.Errorf("PayloadAs(%#v) should report false for nil payloads", payload)
+		}
+	}
+	if IsNilPayload(NewBaseEvent("order", 0)) {
+		t.Error("IsNilPayload() should be false for zero values that are not nil")
+	}
+}
+
+func TestMustPayload(t *testing.T) {
+	if got := MustPayload[string](NewBaseEvent("greeting", "hello")); got != "hello" {
+		t.Errorf("MustPayload() = %q, want hello", got)
+	}
+
+	tests := []struct {
+		payload interface{}
+		want    error
+	}{
+		{(*payloadOrder)(nil), ErrNilPayload},
+		{42, ErrPayloadTypeMismatch},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, tt.want) {
+					t.Errorf("MustPayload(%#v) panicked with %v, want %v", tt.payload, err, tt.want)
+				}
+			}()
+			MustPayload[*payloadOrder](NewBaseEvent("order", tt.payload))
+		}()
+	}
+}
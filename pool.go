@@ -8,6 +8,21 @@ type Pool interface {
 	Release()
 }
 
+// TrySubmitter is implemented by pools that can refuse a task instead of queuing it,
+// which lets the emitter apply backpressure to producers.
+type TrySubmitter interface {
+	// TrySubmit queues the task unless the pool is saturated and reports whether it did.
+	TrySubmit(task func()) bool
+}
+
+// QueueMetrics is implemented by pools that report the state of their task queue.
+type QueueMetrics interface {
+	// Waiting returns the number of queued tasks that have not started yet.
+	Waiting() int
+	// Capacity returns the maximum number of queued tasks, or zero if it is unbounded.
+	Capacity() int
+}
+
 type PondPool struct {
 	pool *pond.WorkerPool
 }
@@ -22,6 +37,21 @@ func (p *PondPool) Submit(task func()) {
 	p.pool.Submit(task)
 }
 
+// TrySubmit queues the task unless the pool's queue is full.
+func (p *PondPool) TrySubmit(task func()) bool {
+	return p.pool.TrySubmit(task)
+}
+
+// Waiting returns the number of tasks waiting in the pool's queue.
+func (p *PondPool) Waiting() int {
+	return int(p.pool.WaitingTasks())
+}
+
+// Capacity returns the maximum number of tasks the pool's queue holds.
+func (p *PondPool) Capacity() int {
+	return p.pool.MaxCapacity()
+}
+
 func (p *PondPool) Running() int {
 	return p.pool.RunningWorkers()
 }
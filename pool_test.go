@@ -106,3 +106,45 @@ func TestEmitMultipleEventsWithPool(t *testing.T) {
 		t.Errorf("Error processing event: %v", processingError)
 	}
 }
+
+func TestPoolTaskPanicIsolation(t *testing.T) {
+	panics := make(chan interface{}, 2)
+	emitter := NewMemoryEmitter(
+		WithPool(NewPondPool(1, 10)),
+		WithPanicHandler(func(p interface{}) { panics <- p }),
+		WithEventIDGenerator(func() string { panic("id generator failed") }),
+	)
+	defer emitter.Close()
+
+	emitter.On("job.run", func(e Event) error { return nil })
+
+	for range emitter.Emit("job.run", nil) {
+	}
+	select {
+	case p := <-panics:
+		if p != "id generator failed" {
+			t.Errorf("Panic handler received %v, want the ID generator panic", p)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Panic outside the listener chain was not reported")
+	}
+
+	emitter.SetEventIDGenerator(nil)
+	errChan := emitter.Emit("job.run", nil, WithOnComplete(func(string, int, []error) {
+		panic("completion callback failed")
+	}))
+	for range errChan {
+	}
+	select {
+	case p := <-panics:
+		if p != "completion callback failed" {
+			t.Errorf("Panic handler received %v, want the completion callback panic", p)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Panic in the completion callback was not reported")
+	}
+
+	if errs := emitter.EmitSync("job.run", nil); len(errs) != 0 {
+		t.Errorf("Emitter should keep working after a panic, got %v", errs)
+	}
+}
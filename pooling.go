@@ -0,0 +1,69 @@
+package emitter
+
+import "sync"
+
+// PayloadPool recycles payloads of type T for high-frequency emissions, such as
+// telemetry samples, so that emitting does not allocate a payload per event. Producers
+// rent a payload with Get, fill it and emit it with the EmitOption returned by Release,
+// which puts the payload back once every listener returned:
+//
+//	samples := emitter.NewPayloadPool(func(s *Sample) { *s = Sample{} })
+//
+//	s := samples.Get()
+//	s.Name, s.Value = "cpu", load
+//	e.Emit("metrics.cpu", s, samples.Release(s))
+//
+// The safety contract is that a pooled payload belongs to the emitter from the emission
+// until it completes. The producer must not touch it after emitting, and listeners must
+// not keep it, or anything it references, once they return: a listener handing the
+// payload to another goroutine, or storing it, must copy it first. This includes
+// passing it on with an asynchronous Emit, which runs after the payload was released.
+// An emission that is refused, for example because the emitter is closed, releases its
+// payload at once.
+type PayloadPool[T any] struct {
+	pool  sync.Pool
+	reset func(*T)
+}
+
+// NewPayloadPool creates a PayloadPool. The reset function, if not nil, clears payloads
+// as they are returned, so that rented payloads never expose earlier values.
+func NewPayloadPool[T any](reset func(*T)) *PayloadPool[T] {
+	return &PayloadPool[T]{
+		pool:  sync.Pool{New: func() interface{} { return new(T) }},
+		reset: reset,
+	}
+}
+
+// Get rents a payload from the pool, allocating one if the pool is empty.
+func (p *PayloadPool[T]) Get() *T {
+	return p.pool.Get().(*T)
+}
+
+// Put returns a payload to the pool. It is called by the emission when Release was used,
+// and may be called directly for payloads that were rented but never emitted.
+func (p *PayloadPool[T]) Put(payload *T) {
+	if payload == nil {
+		return
+	}
+	if p.reset != nil {
+		p.reset(payload)
+	}
+	p.pool.Put(payload)
+}
+
+// Release returns an EmitOption that puts payload back into the pool once the emission
+// completes, after its WithOnComplete callback, if any. Several Release options can be
+// passed to the same emission. Emissions with WithRetained never put their payloads
+// back, since the retained event keeps them for late subscribers and Last; they are left
+// to the garbage collector instead.
+func (p *PayloadPool[T]) Release(payload *T) EmitOption {
+	return func(cfg *emitConfig) {
+		previous := cfg.release
+		cfg.release = func() {
+			if previous != nil {
+				previous()
+			}
+			p.Put(payload)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+type sample struct {
+	Name  string
+	Value float64
+}
+
+func TestPayloadPoolRelease(t *testing.T) {
+	var released []*sample
+	pool := NewPayloadPool(func(s *sample) {
+		released = append(released, s)
+		*s = sample{}
+	})
+	e := NewMemoryEmitter()
+	var seen sample
+	e.On("metrics.cpu", func(evt Event) error {
+		seen = *evt.Payload().(*sample)
+		return nil
+	})
+
+	s := pool.Get()
+	s.Name, s.Value = "cpu", 0.5
+	var completedBeforeRelease bool
+	e.EmitSync("metrics.cpu", s, pool.Release(s), WithOnComplete(func(string, int, []error) {
+		completedBeforeRelease = len(released) == 0
+	}))
+
+	if seen != (sample{Name: "cpu", Value: 0.5}) {
+		t.Errorf("listener saw %+v, want the filled payload", seen)
+	}
+	if len(released) != 1 || released[0] != s || *s != (sample{}) {
+		t.Errorf("released %v, want the reset payload once", released)
+	}
+	if !completedBeforeRelease {
+		t.Error("payload released before the completion callback")
+	}
+}
+
+func TestPayloadPoolReleaseAsync(t *testing.T) {
+	released := make(chan *sample, 2)
+	pool := NewPayloadPool(func(s *sample) { released <- s })
+
+	for _, e := range []*MemoryEmitter{NewMemoryEmitter(), NewMemoryEmitter(WithSilentErrors())} {
+		e.On("metrics.cpu", func(Event) error { return nil })
+		s := pool.Get()
+		for range e.Emit("metrics.cpu", s, pool.Release(s)) {
+		}
+		if got := <-released; got != s {
+			t.Errorf("released %p, want %p", got, s)
+		}
+	}
+}
+
+func TestPayloadPoolReleaseRefused(t *testing.T) {
+	var releases int
+	pool := NewPayloadPool(func(*sample) { releases++ })
+	e := NewMemoryEmitter()
+	e.Close()
+
+	s := pool.Get()
+	e.EmitSync("metrics.cpu", s, pool.Release(s))
+	for range e.Emit("metrics.cpu", s, pool.Release(s)) {
+	}
+	if releases != 2 {
+		t.Errorf("payload released %d times, want 2", releases)
+	}
+}
+
+// BenchmarkEmitPooledPayload measures silent synchronous emission of pooled payloads.
+func BenchmarkEmitPooledPayload(b *testing.B) {
+	pool := NewPayloadPool(func(s *sample) { *s = sample{} })
+	e := NewMemoryEmitter(WithSilentErrors())
+	e.On("metrics.cpu", func(Event) error { return nil })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := pool.Get()
+		s.Value = float64(i)
+		e.EmitSync("metrics.cpu", s, pool.Release(s))
+	}
+}
+
+func TestPayloadPoolReleaseRetained(t *testing.T) {
+	var releases int
+	pool := NewPayloadPool(func(s *sample) {
+		releases++
+		*s = sample{}
+	})
+	e := NewMemoryEmitter()
+
+	s := pool.Get()
+	s.Name, s.Value = "cpu", 0.5
+	e.EmitSync("metrics.cpu", s, pool.Release(s), WithRetained())
+
+	// The retained event keeps its payload rather than handing it back to the pool.
+	if releases != 0 {
+		t.Errorf("payload of a retained event released %d times, want 0", releases)
+	}
+	last, ok := e.Last("metrics.cpu")
+	if !ok || *last.Payload().(*sample) != (sample{Name: "cpu", Value: 0.5}) {
+		t.Errorf("Last() = %v, want the retained payload intact", last)
+	}
+}
+
+func TestPayloadPoolReleasePauseBuffered(t *testing.T) {
+	var releases int
+	pool := NewPayloadPool(func(s *sample) {
+		releases++
+		*s = sample{}
+	})
+	e := NewMemoryEmitter()
+	var seen sample
+	e.On("metrics.cpu", func(evt Event) error {
+		seen = *evt.Payload().(*sample)
+		return nil
+	})
+	var delivered []int
+	var completed [][]error
+	onComplete := WithOnComplete(func(_ string, n int, errs []error) {
+		delivered = append(delivered, n)
+		completed = append(completed, errs)
+	})
+
+	e.PauseTopic("metrics.*", WithPauseBuffer(0))
+	s := pool.Get()
+	s.Name, s.Value = "cpu", 0.5
+	e.EmitSync("metrics.cpu", s, pool.Release(s), onComplete)
+	if releases != 0 || len(delivered) != 0 {
+		t.Fatalf("buffered emission completed %d times and released %d times, want neither before resuming", len(delivered), releases)
+	}
+
+	e.ResumeTopic("metrics.*")
+	if seen != (sample{Name: "cpu", Value: 0.5}) {
+		t.Errorf("listener saw %+v, want the payload intact after resuming", seen)
+	}
+	if releases != 1 || len(delivered) != 1 || delivered[0] != 1 {
+		t.Errorf("released %d times, delivered %v, want one release after one delivery", releases, delivered)
+	}
+
+	// Events still buffered when the emitter is closed are released with it.
+	e.PauseTopic("metrics.*", WithPauseBuffer(0))
+	s = pool.Get()
+	e.EmitSync("metrics.cpu", s, pool.Release(s), onComplete)
+	e.Close()
+	if releases != 2 || len(completed) != 2 || len(completed[1]) != 1 || !errors.Is(completed[1][0], ErrEmitterClosed) {
+		t.Errorf("released %d times, completed with %v, want the dropped event released with %v", releases, completed, ErrEmitterClosed)
+	}
+}
@@ -0,0 +1,75 @@
+package emitter
+
+import "runtime"
+
+// Topics used by the emitters created with NewResilientEmitter.
+const (
+	TopicDeadLetter = "emitter.deadletter" // Unhandled listener errors, as *DispatchError payloads.
+	TopicPanics     = "emitter.panics"     // Recovered listener panics, as *ListenerPanic payloads.
+)
+
+// highThroughputQueue is the number of queued emissions per worker of the pool created
+// by NewHighThroughputEmitter.
+const highThroughputQueue = 1024
+
+// NewStrictEmitter creates an emitter for development and tests that surfaces mistakes
+// early. It combines:
+//
+//   - WithMatchProfile(MatchStrict), so wildcards never match empty or missing segments;
+//   - WithListenerSources, so every listener can be traced to the code registering it;
+//   - WithPanicPolicy(PanicPropagate), so listener panics are raised again once reported
+//     rather than just logged.
+//
+// Options given to it are applied afterwards and can override any of these.
+func NewStrictEmitter(opts ...EmitterOption) *MemoryEmitter {
+	preset := []EmitterOption{
+		WithMatchProfile(MatchStrict),
+		WithListenerSources(),
+		WithPanicPolicy(PanicPropagate),
+	}
+	return NewMemoryEmitter(append(preset, opts...)...)
+}
+
+// NewResilientEmitter creates an emitter for services that must keep going when some
+// listeners misbehave. It combines:
+//
+//   - WithErrorTopic(TopicDeadLetter), acting as a dead-letter queue for unhandled errors;
+//   - WithPanicTopic(TopicPanics), reporting recovered panics as events;
+//   - WithPanicPolicy(PanicRecover), so a panicking listener does not keep the
+//     remaining listeners of an emission from being notified;
+//   - WithPriorityDecay, moving listeners that fail three times in a row behind healthy
+//     ones, much like a circuit breaker keeps them from holding up the chain.
+//
+// The emitter does not retry listeners; subscribe to TopicDeadLetter to retry or park
+// failed events. Options given to it are applied afterwards and can override any of these.
+func NewResilientEmitter(opts ...EmitterOption) *MemoryEmitter {
+	preset := []EmitterOption{
+		WithErrorTopic(TopicDeadLetter),
+		WithPanicTopic(TopicPanics),
+		WithPanicPolicy(PanicRecover),
+		WithPriorityDecay(DecayPolicy{Failures: 3}),
+	}
+	return NewMemoryEmitter(append(preset, opts...)...)
+}
+
+// NewHighThroughputEmitter creates an emitter for heavy asynchronous traffic. It
+// combines:
+//
+//   - WithPoolFactory with a pool of one worker per CPU and a bounded queue, so bursts
+//     do not spawn unbounded goroutines and TryEmit can shed load;
+//   - WithSilentErrors, the allocation-free path that skips error channels.
+//
+// Exact topic names are resolved with a single map lookup, so keeping subscriptions to
+// exact names keeps emissions fast. Observe listener errors with WithErrorTopic or
+// WithOnComplete. Options given to it are applied afterwards and can override any of
+// these.
+func NewHighThroughputEmitter(opts ...EmitterOption) *MemoryEmitter {
+	preset := []EmitterOption{
+		WithPoolFactory(func() Pool {
+			workers := runtime.GOMAXPROCS(0)
+			return NewPondPool(workers, workers*highThroughputQueue)
+		}),
+		WithSilentErrors(),
+	}
+	return NewMemoryEmitter(append(preset, opts...)...)
+}
@@ -0,0 +1,72 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewStrictEmitter(t *testing.T) {
+	e := NewStrictEmitter()
+	called := false
+	e.On("event.*", func(evt Event) error {
+		called = true
+		return nil
+	})
+	e.EmitSync("event.")
+	if called {
+		t.Error("strict emitter matched an empty segment")
+	}
+	if infos := e.Listeners(); len(infos) != 1 || infos[0].Source == "" {
+		t.Errorf("Listeners() = %+v, want a recorded source", infos)
+	}
+
+	e.On("boom", func(evt Event) error { panic("boom") })
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recovered %v, want the listener panic re-raised", r)
+		}
+	}()
+	e.EmitSync("boom")
+}
+
+func TestNewResilientEmitter(t *testing.T) {
+	e := NewResilientEmitter()
+	var dead []*DispatchError
+	e.On(TopicDeadLetter, func(evt Event) error {
+		dead = append(dead, evt.Payload().(*DispatchError))
+		return nil
+	})
+	var demotions int
+	e.On(TopicListenerDemoted, func(evt Event) error {
+		demotions++
+		return nil
+	})
+	e.On("job", func(evt Event) error { return errors.New("failed") })
+
+	for i := 0; i < 3; i++ {
+		e.EmitSync("job")
+	}
+	if len(dead) != 3 || dead[0].Topic != "job" {
+		t.Errorf("dead letters = %v, want 3 for job", dead)
+	}
+	if demotions != 1 {
+		t.Errorf("demotions = %d, want 1 after three failures", demotions)
+	}
+}
+
+func TestNewHighThroughputEmitter(t *testing.T) {
+	e := NewHighThroughputEmitter(WithErrChanBufferSize(1))
+	defer e.Close()
+	if _, ok := e.Pool.(*PondPool); !ok {
+		t.Fatalf("Pool = %T, want a *PondPool", e.Pool)
+	}
+	done := make(chan struct{})
+	e.On("job", func(evt Event) error {
+		close(done)
+		return errors.New("ignored")
+	})
+	for err := range e.Emit("job") {
+		t.Errorf("silent emitter reported %v", err)
+	}
+	<-done
+}
@@ -1,5 +1,11 @@
 package emitter
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // Priority type for listener priority levels.
 type Priority int
 
@@ -10,3 +16,55 @@ const (
 	High
 	Highest
 )
+
+// priorityNames maps the textual form of each priority level to its value.
+var priorityNames = map[string]Priority{
+	"lowest":  Lowest,
+	"low":     Low,
+	"normal":  Normal,
+	"high":    High,
+	"highest": Highest,
+}
+
+// String returns the textual form of the priority level.
+func (p Priority) String() string {
+	for name, priority := range priorityNames {
+		if priority == p {
+			return name
+		}
+	}
+	return "priority(" + strconv.Itoa(int(p)) + ")"
+}
+
+// ParsePriority converts a priority name such as "high" into its Priority value.
+func ParsePriority(name string) (Priority, error) {
+	priority, ok := priorityNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidPriority, name)
+	}
+	return priority, nil
+}
+
+// MarshalText encodes the priority by name, or as a number for levels without a name.
+func (p Priority) MarshalText() ([]byte, error) {
+	for name, priority := range priorityNames {
+		if priority == p {
+			return []byte(name), nil
+		}
+	}
+	return []byte(strconv.Itoa(int(p))), nil
+}
+
+// UnmarshalText decodes a priority encoded by MarshalText.
+func (p *Priority) UnmarshalText(text []byte) error {
+	if n, err := strconv.Atoi(string(text)); err == nil {
+		*p = Priority(n)
+		return nil
+	}
+	priority, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = priority
+	return nil
+}
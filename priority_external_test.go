@@ -0,0 +1,37 @@
+package emitter_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+// TestPriorityOrdering checks if the Emitter calls listeners in the correct order of their priorities.
+func TestPriorityOrdering(t *testing.T) {
+	em := emitter.NewMemoryEmitter()
+	rec := emittertest.NewRecorder()
+	topic := "test_priority_topic"
+
+	// Set up listeners with different priorities.
+	for _, priority := range []emitter.Priority{emitter.High, emitter.Low, emitter.Normal, emitter.Lowest, emitter.Highest} {
+		em.On(topic, rec.Listener(priority.String()), emitter.WithPriority(priority))
+	}
+
+	// Emit an event to the topic and wait for all listeners to process it.
+	em.Emit(topic, "test_payload")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := rec.Wait(ctx, 5); err != nil {
+		t.Fatalf("Wait() failed with error: %v", err)
+	}
+
+	// Verify the call order of listeners matches the expected priority order.
+	if got, want := fmt.Sprint(rec.Labels()), "[highest high normal low lowest]"; got != want {
+		t.Errorf("Call order = %s, want %s", got, want)
+	}
+}
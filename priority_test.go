@@ -1,58 +1,10 @@
 package emitter
 
 import (
-	"sync"
+	"errors"
 	"testing"
 )
 
-// TestPriorityOrdering checks if the Emitter calls listeners in the correct order of their priorities.
-func TestPriorityOrdering(t *testing.T) {
-	em := NewMemoryEmitter()
-
-	var mu sync.Mutex // Mutex to protect access to callOrder slice.
-	topic := "test_priority_topic"
-	callOrder := make([]Priority, 0)
-	var wg sync.WaitGroup // WaitGroup to wait for listeners to finish.
-
-	// Helper function to subscribe to the emitter with synchronization.
-	subscribeWithPriority := func(priority Priority) {
-		wg.Add(1) // Increment the WaitGroup counter.
-		em.On(topic, func(e Event) error {
-			defer wg.Done() // Decrement the counter when the function completes.
-			mu.Lock()       // Lock the mutex to safely append to callOrder.
-			callOrder = append(callOrder, priority)
-			mu.Unlock() // Unlock the mutex after appending.
-			return nil
-		}, WithPriority(priority))
-	}
-
-	// Set up listeners with different priorities.
-	subscribeWithPriority(High)
-	subscribeWithPriority(Low)
-	subscribeWithPriority(Normal)
-	subscribeWithPriority(Lowest)
-	subscribeWithPriority(Highest)
-
-	// Emit an event to the topic
-	em.Emit(topic, "test_payload")
-
-	wg.Wait() // Wait for all listeners to process the event
-
-	// Verify the call order of listeners matches the expected priority order.
-	expectedOrder := []Priority{Highest, High, Normal, Low, Lowest}
-	mu.Lock() // Lock the mutex to safely read callOrder.
-	defer mu.Unlock()
-	if len(callOrder) != len(expectedOrder) {
-		t.Fatalf("Expected call order length to be %d, got %d", len(expectedOrder), len(callOrder))
-	}
-
-	for i, priority := range expectedOrder {
-		if callOrder[i] != priority {
-			t.Errorf("Expected priority %v at index %d, got %v", priority, i, callOrder[i])
-		}
-	}
-}
-
 // TestEmitSyncWithAbort tests the synchronous EmitSync method with a listener that aborts the event.
 func TestEmitSyncWithAbort(t *testing.T) {
 	emitter := NewMemoryEmitter()
@@ -132,3 +84,19 @@ func TestEmitWithAbort(t *testing.T) {
 		t.Errorf("Emit() resulted in errors: %v", emitErrors)
 	}
 }
+
+func TestParsePriority(t *testing.T) {
+	for _, priority := range []Priority{Lowest, Low, Normal, High, Highest} {
+		parsed, err := ParsePriority(priority.String())
+		if err != nil {
+			t.Fatalf("ParsePriority(%q) failed with error: %v", priority.String(), err)
+		}
+		if parsed != priority {
+			t.Errorf("ParsePriority(%q) = %v, want %v", priority.String(), parsed, priority)
+		}
+	}
+
+	if _, err := ParsePriority("urgent"); !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("ParsePriority(%q) error = %v, want %v", "urgent", err, ErrInvalidPriority)
+	}
+}
@@ -0,0 +1,164 @@
+package emitter
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// PrioritySubmitter is implemented by pools that schedule tasks by priority. Emitters
+// submit asynchronous emissions to such pools with the priority of the event, so that
+// higher-priority events are processed first when the pool is busy.
+type PrioritySubmitter interface {
+	// SubmitWithPriority queues the task with the given priority.
+	SubmitWithPriority(task func(), priority Priority)
+	// TrySubmitWithPriority queues the task unless the pool is saturated and reports whether it did.
+	TrySubmitWithPriority(task func(), priority Priority) bool
+}
+
+// PriorityPool wraps a Pool so that queued tasks run in priority order, and in
+// submission order among tasks of the same priority. Each submitted task is paired with
+// a slot in the wrapped pool; when a slot starts, it runs the highest-priority task
+// waiting at that moment rather than the task it was submitted with.
+type PriorityPool struct {
+	pool  Pool
+	mu    sync.Mutex
+	queue priorityQueue
+	seq   uint64 // Submission counter keeping equal priorities in FIFO order.
+}
+
+// NewPriorityPool wraps pool with priority scheduling.
+func NewPriorityPool(pool Pool) *PriorityPool {
+	return &PriorityPool{pool: pool}
+}
+
+// Submit queues the task with Normal priority.
+func (p *PriorityPool) Submit(task func()) {
+	p.SubmitWithPriority(task, Normal)
+}
+
+// SubmitWithPriority queues the task with the given priority.
+func (p *PriorityPool) SubmitWithPriority(task func(), priority Priority) {
+	p.push(task, priority)
+	p.pool.Submit(p.runNext)
+}
+
+// TrySubmit queues the task with Normal priority unless the wrapped pool is saturated.
+func (p *PriorityPool) TrySubmit(task func()) bool {
+	return p.TrySubmitWithPriority(task, Normal)
+}
+
+// TrySubmitWithPriority queues the task unless the wrapped pool is saturated. Pools that
+// cannot refuse tasks always accept it.
+func (p *PriorityPool) TrySubmitWithPriority(task func(), priority Priority) bool {
+	pool, ok := p.pool.(TrySubmitter)
+	if !ok {
+		p.SubmitWithPriority(task, priority)
+		return true
+	}
+
+	// Hold the lock while submitting so that no slot can take the task before it is
+	// known whether the wrapped pool accepted it.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	item := p.pushLocked(task, priority)
+	if pool.TrySubmit(p.runNext) {
+		return true
+	}
+	heap.Remove(&p.queue, item.index)
+	return false
+}
+
+// Running returns the number of running workers of the wrapped pool.
+func (p *PriorityPool) Running() int {
+	return p.pool.Running()
+}
+
+// Waiting returns the number of tasks that have not started yet.
+func (p *PriorityPool) Waiting() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.queue)
+}
+
+// Capacity returns the queue capacity of the wrapped pool, or zero if it does not report one.
+func (p *PriorityPool) Capacity() int {
+	if metrics, ok := p.pool.(QueueMetrics); ok {
+		return metrics.Capacity()
+	}
+	return 0
+}
+
+// Release releases the wrapped pool, which runs the tasks still queued.
+func (p *PriorityPool) Release() {
+	p.pool.Release()
+}
+
+// push adds a task to the queue.
+func (p *PriorityPool) push(task func(), priority Priority) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pushLocked(task, priority)
+}
+
+// pushLocked adds a task to the queue and returns its entry. Callers must hold p.mu.
+func (p *PriorityPool) pushLocked(task func(), priority Priority) *priorityTask {
+	p.seq++
+	item := &priorityTask{task: task, priority: priority, seq: p.seq}
+	heap.Push(&p.queue, item)
+	return item
+}
+
+// runNext runs the highest-priority queued task. It is what the wrapped pool executes.
+func (p *PriorityPool) runNext() {
+	p.mu.Lock()
+	if len(p.queue) == 0 {
+		p.mu.Unlock()
+		return
+	}
+	item := heap.Pop(&p.queue).(*priorityTask)
+	p.mu.Unlock()
+
+	item.task()
+}
+
+// priorityTask is a task waiting in a PriorityPool.
+type priorityTask struct {
+	task     func()
+	priority Priority
+	seq      uint64
+	index    int // Position in the heap, or -1 once removed.
+}
+
+// priorityQueue is a max-heap of tasks ordered by priority, then submission order.
+type priorityQueue []*priorityTask
+
+func (q priorityQueue) Len() int { return len(q) }
+
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q priorityQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *priorityQueue) Push(x interface{}) {
+	item := x.(*priorityTask)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *priorityQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*q = old[:n-1]
+	return item
+}
@@ -0,0 +1,96 @@
+package emitter
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// gatedPool is a single-worker pool whose worker waits for the gate before taking tasks.
+type gatedPool struct {
+	tasks chan func()
+	gate  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func newGatedPool(capacity int) *gatedPool {
+	p := &gatedPool{tasks: make(chan func(), capacity), gate: make(chan struct{})}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		<-p.gate
+		for task := range p.tasks {
+			task()
+		}
+	}()
+	return p
+}
+
+func (p *gatedPool) Submit(task func()) { p.tasks <- task }
+func (p *gatedPool) TrySubmit(task func()) bool {
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
+func (p *gatedPool) Running() int { return 1 }
+func (p *gatedPool) Release()     { close(p.tasks); p.wg.Wait() }
+
+func TestPriorityPoolOrdering(t *testing.T) {
+	inner := newGatedPool(16)
+	pool := NewPriorityPool(inner)
+	emitter := NewMemoryEmitter(WithPool(pool))
+
+	var mu sync.Mutex
+	var order []interface{}
+	emitter.On("job.*", func(e Event) error {
+		mu.Lock()
+		order = append(order, e.Payload())
+		mu.Unlock()
+		return nil
+	})
+
+	emitter.EmitEvent(NewEvent("job.run").WithPayload("low").WithPriority(Low))
+	emitter.Emit("job.run", "normal-1")
+	emitter.EmitEvent(NewEvent("job.run").WithPayload("highest").WithPriority(Highest))
+	emitter.Emit("job.run", "normal-2")
+	emitter.EmitEvent(NewEvent("job.run").WithPayload("high").WithPriority(High))
+
+	if pool.Waiting() != 5 {
+		t.Errorf("Waiting() = %d, want 5", pool.Waiting())
+	}
+	close(inner.gate)
+	if err := emitter.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() failed with error: %v", err)
+	}
+
+	want := []interface{}{"highest", "high", "normal-1", "normal-2", "low"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Processing order = %v, want %v", order, want)
+	}
+}
+
+func TestPriorityPoolTrySubmit(t *testing.T) {
+	inner := newGatedPool(1)
+	pool := NewPriorityPool(inner)
+
+	var ran []string
+	if !pool.TrySubmitWithPriority(func() { ran = append(ran, "first") }, Low) {
+		t.Fatal("TrySubmitWithPriority() should accept a task while the pool has room")
+	}
+	if pool.TrySubmitWithPriority(func() { ran = append(ran, "second") }, High) {
+		t.Fatal("TrySubmitWithPriority() should refuse a task once the pool is saturated")
+	}
+	if pool.Waiting() != 1 {
+		t.Errorf("Waiting() = %d, want the refused task removed", pool.Waiting())
+	}
+
+	close(inner.gate)
+	pool.Release()
+	if !reflect.DeepEqual(ran, []string{"first"}) {
+		t.Errorf("Ran %v, want [first]", ran)
+	}
+}
@@ -0,0 +1,144 @@
+package emitter
+
+// NATSWildcard is the full wildcard of MatchNATS patterns. It matches one or more
+// trailing segments and is only a wildcard as the last segment of a pattern.
+const NATSWildcard = ">"
+
+// MatchProfile selects the semantics used to match emitted topics against patterns.
+// The profiles differ only in edge cases:
+//
+//	                         Default  Lenient  Strict  NATS
+//	"*" matches ""           yes      yes      no      no
+//	"a.*" matches "a."       yes      yes      no      no
+//	"a.**" matches "a"       no       yes      no      -
+//	"a.b.**" matches "a.b"   yes      yes      no      -
+//	"a.>" matches "a"        -        -        -       no
+//	"**" in the middle       yes      yes      yes     literal
+//
+// In MatchNATS, "**" is an ordinary segment and ">" is the trailing wildcard, following
+// NATS subject rules.
+type MatchProfile int
+
+const (
+	// MatchDefault keeps the emitter's historical rules: "*" matches exactly one segment,
+	// even an empty one, and "**" matches any number of segments, except that a trailing
+	// "**" does not match a subject made of only the pattern's first segment.
+	MatchDefault MatchProfile = iota
+	// MatchLenient lets "*" match one segment, even an empty one, and "**" match zero or
+	// more segments anywhere, so "event.**" matches "event".
+	MatchLenient
+	// MatchStrict requires "*" to match exactly one non-empty segment and "**" to match
+	// one or more non-empty segments, so "event.**" never matches "event".
+	MatchStrict
+	// MatchNATS follows NATS subjects: "*" matches exactly one non-empty segment and a
+	// trailing ">" matches one or more of them.
+	MatchNATS
+)
+
+// String returns the name of the profile.
+func (p MatchProfile) String() string {
+	switch p {
+	case MatchDefault:
+		return "default"
+	case MatchLenient:
+		return "lenient"
+	case MatchStrict:
+		return "strict"
+	case MatchNATS:
+		return "nats"
+	default:
+		return "unknown"
+	}
+}
+
+// Match reports whether the topic name matches the pattern under the profile.
+func (p MatchProfile) Match(pattern, topic string) bool {
+	return p.matchSegments(pattern, topic, splitTopic(pattern), splitTopic(topic))
+}
+
+// matchSegments matches pre-split segments under the profile. The default profile
+// uses matchTopicSegments directly to keep the emit hot path unchanged.
+func (p MatchProfile) matchSegments(pattern, subject string, patternParts, subjectParts []string) bool {
+	if p == MatchDefault {
+		return matchTopicSegments(pattern, subject, patternParts, subjectParts)
+	}
+	rules := p.rules()
+	return rules.match(patternParts, subjectParts, 0, 0)
+}
+
+// hasWildcard reports whether any of the pattern segments is a wildcard under the profile.
+func (p MatchProfile) hasWildcard(segments []string) bool {
+	if p != MatchNATS {
+		return hasWildcard(segments)
+	}
+	for i, segment := range segments {
+		if segment == SingleWildcard || (segment == NATSWildcard && i == len(segments)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// rules returns the wildcard rules of the profile. The default profile's exception for
+// a trailing "**" is handled by its callers.
+func (p MatchProfile) rules() matchRules {
+	switch p {
+	case MatchStrict:
+		return matchRules{multi: MultiWildcard, multiMin: 1}
+	case MatchNATS:
+		return matchRules{multi: NATSWildcard, multiMin: 1, trailingOnly: true}
+	default:
+		return matchRules{multi: MultiWildcard, emptySegments: true}
+	}
+}
+
+// matchRules parameterizes segment matching for the profiles.
+type matchRules struct {
+	multi         string // Segment matching many subject segments.
+	multiMin      int    // Minimum number of segments the multi wildcard covers.
+	trailingOnly  bool   // Whether the multi wildcard is only a wildcard as the last segment.
+	emptySegments bool   // Whether wildcards match empty subject segments.
+}
+
+// isMulti reports whether the pattern segment at p is the multi wildcard.
+func (r matchRules) isMulti(patternParts []string, p int) bool {
+	return patternParts[p] == r.multi && (!r.trailingOnly || p == len(patternParts)-1)
+}
+
+// covers reports whether a wildcard may cover the subject segments.
+func (r matchRules) covers(segments []string) bool {
+	if r.emptySegments {
+		return true
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// match reports whether the pattern segments from p match the subject segments from s.
+func (r matchRules) match(patternParts, subjectParts []string, p, s int) bool {
+	if p == len(patternParts) {
+		return s == len(subjectParts)
+	}
+	if r.isMulti(patternParts, p) {
+		for i := s + r.multiMin; i <= len(subjectParts); i++ {
+			if !r.covers(subjectParts[s:i]) {
+				return false // Longer spans include the same empty segment.
+			}
+			if r.match(patternParts, subjectParts, p+1, i) {
+				return true
+			}
+		}
+		return false
+	}
+	if s == len(subjectParts) {
+		return false
+	}
+	if patternParts[p] == SingleWildcard {
+		return r.covers(subjectParts[s:s+1]) && r.match(patternParts, subjectParts, p+1, s+1)
+	}
+	return patternParts[p] == subjectParts[s] && r.match(patternParts, subjectParts, p+1, s+1)
+}
@@ -0,0 +1,97 @@
+package emitter
+
+import (
+	"testing"
+)
+
+func TestMatchProfiles(t *testing.T) {
+	tests := []struct {
+		pattern, topic                string
+		dflt, lenient, strict, natsOK bool
+	}{
+		{"*", "", true, true, false, false},
+		{"*", "event", true, true, true, true},
+		{"event.*", "event.", true, true, false, false},
+		{"event.*", "event.created", true, true, true, true},
+		{"event.*", "event.a.b", false, false, false, false},
+		{"event.**", "event", false, true, false, false},
+		{"event.**", "event.a.b", true, true, true, false},
+		{"a.b.**", "a.b", true, true, false, false},
+		{"a.**.z", "a.z", true, true, false, false},
+		{"a.**.z", "a.b.c.z", true, true, true, false},
+		{"a.**.z", "a.**.z", true, true, true, true},
+		{"**", "a..b", true, true, false, false},
+		{"event.>", "event", false, false, false, false},
+		{"event.>", "event.a.b", false, false, false, true},
+		{"event.>", "event.>", true, true, true, true},
+		{"event.>.x", "event.a.x", false, false, false, false},
+		{"event.created", "event.created", true, true, true, true},
+	}
+	profiles := []MatchProfile{MatchDefault, MatchLenient, MatchStrict, MatchNATS}
+	for _, tt := range tests {
+		want := []bool{tt.dflt, tt.lenient, tt.strict, tt.natsOK}
+		for i, profile := range profiles {
+			if got := profile.Match(tt.pattern, tt.topic); got != want[i] {
+				t.Errorf("%s.Match(%q, %q) = %v, want %v", profile, tt.pattern, tt.topic, got, want[i])
+			}
+			if got := profile.Explain(tt.pattern, tt.topic); got.Matched != want[i] || (got.Reason == "") != want[i] {
+				t.Errorf("%s.Explain(%q, %q) = %+v, want matched %v", profile, tt.pattern, tt.topic, got, want[i])
+			}
+		}
+		if got := MatchTopic(tt.pattern, tt.topic); got != tt.dflt {
+			t.Errorf("MatchTopic(%q, %q) = %v, want %v", tt.pattern, tt.topic, got, tt.dflt)
+		}
+	}
+}
+
+func TestWithMatchProfile(t *testing.T) {
+	tests := []struct {
+		profile MatchProfile
+		pattern string
+		topic   string
+		want    int
+	}{
+		{MatchDefault, "event.**", "event", 0},
+		{MatchLenient, "event.**", "event", 1},
+		{MatchStrict, "event.*", "event.", 0},
+		{MatchNATS, "event.>", "event.a.b", 1},
+		{MatchNATS, "event.**", "event.a.b", 0},
+		{MatchNATS, "event.**", "event.**", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile.String()+" "+tt.pattern+" "+tt.topic, func(t *testing.T) {
+			e := NewMemoryEmitter(WithMatchProfile(tt.profile))
+			calls := 0
+			e.On(tt.pattern, func(evt Event) error {
+				calls++
+				return nil
+			})
+			e.EmitSync(tt.topic)
+			if calls != tt.want {
+				t.Errorf("listener called %d times, want %d", calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMatchProfileReclassifiesPatterns(t *testing.T) {
+	e := NewMemoryEmitter()
+	calls := 0
+	e.On("event.>", func(evt Event) error {
+		calls++
+		return nil
+	})
+	e.EmitSync("event.a")
+	if calls != 0 {
+		t.Fatalf("listener called %d times under the default profile, want 0", calls)
+	}
+
+	e.SetMatchProfile(MatchNATS)
+	e.EmitSync("event.a")
+	if calls != 1 {
+		t.Errorf("listener called %d times under MatchNATS, want 1", calls)
+	}
+	if explanations := e.Explain("event.a"); len(explanations) != 1 || !explanations[0].Matched {
+		t.Errorf("Explain = %+v, want a match under MatchNATS", explanations)
+	}
+}
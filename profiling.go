@@ -0,0 +1,14 @@
+package emitter
+
+import "runtime/pprof"
+
+// Profiler label keys set on asynchronous emissions by WithProfilerLabels.
+const (
+	ProfilerLabelTopic = "topic" // Topic of the emitted event.
+	ProfilerLabelLane  = "lane"  // Priority lane of the emitted event, such as "high".
+)
+
+// profilerLabels returns the pprof labels of the event's emission.
+func profilerLabels(event *BaseEvent) pprof.LabelSet {
+	return pprof.Labels(ProfilerLabelTopic, event.Topic(), ProfilerLabelLane, event.Priority().String())
+}
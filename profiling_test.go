@@ -0,0 +1,28 @@
+package emitter
+
+import (
+	"runtime/pprof"
+	"testing"
+)
+
+func TestWithProfilerLabels(t *testing.T) {
+	e := NewMemoryEmitter(WithProfilerLabels())
+	labels := make(chan [2]string, 1)
+	e.On("order.*", func(evt Event) error {
+		ctx := eventContext(evt)
+		topic, _ := pprof.Label(ctx, ProfilerLabelTopic)
+		lane, _ := pprof.Label(ctx, ProfilerLabelLane)
+		labels <- [2]string{topic, lane}
+		return nil
+	})
+
+	<-e.EmitEvent(NewBaseEvent("order.created", nil).WithPriority(High))
+	if got := <-labels; got != [2]string{"order.created", "high"} {
+		t.Errorf("labels = %v, want topic order.created and lane high", got)
+	}
+
+	e.EmitSync("order.created")
+	if got := <-labels; got != [2]string{} {
+		t.Errorf("synchronous emission labels = %v, want none", got)
+	}
+}
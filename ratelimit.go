@@ -0,0 +1,133 @@
+package emitter
+
+import (
+	"sync"
+	"time"
+)
+
+// WithRateLimit calls the listener at most limit times per second on average, allowing
+// bursts of up to burst calls, as measured on the emitter's clock. Events beyond the
+// limit are dropped without calling the listener, unless WithRateLimitQueue queues them.
+// A non-positive limit disables rate limiting.
+//
+// The limit is a plain float64 rather than a rate.Limit so that the emitter does not
+// depend on golang.org/x/time/rate; callers holding a rate.Limit pass float64(r).
+func WithRateLimit(limit float64, burst int) ListenerOption {
+	return func(item *listenerItem) {
+		item.rateLimit, item.rateBurst = limit, burst
+	}
+}
+
+// WithRateLimitQueue queues up to size events beyond the rate limit set with
+// WithRateLimit, and calls the listener with them in order as the limit allows; events
+// beyond a full queue are dropped. Queued events are delivered after their emission
+// completed, so the listener cannot abort them, and its errors and panics are reported
+// like those of a listener with an Executor. Like batched events, queued events must not
+// be modified after they were emitted, and payloads from a PayloadPool must not be
+// queued.
+func WithRateLimitQueue(size int) ListenerOption {
+	return func(item *listenerItem) {
+		item.rateQueue = size
+	}
+}
+
+// withThrottle wraps the listener so that its calls respect the rate limit set with
+// WithRateLimit, if any. It applies after withHandOff, so that the calls the limiter
+// lets through are still handed to the listener's executor.
+func (m *MemoryEmitter) withThrottle(topicName, listenerID string) ListenerOption {
+	return func(item *listenerItem) {
+		if item.rateLimit <= 0 {
+			return
+		}
+		l := &rateLimiter{
+			emitter:    m,
+			listener:   item.listener,
+			topicName:  topicName,
+			listenerID: listenerID,
+			limit:      item.rateLimit,
+			burst:      float64(max(item.rateBurst, 1)),
+			queueMax:   item.rateQueue,
+		}
+		l.tokens, l.last = l.burst, m.clock.Now()
+		item.listener = l.receive
+	}
+}
+
+// rateLimiter is a token bucket throttling the calls of a listener.
+type rateLimiter struct {
+	emitter    *MemoryEmitter
+	listener   Listener
+	topicName  string
+	listenerID string
+	limit      float64 // Tokens added per second.
+	burst      float64 // Maximum number of tokens.
+	queueMax   int     // Maximum number of queued events; zero drops them instead.
+
+	mu      sync.Mutex
+	tokens  float64
+	last    time.Time // Time tokens were last added.
+	queue   []Event   // Events waiting for a token, oldest first.
+	pending bool      // Whether a drain of the queue is scheduled.
+}
+
+// receive calls the listener with the event if the limit allows it, and otherwise
+// queues or drops the event.
+func (l *rateLimiter) receive(evt Event) error {
+	l.mu.Lock()
+	if len(l.queue) == 0 && l.takeLocked() {
+		l.mu.Unlock()
+		return l.listener(evt)
+	}
+	if len(l.queue) < l.queueMax {
+		l.queue = append(l.queue, boundEvent(evt))
+		l.scheduleLocked()
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+// takeLocked adds the tokens accumulated since the last call and takes one, if any.
+// Callers must hold l.mu.
+func (l *rateLimiter) takeLocked() bool {
+	now := l.emitter.clock.Now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.limit)
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// scheduleLocked schedules a drain of the queue once the next token is available,
+// unless one is already scheduled. Callers must hold l.mu.
+func (l *rateLimiter) scheduleLocked() {
+	if l.pending {
+		return
+	}
+	l.pending = true
+	wait := time.Duration((1 - l.tokens) / l.limit * float64(time.Second))
+	l.emitter.afterFunc(max(wait, 0), l.drain)
+}
+
+// drain calls the listener with the queued events the limit allows, and schedules
+// another drain for the others.
+func (l *rateLimiter) drain() {
+	l.mu.Lock()
+	l.pending = false
+	var ready []Event
+	for len(l.queue) > 0 && l.takeLocked() {
+		ready = append(ready, l.queue[0])
+		l.queue = l.queue[1:]
+	}
+	if len(l.queue) > 0 {
+		l.scheduleLocked()
+	}
+	l.mu.Unlock()
+
+	for _, evt := range ready {
+		l.emitter.callHandedOff(l.listener, evt, l.topicName, l.listenerID)
+	}
+}
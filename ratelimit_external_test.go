@@ -0,0 +1,76 @@
+package emitter_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+	defer em.Close()
+
+	var sent []interface{}
+	em.On("notify", func(evt emitter.Event) error {
+		sent = append(sent, evt.Payload())
+		return nil
+	}, emitter.WithRateLimit(2, 2))
+
+	for i := 1; i <= 4; i++ {
+		em.EmitSync("notify", i)
+	}
+	clock.Advance(500 * time.Millisecond)
+	em.EmitSync("notify", 5)
+	em.EmitSync("notify", 6)
+
+	if want := []interface{}{1, 2, 5}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("listener called with %v, want %v", sent, want)
+	}
+}
+
+func TestWithRateLimitQueue(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	errQuota := errors.New("quota exceeded")
+	var reported []error
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock), emitter.WithErrorHandler(func(_ emitter.Event, err error) error {
+		reported = append(reported, err)
+		return err
+	}))
+	defer em.Close()
+
+	var sent []interface{}
+	em.On("notify", func(evt emitter.Event) error {
+		sent = append(sent, evt.Payload())
+		if evt.Payload() == 3 {
+			return errQuota
+		}
+		return nil
+	}, emitter.WithRateLimit(1, 1), emitter.WithRateLimitQueue(2))
+
+	for i := 1; i <= 4; i++ {
+		if errs := em.EmitSync("notify", i); len(errs) != 0 {
+			t.Errorf("EmitSync(%d) = %v, want no error", i, errs)
+		}
+	}
+	if want := []interface{}{1}; !reflect.DeepEqual(sent, want) {
+		t.Fatalf("listener called with %v before the limit allowed more, want %v", sent, want)
+	}
+
+	clock.Advance(time.Second)
+	clock.Advance(time.Second)
+	clock.Advance(time.Second)
+	em.Flush(context.Background())
+
+	if want := []interface{}{1, 2, 3}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("listener called with %v, want %v with 4 dropped from the full queue", sent, want)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], errQuota) {
+		t.Errorf("error handler received %v, want %v", reported, errQuota)
+	}
+}
@@ -0,0 +1,96 @@
+package emitter
+
+import (
+	"context"
+	"time"
+)
+
+// EventMutator is implemented by events that report whether a mutation was accepted.
+// Listeners registered with WithReadOnlyEvent receive events whose mutators return
+// ErrReadOnlyEvent, while regular events accept every mutation.
+type EventMutator interface {
+	TrySetPayload(payload interface{}) error
+	TrySetAborted(abort bool) error
+}
+
+// readOnlyEvent is the view of an event handed to read-only listeners. Reads are
+// delegated to the underlying event, while SetPayload and SetAborted are no-ops.
+type readOnlyEvent struct {
+	Event
+}
+
+// newReadOnlyEvent wraps an event so that listeners cannot mutate it.
+func newReadOnlyEvent(event Event) *readOnlyEvent {
+	if ro, ok := event.(*readOnlyEvent); ok {
+		return ro
+	}
+	return &readOnlyEvent{Event: event}
+}
+
+// SetPayload ignores the new payload.
+func (e *readOnlyEvent) SetPayload(interface{}) {}
+
+// SetAborted ignores the abort request.
+func (e *readOnlyEvent) SetAborted(bool) {}
+
+// TrySetPayload rejects the new payload with ErrReadOnlyEvent.
+func (e *readOnlyEvent) TrySetPayload(interface{}) error {
+	return ErrReadOnlyEvent
+}
+
+// TrySetAborted rejects the abort request with ErrReadOnlyEvent.
+func (e *readOnlyEvent) TrySetAborted(bool) error {
+	return ErrReadOnlyEvent
+}
+
+// Context returns the emission context of the underlying event.
+func (e *readOnlyEvent) Context() context.Context {
+	if c, ok := e.Event.(interface{ Context() context.Context }); ok {
+		return c.Context()
+	}
+	return context.Background()
+}
+
+// Args returns the arguments of the underlying event.
+func (e *readOnlyEvent) Args() []interface{} {
+	return eventArgs(e.Event)
+}
+
+// ID returns the identifier of the underlying event, if it has one.
+func (e *readOnlyEvent) ID() string {
+	return eventID(e.Event)
+}
+
+// String formats the underlying event like BaseEvent.String.
+func (e *readOnlyEvent) String() string {
+	return formatEvent(e)
+}
+
+// Timestamp returns the emission time of the underlying event, if it has one.
+func (e *readOnlyEvent) Timestamp() time.Time {
+	if stamped, ok := e.Event.(interface{ Timestamp() time.Time }); ok {
+		return stamped.Timestamp()
+	}
+	return time.Time{}
+}
+
+// Metadata returns a copy of the metadata of the underlying event, if it has any.
+func (e *readOnlyEvent) Metadata() map[string]interface{} {
+	if carrier, ok := e.Event.(MetadataCarrier); ok {
+		return carrier.Metadata()
+	}
+	return nil
+}
+
+// MetadataValue returns the metadata value of the underlying event stored under key.
+func (e *readOnlyEvent) MetadataValue(key string) (interface{}, bool) {
+	return LookupMetadata(e.Event, key)
+}
+
+// Priority returns the priority of the underlying event, Normal if it has none.
+func (e *readOnlyEvent) Priority() Priority {
+	if prioritized, ok := e.Event.(interface{ Priority() Priority }); ok {
+		return prioritized.Priority()
+	}
+	return Normal
+}
@@ -0,0 +1,67 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithReadOnlyEvent(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	var observerErrs []error
+	emitter.On("order.created", func(e Event) error {
+		e.SetPayload("tampered")
+		e.SetAborted(true)
+
+		mutator, ok := e.(EventMutator)
+		if !ok {
+			t.Fatal("Read-only event should implement EventMutator")
+		}
+		observerErrs = append(observerErrs, mutator.TrySetPayload("tampered"), mutator.TrySetAborted(true))
+		return nil
+	}, WithPriority(High), WithReadOnlyEvent())
+
+	var seen interface{}
+	emitter.On("order.created", func(e Event) error {
+		seen = e.Payload()
+		return nil
+	}, WithPriority(Low))
+
+	emitter.EmitSync("order.created", "original")
+
+	if seen != "original" {
+		t.Errorf("Downstream listener saw payload %v, want original", seen)
+	}
+	for _, err := range observerErrs {
+		if !errors.Is(err, ErrReadOnlyEvent) {
+			t.Errorf("Mutation error = %v, want %v", err, ErrReadOnlyEvent)
+		}
+	}
+}
+
+func TestBaseEventMutator(t *testing.T) {
+	event := NewBaseEvent("topic", "payload")
+
+	if err := event.TrySetPayload("updated"); err != nil {
+		t.Errorf("TrySetPayload() failed with error: %v", err)
+	}
+	if err := event.TrySetAborted(true); err != nil {
+		t.Errorf("TrySetAborted() failed with error: %v", err)
+	}
+	if event.Payload() != "updated" || !event.IsAborted() {
+		t.Error("BaseEvent mutations should be applied")
+	}
+}
+
+func TestReadOnlyEventReads(t *testing.T) {
+	event := NewBaseEvent("topic", "payload")
+	event.SetAborted(true)
+
+	view := newReadOnlyEvent(event)
+	if view.Topic() != "topic" || view.Payload() != "payload" || !view.IsAborted() {
+		t.Error("Read-only view should expose the underlying event's state")
+	}
+	if newReadOnlyEvent(view) != view {
+		t.Error("Wrapping a read-only view again should return the same view")
+	}
+}
@@ -0,0 +1,267 @@
+package emitter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// topicEntry pairs a registered topic with its pre-split pattern segments.
+type topicEntry struct {
+	pattern  string
+	segments []string
+	wildcard bool // Whether the pattern contains wildcard segments.
+	topic    *Topic
+}
+
+// topicSnapshot is an immutable view of the registered topics. Exact topic names are
+// resolved with a single map lookup, while wildcard patterns are kept in a slice in
+// registration order so the emit hot path is a plain iteration.
+type topicSnapshot struct {
+	entries   map[string]*topicEntry // All entries indexed by their pattern.
+	wildcards []*topicEntry          // Entries whose pattern contains wildcards.
+	profile   MatchProfile           // Rules matching subjects against the wildcard patterns.
+	costs     bool                   // Whether wildcard matching is timed, see WithMatchCosts.
+}
+
+var emptyTopicSnapshot = &topicSnapshot{entries: map[string]*topicEntry{}}
+
+// topicRegistry is a copy-on-write index of topics. Writers serialize on a mutex and
+// atomically publish a new snapshot, so readers never take a lock.
+type topicRegistry struct {
+	mu       sync.Mutex
+	snapshot atomic.Pointer[topicSnapshot]
+	closed   bool // Whether the registry refuses new topics; guarded by mu.
+}
+
+// newTopicRegistry creates an empty topic registry.
+func newTopicRegistry() *topicRegistry {
+	r := &topicRegistry{}
+	r.snapshot.Store(emptyTopicSnapshot)
+	return r
+}
+
+// load returns the current snapshot of the registry.
+func (r *topicRegistry) load() *topicSnapshot {
+	return r.snapshot.Load()
+}
+
+// get returns the topic registered under the exact given name or pattern.
+func (r *topicRegistry) get(name string) (*Topic, bool) {
+	entry, ok := r.load().entries[name]
+	if !ok {
+		return nil, false
+	}
+	return entry.topic, true
+}
+
+// ensure returns the topic registered under the given name, creating and publishing
+// it in a new snapshot if it does not exist yet.
+func (r *topicRegistry) ensure(name string) *Topic {
+	topic, _ := r.ensureCreated(name)
+	return topic
+}
+
+// ensureCreated behaves like ensure and also reports whether the topic was created.
+func (r *topicRegistry) ensureCreated(name string) (*Topic, bool) {
+	topic, created, _ := r.ensureWithin(name, 0)
+	return topic, created
+}
+
+// ensureWithin behaves like ensureCreated, but refuses to create a wildcard pattern
+// when limit is positive and that many wildcard patterns are registered already.
+func (r *topicRegistry) ensureWithin(name string, limit int) (*Topic, bool, error) {
+	if topic, ok := r.get(name); ok {
+		return topic, false, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.load()
+	if entry, ok := current.entries[name]; ok {
+		return entry.topic, false, nil
+	}
+	if r.closed {
+		return nil, false, ErrEmitterClosed
+	}
+
+	topic := NewTopic()
+	topic.Name = name
+	segments := strings.Split(name, ".")
+	entry := &topicEntry{
+		pattern:  name,
+		segments: segments,
+		wildcard: current.profile.hasWildcard(segments),
+		topic:    topic,
+	}
+	if entry.wildcard && limit > 0 && len(current.wildcards) >= limit {
+		return nil, false, fmt.Errorf("%w: %d wildcard patterns registered", ErrWildcardLimitExceeded, len(current.wildcards))
+	}
+
+	next := &topicSnapshot{
+		entries:   make(map[string]*topicEntry, len(current.entries)+1),
+		wildcards: current.wildcards,
+		profile:   current.profile,
+		costs:     current.costs,
+	}
+	for pattern, e := range current.entries {
+		next.entries[pattern] = e
+	}
+	next.entries[name] = entry
+	if entry.wildcard {
+		// Copy before appending so the previous snapshot's slice is never shared.
+		next.wildcards = append(append(make([]*topicEntry, 0, len(current.wildcards)+1), current.wildcards...), entry)
+	}
+
+	r.snapshot.Store(next)
+	return topic, true, nil
+}
+
+// remove removes the topic registered under the exact given name or pattern and returns
+// it, if any.
+func (r *topicRegistry) remove(name string) (*Topic, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.load()
+	removed, ok := current.entries[name]
+	if !ok {
+		return nil, false
+	}
+
+	next := &topicSnapshot{
+		entries: make(map[string]*topicEntry, len(current.entries)-1),
+		profile: current.profile,
+		costs:   current.costs,
+	}
+	for pattern, e := range current.entries {
+		if pattern != name {
+			next.entries[pattern] = e
+		}
+	}
+	next.wildcards = current.wildcards
+	if removed.wildcard {
+		next.wildcards = make([]*topicEntry, 0, len(current.wildcards)-1)
+		for _, e := range current.wildcards {
+			if e != removed {
+				next.wildcards = append(next.wildcards, e)
+			}
+		}
+	}
+
+	r.snapshot.Store(next)
+	return removed.topic, true
+}
+
+// clear removes every topic from the registry and returns the removed topics.
+func (r *topicRegistry) clear() []*Topic {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clearLocked()
+}
+
+// close removes every topic from the registry like clear, and refuses to create topics
+// from then on.
+func (r *topicRegistry) close() []*Topic {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return r.clearLocked()
+}
+
+// clearLocked removes every topic from the registry. Callers must hold r.mu.
+func (r *topicRegistry) clearLocked() []*Topic {
+	current := r.load()
+	topics := make([]*Topic, 0, len(current.entries))
+	for _, entry := range current.entries {
+		topics = append(topics, entry.topic)
+	}
+	if current.profile == MatchDefault && !current.costs {
+		r.snapshot.Store(emptyTopicSnapshot)
+	} else {
+		r.snapshot.Store(&topicSnapshot{entries: map[string]*topicEntry{}, profile: current.profile, costs: current.costs})
+	}
+	return topics
+}
+
+// setProfile switches the rules used to match subjects, reclassifying the registered
+// patterns as exact names or wildcards under the new profile.
+func (r *topicRegistry) setProfile(profile MatchProfile) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.load()
+	next := &topicSnapshot{
+		entries: make(map[string]*topicEntry, len(current.entries)),
+		profile: profile,
+		costs:   current.costs,
+	}
+	for pattern, e := range current.entries {
+		entry := *e
+		entry.wildcard = profile.hasWildcard(entry.segments)
+		next.entries[pattern] = &entry
+	}
+	// Keep wildcard patterns in registration order.
+	for _, e := range current.wildcards {
+		if entry := next.entries[e.pattern]; entry.wildcard {
+			next.wildcards = append(next.wildcards, entry)
+		}
+	}
+	// Patterns that only became wildcards follow, in sorted order.
+	var added []string
+	for pattern, e := range current.entries {
+		if next.entries[pattern].wildcard && !e.wildcard {
+			added = append(added, pattern)
+		}
+	}
+	sort.Strings(added)
+	for _, pattern := range added {
+		next.wildcards = append(next.wildcards, next.entries[pattern])
+	}
+	r.snapshot.Store(next)
+}
+
+// setCosts controls whether matching subjects against wildcard patterns is timed.
+func (r *topicRegistry) setCosts(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := *r.load()
+	next.costs = enabled
+	r.snapshot.Store(&next)
+}
+
+// match calls fn for every topic whose pattern matches the subject. The subject
+// segments are supplied by the caller so they are split only once per emission.
+func (s *topicSnapshot) match(subject string, subjectParts []string, fn func(*Topic)) {
+	if entry, ok := s.entries[subject]; ok && !entry.wildcard {
+		fn(entry.topic)
+	}
+	for _, entry := range s.wildcards {
+		var start time.Time
+		if s.costs {
+			start = time.Now()
+		}
+		matched := s.profile.matchSegments(entry.pattern, subject, entry.segments, subjectParts)
+		if s.costs {
+			entry.topic.matchCost.record(time.Since(start))
+		}
+		if matched {
+			fn(entry.topic)
+		}
+	}
+}
+
+// hasWildcard reports whether any of the segments is a wildcard.
+func hasWildcard(segments []string) bool {
+	for _, segment := range segments {
+		if segment == SingleWildcard || segment == MultiWildcard {
+			return true
+		}
+	}
+	return false
+}
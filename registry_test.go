@@ -0,0 +1,111 @@
+package emitter
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTopicRegistryEnsure(t *testing.T) {
+	registry := newTopicRegistry()
+
+	topic := registry.ensure("event.created")
+	if topic == nil {
+		t.Fatal("ensure() should not return nil")
+	}
+	if topic.Name != "event.created" {
+		t.Errorf("ensure() topic name = %q, want %q", topic.Name, "event.created")
+	}
+	if same := registry.ensure("event.created"); same != topic {
+		t.Error("ensure() should return the existing topic for a known name")
+	}
+	if got, ok := registry.get("event.created"); !ok || got != topic {
+		t.Error("get() should return the topic created by ensure()")
+	}
+}
+
+func TestTopicRegistrySnapshotIsImmutable(t *testing.T) {
+	registry := newTopicRegistry()
+	registry.ensure("event.*")
+
+	before := registry.load()
+	registry.ensure("event.**")
+	registry.ensure("event.created")
+
+	if len(before.entries) != 1 || len(before.wildcards) != 1 {
+		t.Fatalf("previous snapshot was modified: %d entries, %d wildcards", len(before.entries), len(before.wildcards))
+	}
+
+	after := registry.load()
+	if len(after.entries) != 3 || len(after.wildcards) != 2 {
+		t.Fatalf("current snapshot has %d entries and %d wildcards, want 3 and 2", len(after.entries), len(after.wildcards))
+	}
+}
+
+func TestTopicSnapshotMatch(t *testing.T) {
+	registry := newTopicRegistry()
+	for _, pattern := range []string{"event.created", "event.*", "event.**", "other.*"} {
+		registry.ensure(pattern)
+	}
+
+	tests := []struct {
+		subject string
+		want    []string
+	}{
+		{"event.created", []string{"event.created", "event.*", "event.**"}},
+		{"event.deleted.soft", []string{"event.**"}},
+		{"event.*", []string{"event.*", "event.**"}},
+		{"unknown", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			var got []string
+			registry.load().match(tt.subject, splitTopic(tt.subject), func(topic *Topic) {
+				got = append(got, topic.Name)
+			})
+			if len(got) != len(tt.want) {
+				t.Fatalf("match(%q) = %v, want %v", tt.subject, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("match(%q) = %v, want %v", tt.subject, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestTopicRegistryConcurrentEnsure(t *testing.T) {
+	registry := newTopicRegistry()
+
+	var wg sync.WaitGroup
+	topics := make([]*Topic, 50)
+	for i := range topics {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			topics[i] = registry.ensure("shared.topic")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, topic := range topics {
+		if topic != topics[0] {
+			t.Fatal("concurrent ensure() calls should resolve to a single topic")
+		}
+	}
+}
+
+func TestTopicRegistryClear(t *testing.T) {
+	registry := newTopicRegistry()
+	registry.ensure("a")
+	registry.ensure("b.*")
+
+	if removed := registry.clear(); len(removed) != 2 {
+		t.Errorf("clear() removed %d topics, want 2", len(removed))
+	}
+	if _, ok := registry.get("a"); ok {
+		t.Error("get() should not find topics after clear()")
+	}
+}
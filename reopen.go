@@ -0,0 +1,69 @@
+package emitter
+
+// NewMemoryEmitterFrom creates a fresh emitter with the configuration of e, such as its
+// handlers, generators, clock, limits and topic options, followed by opts. It lets
+// long-lived applications that close an emitter during a reload start over without
+// repeating every option. Listeners, topics and links to related emitters are not
+// carried over, and e may be open or closed.
+//
+// An emitter configured with WithPoolFactory gets a new pool from the factory. Without
+// a factory, e's pool is left out, since it belongs to e and closing e releases it: the
+// new emitter runs emissions on their own goroutines unless opts set a pool.
+func NewMemoryEmitterFrom(e *MemoryEmitter, opts ...EmitterOption) *MemoryEmitter {
+	m := NewMemoryEmitter()
+	m.errorHandler = e.errorHandler
+	m.idGenerator = e.idGenerator
+	m.eventIDGenerator = e.eventIDGenerator
+	m.panicHandler = e.panicHandler
+	m.panicPolicy = e.panicPolicy
+	m.errChanBufferSize = e.errChanBufferSize
+	m.emissionTimeout = e.emissionTimeout
+	m.listenerBudget = e.listenerBudget
+	m.profilerLabels = e.profilerLabels
+	m.decay = e.decay
+	m.wildcardLimit = e.wildcardLimit
+	m.wildcardWarnCount, m.wildcardWarnHandler = e.wildcardWarnCount, e.wildcardWarnHandler
+	m.chainWarnLength, m.chainWarnHandler = e.chainWarnLength, e.chainWarnHandler
+	m.clock = e.clock
+	m.silentErrors = e.silentErrors
+	m.errorTopic = e.errorTopic
+	m.panicTopic = e.panicTopic
+	m.lifecycleEvents = e.lifecycleEvents
+	m.listenerSources = e.listenerSources
+	m.schedules.store = e.schedules.store
+	if rules := e.topicConfigs.rules.Load(); rules != nil {
+		for _, rule := range *rules {
+			m.SetTopicConfig(rule.pattern, rule.cfg)
+		}
+	}
+	for _, s := range e.suppressions {
+		m.SetSuppressUnchanged(s.pattern, s.equal)
+	}
+	e.middleware.mu.Lock()
+	if len(e.middleware.list) > 0 {
+		m.Use(e.middleware.list...)
+	}
+	e.middleware.mu.Unlock()
+
+	snapshot := e.topics.load()
+	m.topics.setProfile(snapshot.profile)
+	m.topics.setCosts(snapshot.costs)
+
+	if e.poolFactory != nil {
+		m.SetPoolFactory(e.poolFactory)
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SetPoolFactory sets a function creating the emitter's pool and uses it right away.
+// NewMemoryEmitterFrom calls it again for the new emitter, since Close releases the pool.
+func (m *MemoryEmitter) SetPoolFactory(factory func() Pool) {
+	m.poolFactory = factory
+	if factory != nil {
+		m.Pool = factory()
+	}
+}
@@ -0,0 +1,69 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewMemoryEmitterFrom(t *testing.T) {
+	var handled []error
+	pools := 0
+	e := NewMemoryEmitter(
+		WithErrorHandler(func(evt Event, err error) error {
+			handled = append(handled, err)
+			return nil
+		}),
+		WithIDGenerator(func() string { return "fixed" }),
+		WithPoolFactory(func() Pool {
+			pools++
+			return NewPondPool(2, 10)
+		}),
+		WithMatchProfile(MatchLenient),
+	)
+	e.On("order.**", func(evt Event) error { return errors.New("rejected") })
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewMemoryEmitterFrom(e, WithErrChanBufferSize(3))
+	defer r.Close()
+	if pools != 2 || r.Pool == nil || r.Pool == e.Pool {
+		t.Errorf("rebuilt emitter pool = %v after %d factory calls, want a new pool", r.Pool, pools)
+	}
+	if r.errChanBufferSize != 3 {
+		t.Errorf("errChanBufferSize = %d, want the extra option applied", r.errChanBufferSize)
+	}
+	if _, err := r.GetTopic("order.**"); err == nil {
+		t.Error("listeners of the closed emitter were carried over")
+	}
+
+	id, err := r.On("order.**", func(evt Event) error { return errors.New("rejected") })
+	if err != nil || id != "fixed" {
+		t.Fatalf("On() = %q, %v, want the configured ID generator", id, err)
+	}
+	// The lenient profile lets "order.**" match "order".
+	if errs := r.EmitSync("order"); len(errs) != 0 || len(handled) != 1 {
+		t.Errorf("EmitSync() = %v with %d handled errors, want the configured error handler", errs, len(handled))
+	}
+}
+
+func TestNewMemoryEmitterFromWithoutFactory(t *testing.T) {
+	pool := NewPondPool(2, 10)
+	e := NewMemoryEmitter(WithPool(pool))
+	r := NewMemoryEmitterFrom(e)
+	if r.Pool != nil {
+		t.Error("open emitter's pool was shared")
+	}
+
+	// Closing the old emitter, as a reload does, leaves the new one working.
+	e.Close()
+	handled := make(chan struct{})
+	r.On("order", func(Event) error {
+		close(handled)
+		return nil
+	})
+	if err := <-r.Emit("order"); err != nil {
+		t.Errorf("Emit() after closing the old emitter error = %v", err)
+	}
+	<-handled
+}
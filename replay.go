@@ -0,0 +1,138 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ReplayOption configures a replay started with MemoryEmitter.Replay.
+type ReplayOption func(*replayConfig)
+
+// replayConfig holds the settings of a replay.
+type replayConfig struct {
+	speed     float64                     // Multiplier of the original timing, if positive.
+	rate      float64                     // Fixed number of events per second, if positive.
+	topic     string                      // Topic pattern of the replayed events, if set.
+	transform func(*BaseEvent) *BaseEvent // Rewrites or drops events before they are emitted.
+}
+
+// WithReplaySpeed replays events with their original spacing divided by multiplier:
+// 1 reproduces the original timing, 2 replays twice as fast. By default, and with a
+// multiplier of zero, events are replayed as fast as possible.
+func WithReplaySpeed(multiplier float64) ReplayOption {
+	return func(cfg *replayConfig) {
+		cfg.speed = multiplier
+		cfg.rate = 0
+	}
+}
+
+// WithReplayRate replays events at a fixed number of events per second, regardless of
+// their original timing.
+func WithReplayRate(eventsPerSecond float64) ReplayOption {
+	return func(cfg *replayConfig) {
+		cfg.rate = eventsPerSecond
+		cfg.speed = 0
+	}
+}
+
+// WithReplayTopic replays only the events whose topic matches pattern.
+func WithReplayTopic(pattern string) ReplayOption {
+	return func(cfg *replayConfig) {
+		cfg.topic = pattern
+	}
+}
+
+// WithReplayTransform passes each selected event through transform before it is
+// emitted. The transform may modify the event or return another one; returning nil
+// skips the event.
+func WithReplayTransform(transform func(*BaseEvent) *BaseEvent) ReplayOption {
+	return func(cfg *replayConfig) {
+		cfg.transform = transform
+	}
+}
+
+// Replay reads a journal from r and synchronously emits its events again, keeping their
+// original IDs, timestamps and metadata. Payloads stay encoded, as described for
+// Envelope.Event. Pacing follows WithReplaySpeed or WithReplayRate and waits on the
+// emitter's clock.
+//
+// Listener errors do not stop the replay; they are returned joined together once it
+// completes. Replay stops early when ctx ends, the emitter is closed or the journal
+// cannot be read. It returns the number of emitted events.
+func (m *MemoryEmitter) Replay(ctx context.Context, r io.Reader, opts ...ReplayOption) (int, error) {
+	var cfg replayConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		count      int
+		errs       []error
+		start      time.Time // Time at which the replay emitted its first event.
+		firstStamp time.Time // Original timestamp of the first emitted event.
+	)
+	err := ReadJournal(r, func(env *Envelope) error {
+		if cfg.topic != "" && !MatchTopic(cfg.topic, env.Topic) {
+			return nil
+		}
+		event := env.Event()
+		event.expiresAt = time.Time{} // Replays deliver events that expired since they were recorded.
+		if cfg.transform != nil {
+			if event = cfg.transform(event); event == nil {
+				return nil
+			}
+		}
+
+		if count == 0 {
+			start, firstStamp = m.clock.Now(), event.Timestamp()
+		} else if err := m.waitUntil(ctx, start.Add(cfg.offset(count, event.Timestamp().Sub(firstStamp)))); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if m.closed.Load().(bool) {
+			return ErrEmitterClosed
+		}
+
+		errs = append(errs, m.emitSync(ctx, event, emitConfig{})...)
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, errors.Join(errs...)
+}
+
+// offset returns when the event at index i, emitted elapsed after the first replayed
+// event, is due relative to the start of the replay.
+func (cfg replayConfig) offset(i int, elapsed time.Duration) time.Duration {
+	switch {
+	case cfg.rate > 0:
+		return time.Duration(float64(i) / cfg.rate * float64(time.Second))
+	case cfg.speed > 0 && elapsed > 0:
+		return time.Duration(float64(elapsed) / cfg.speed)
+	default:
+		return 0
+	}
+}
+
+// waitUntil blocks until the emitter's clock reaches at or ctx ends.
+func (m *MemoryEmitter) waitUntil(ctx context.Context, at time.Time) error {
+	delay := at.Sub(m.clock.Now())
+	if delay <= 0 {
+		return nil
+	}
+	done := make(chan struct{})
+	timer := m.clock.AfterFunc(delay, func() { close(done) })
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,133 @@
+package emitter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+var replayStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// recordJournal writes a journal of events emitted at the given offsets on alternating
+// order and user topics.
+func recordJournal(t *testing.T, offsets ...time.Duration) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	clock := emittertest.NewFakeClock(replayStart)
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+	if _, err := em.On("**", emitter.NewJournal(&buf).Listener()); err != nil {
+		t.Fatalf("On() failed with error: %v", err)
+	}
+	topics := []string{"order.created", "user.created"}
+	elapsed := time.Duration(0)
+	for i, offset := range offsets {
+		clock.Advance(offset - elapsed)
+		elapsed = offset
+		em.EmitSync(topics[i%2], i)
+	}
+	return &buf
+}
+
+// replay runs em.Replay, advancing the fake clock a second at a time whenever the
+// replay waits, and returns the replay offset at which each event was received.
+func replay(t *testing.T, journal *bytes.Buffer, opts ...emitter.ReplayOption) ([]time.Duration, []string, error) {
+	t.Helper()
+	clock := emittertest.NewFakeClock(replayStart.Add(time.Hour))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+	var offsets []time.Duration
+	var payloads []string
+	if _, err := em.On("**", func(e emitter.Event) error {
+		offsets = append(offsets, clock.Now().Sub(replayStart.Add(time.Hour)))
+		payloads = append(payloads, string(emitter.MustPayload[json.RawMessage](e)))
+		return nil
+	}); err != nil {
+		t.Fatalf("On() failed with error: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := em.Replay(context.Background(), journal, opts...)
+		done <- err
+	}()
+	for {
+		select {
+		case err := <-done:
+			return offsets, payloads, err
+		default:
+		}
+		if clock.PendingTimers() > 0 {
+			clock.Advance(time.Second)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestReplayPacing(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []emitter.ReplayOption
+		want []time.Duration
+	}{
+		{"as fast as possible", nil, []time.Duration{0, 0, 0}},
+		{"original timing", []emitter.ReplayOption{emitter.WithReplaySpeed(1)}, []time.Duration{0, 10 * time.Second, 30 * time.Second}},
+		{"twice as fast", []emitter.ReplayOption{emitter.WithReplaySpeed(2)}, []time.Duration{0, 5 * time.Second, 15 * time.Second}},
+		{"fixed rate", []emitter.ReplayOption{emitter.WithReplayRate(0.5)}, []time.Duration{0, 2 * time.Second, 4 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			journal := recordJournal(t, 0, 10*time.Second, 30*time.Second)
+			got, _, err := replay(t, journal, tt.opts...)
+			if err != nil {
+				t.Fatalf("Replay() failed with error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replay offsets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayFilterAndTransform(t *testing.T) {
+	journal := recordJournal(t, 0, time.Second, 2*time.Second, 3*time.Second)
+	_, payloads, err := replay(t, journal,
+		emitter.WithReplayTopic("order.*"),
+		emitter.WithReplayTransform(func(e *emitter.BaseEvent) *emitter.BaseEvent {
+			if string(emitter.MustPayload[json.RawMessage](e)) == "0" {
+				return nil
+			}
+			return e.WithPayload(json.RawMessage(`"replayed"`))
+		}))
+	if err != nil {
+		t.Fatalf("Replay() failed with error: %v", err)
+	}
+	if want := []string{`"replayed"`}; !reflect.DeepEqual(payloads, want) {
+		t.Errorf("Replayed payloads = %v, want %v", payloads, want)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	em := emitter.NewMemoryEmitter()
+	errFailed := errors.New("failed")
+	if _, err := em.On("order.*", func(emitter.Event) error { return errFailed }); err != nil {
+		t.Fatalf("On() failed with error: %v", err)
+	}
+
+	count, err := em.Replay(context.Background(), recordJournal(t, 0, time.Second, 2*time.Second))
+	if count != 3 || !errors.Is(err, errFailed) {
+		t.Errorf("Replay() = %d, %v, want 3 events and listener errors", count, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := em.Replay(ctx, recordJournal(t, 0)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Replay() with a canceled context error = %v, want %v", err, context.Canceled)
+	}
+}
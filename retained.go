@@ -0,0 +1,124 @@
+package emitter
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// WithRetained keeps the event as the retained event of its topic, replacing the
+// previous one, like an MQTT retained message. Listeners subscribing afterwards with a
+// pattern matching the topic receive it right away, so that topics carrying the current
+// state, such as "config.updated", need not be emitted again for late subscribers.
+//
+// On delivers the matching retained events as read-only views before it returns, in
+// the order they were retained, and holds back the events emitted meanwhile until they
+// were delivered. Listener errors of retained events go to the error handler and the
+// error topic, if set. Payloads of retained events are not put back into their
+// PayloadPool, as they outlive the emission.
+func WithRetained() EmitOption {
+	return func(cfg *emitConfig) {
+		cfg.retain = true
+	}
+}
+
+// Last returns a read-only view of the retained event of the topic, the last one
+// emitted on it with WithRetained, so that code can read the current state of a topic
+// without subscribing to it. It reports false if the topic has no retained event.
+func (m *MemoryEmitter) Last(topic string) (Event, bool) {
+	m.retained.mu.Lock()
+	event, ok := m.retained.events[topic]
+	m.retained.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return newReadOnlyEvent(event.listenerEvent()), true
+}
+
+// ClearRetained forgets the retained event of the topic, if any, so that listeners
+// subscribing afterwards no longer receive it.
+func (m *MemoryEmitter) ClearRetained(topic string) {
+	m.retained.mu.Lock()
+	defer m.retained.mu.Unlock()
+	delete(m.retained.events, topic)
+}
+
+// retainedEvents holds the events emitted with WithRetained, by topic.
+type retainedEvents struct {
+	mu     sync.Mutex
+	events map[string]*BaseEvent
+	seq    uint64      // Position of the last retained event.
+	used   atomic.Bool // Whether any event was retained, so that On need not check otherwise.
+}
+
+// retain records the event as the retained event of its topic before it is delivered.
+func (m *MemoryEmitter) retain(event *BaseEvent) {
+	m.retained.mu.Lock()
+	defer m.retained.mu.Unlock()
+	if m.retained.events == nil {
+		m.retained.events = make(map[string]*BaseEvent)
+	}
+	m.retained.seq++
+	event.retainSeq = m.retained.seq
+	m.retained.events[event.Topic()] = event
+	m.retained.used.Store(true)
+}
+
+// matchingRetained returns the retained events whose topic matches pattern, in the
+// order they were retained.
+func (m *MemoryEmitter) matchingRetained(pattern string) []*BaseEvent {
+	profile := m.topics.load().profile
+	m.retained.mu.Lock()
+	var events []*BaseEvent
+	for topic, event := range m.retained.events {
+		if topic == pattern || profile.Match(pattern, topic) {
+			events = append(events, event)
+		}
+	}
+	m.retained.mu.Unlock()
+	sort.Slice(events, func(i, j int) bool { return events[i].retainSeq < events[j].retainSeq })
+	return events
+}
+
+// deliverRetained passes the retained events matching the pattern of a new listener to
+// it as read-only views, followed by the events its stream buffered meanwhile. Events
+// the listener receives again after they were delivered as retained, or that an event
+// it was delivered replaced, are dropped. Listener errors go to the error handler and
+// the error topic, if set.
+func (m *MemoryEmitter) deliverRetained(topicName, listenerID string, stream *snapshotStream) {
+	report := func(evt Event, err error) {
+		m.reportDeferredError(evt, topicName, listenerID, err)
+	}
+	events := m.matchingRetained(topicName)
+	if len(events) == 0 {
+		stream.goLive(report)
+		return
+	}
+
+	delivered := make(map[string]uint64, len(events))
+	for _, event := range events {
+		delivered[event.Topic()] = event.retainSeq
+		view := newReadOnlyEvent(event.listenerEvent())
+		if err := stream.listener(view); err != nil {
+			report(view, err)
+		}
+	}
+	skip := func(evt Event) bool {
+		var base *BaseEvent
+		switch e := evt.(type) {
+		case *BaseEvent:
+			base = e
+		case *readOnlyEvent:
+			base, _ = e.Event.(*BaseEvent)
+		}
+		if base == nil || base.retainSeq == 0 {
+			return false
+		}
+		seq, ok := delivered[base.Topic()]
+		return ok && base.retainSeq <= seq
+	}
+	stream.mu.Lock()
+	stream.skip = skip
+	stream.mu.Unlock()
+	stream.goLive(report)
+}
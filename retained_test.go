@@ -0,0 +1,113 @@
+package emitter
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestWithRetained(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+
+	e.EmitSync("config.updated", "v1", WithRetained())
+	e.EmitSync("config.updated", "v2", WithRetained())
+	e.EmitSync("config.reloaded", "not retained")
+	<-e.Emit("feature.toggled", "dark-mode", WithRetained())
+
+	var seen []interface{}
+	if _, err := e.On("config.*", func(evt Event) error {
+		if len(seen) == 0 {
+			if err := evt.(EventMutator).TrySetPayload("changed"); !errors.Is(err, ErrReadOnlyEvent) {
+				t.Errorf("TrySetPayload() on a retained event error = %v, want %v", err, ErrReadOnlyEvent)
+			}
+		}
+		seen = append(seen, evt.Payload())
+		return nil
+	}); err != nil {
+		t.Fatalf("On() error = %v", err)
+	}
+	if want := []interface{}{"v2"}; !reflect.DeepEqual(seen, want) {
+		t.Fatalf("listener received %v on subscribing, want %v", seen, want)
+	}
+
+	e.EmitSync("config.updated", "v3", WithRetained())
+	if want := []interface{}{"v2", "v3"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("listener received %v, want %v", seen, want)
+	}
+
+	e.ClearRetained("config.updated")
+	var late []interface{}
+	e.On("**", func(evt Event) error {
+		late = append(late, evt.Payload())
+		return nil
+	})
+	if want := []interface{}{"dark-mode"}; !reflect.DeepEqual(late, want) {
+		t.Errorf("listener received %v after ClearRetained, want %v", late, want)
+	}
+}
+
+func TestLast(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+
+	if _, ok := e.Last("config.updated"); ok {
+		t.Fatal("Last() reported an event before any was retained")
+	}
+	e.EmitSync("config.updated", "v1", WithRetained())
+	e.EmitSync("config.updated", "v2")
+
+	evt, ok := e.Last("config.updated")
+	if !ok || evt.Payload() != "v1" || evt.Topic() != "config.updated" {
+		t.Fatalf("Last() = %v, %v, want the retained event with payload v1", evt, ok)
+	}
+	evt.SetPayload("changed")
+	if evt, _ := e.Last("config.updated"); evt.Payload() != "v1" {
+		t.Errorf("Last() payload = %v after changing the returned event, want v1", evt.Payload())
+	}
+
+	e.ClearRetained("config.updated")
+	if _, ok := e.Last("config.updated"); ok {
+		t.Error("Last() reported an event after ClearRetained")
+	}
+}
+
+func TestWithRetainedConcurrentSubscribe(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+	e.EmitSync("state", 0, WithRetained())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 200; i++ {
+			e.EmitSync("state", i, WithRetained())
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		var mu sync.Mutex
+		var seen []int
+		id, err := e.On("state", func(evt Event) error {
+			mu.Lock()
+			seen = append(seen, evt.Payload().(int))
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("On() error = %v", err)
+		}
+		mu.Lock()
+		for j := 1; j < len(seen); j++ {
+			if seen[j] <= seen[j-1] {
+				t.Errorf("listener received %v, want increasing states without repeats", seen)
+				break
+			}
+		}
+		mu.Unlock()
+		e.Off("state", id)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,237 @@
+package emitter
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Retention limits how many events a journal keeps. Zero fields impose no limit. When
+// limits apply, the newest events are kept.
+type Retention struct {
+	MaxAge   time.Duration // Events older than this are dropped.
+	MaxCount int           // At most this many events are kept.
+	MaxBytes int64         // Kept events take at most this many bytes of journal.
+}
+
+// TopicRetention overrides the retention of the events whose topic matches Pattern.
+type TopicRetention struct {
+	Pattern string
+	Retention
+}
+
+// RetentionPolicy describes which events compaction keeps in a journal. Events matching
+// one of the Topics patterns are retained by the first matching override, and the limits
+// of each override apply to its events separately. Other events follow the default
+// Retention.
+type RetentionPolicy struct {
+	Retention
+	Topics []TopicRetention
+}
+
+// group returns the index of the override applying to topic, or -1 for the default.
+func (p RetentionPolicy) group(topic string) int {
+	for i, override := range p.Topics {
+		if MatchTopic(override.Pattern, topic) {
+			return i
+		}
+	}
+	return -1
+}
+
+// retention returns the limits of the given group.
+func (p RetentionPolicy) retention(group int) Retention {
+	if group < 0 {
+		return p.Retention
+	}
+	return p.Topics[group].Retention
+}
+
+// CompactionResult reports what a compaction kept and dropped.
+type CompactionResult struct {
+	Kept    int
+	Dropped int
+}
+
+// CompactJournal copies the events of the journal read from r that the policy retains
+// at now to w, in their original order.
+func CompactJournal(r io.Reader, w io.Writer, policy RetentionPolicy, now time.Time) (CompactionResult, error) {
+	type entry struct {
+		line  []byte
+		group int
+		at    time.Time
+	}
+	var entries []entry
+	err := ReadJournal(r, func(env *Envelope) error {
+		line, err := env.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{line: append(line, '\n'), group: policy.group(env.Topic), at: env.Timestamp})
+		return nil
+	})
+	if err != nil {
+		return CompactionResult{}, err
+	}
+
+	// Walk from the newest event back, keeping events while their group is within limits.
+	type usage struct {
+		count int
+		bytes int64
+		full  bool // Whether a limit was reached; older events of the group are dropped.
+	}
+	used := make(map[int]*usage)
+	keep := make([]bool, len(entries))
+	var result CompactionResult
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		u := used[e.group]
+		if u == nil {
+			u = &usage{}
+			used[e.group] = u
+		}
+		limits := policy.retention(e.group)
+		switch {
+		case u.full:
+		case limits.MaxAge > 0 && now.Sub(e.at) > limits.MaxAge:
+		case limits.MaxCount > 0 && u.count >= limits.MaxCount:
+			u.full = true
+		case limits.MaxBytes > 0 && u.bytes+int64(len(e.line)) > limits.MaxBytes:
+			u.full = true
+		default:
+			u.count++
+			u.bytes += int64(len(e.line))
+			keep[i] = true
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for i, e := range entries {
+		if !keep[i] {
+			result.Dropped++
+			continue
+		}
+		if _, err := bw.Write(e.line); err != nil {
+			return result, err
+		}
+		result.Kept++
+	}
+	return result, bw.Flush()
+}
+
+// FileJournal is a Journal appending to a file, which can be compacted in place.
+type FileJournal struct {
+	*Journal
+	path string
+	file *os.File
+}
+
+// OpenFileJournal opens the journal file at path for appending, creating it if needed.
+func OpenFileJournal(path string) (*FileJournal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileJournal{Journal: NewJournal(file), path: path, file: file}, nil
+}
+
+// Path returns the path of the journal file.
+func (j *FileJournal) Path() string {
+	return j.path
+}
+
+// Close closes the journal file.
+func (j *FileJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// Compact rewrites the journal file with only the events the policy retains at now.
+// Appends wait while the file is rewritten.
+func (j *FileJournal) Compact(policy RetentionPolicy, now time.Time) (CompactionResult, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	src, err := os.Open(j.path)
+	if err != nil {
+		return CompactionResult{}, err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".compact-*")
+	if err != nil {
+		return CompactionResult{}, err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed.
+
+	result, err := CompactJournal(src, tmp, policy, now)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if err := j.file.Close(); err != nil {
+		return result, err
+	}
+	renameErr := os.Rename(tmp.Name(), j.path)
+	// Reopen the journal whether or not the rename succeeded, so appends keep working.
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return result, err
+	}
+	j.file, j.w = file, file
+	return result, renameErr
+}
+
+// compactionTimer is the Timer of a recurring compaction.
+type compactionTimer struct {
+	mu      sync.Mutex
+	timer   Timer
+	stopped bool
+}
+
+// Stop cancels the upcoming compactions. It reports whether compactions were still scheduled.
+func (t *compactionTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	t.timer.Stop()
+	return true
+}
+
+// ScheduleCompaction compacts the journal with the policy every interval on the
+// emitter's clock, until the returned Timer is stopped or the emitter is closed.
+// Compaction errors are passed to onError, if set.
+func (m *MemoryEmitter) ScheduleCompaction(j *FileJournal, policy RetentionPolicy, interval time.Duration, onError func(error)) (Timer, error) {
+	if interval <= 0 {
+		return nil, &ConfigError{Op: "ScheduleCompaction", Err: ErrInvalidInterval}
+	}
+	t := &compactionTimer{}
+	var run func()
+	run = func() {
+		if m.closed.Load().(bool) {
+			return
+		}
+		if _, err := j.Compact(policy, m.clock.Now()); err != nil && onError != nil {
+			onError(err)
+		}
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if !t.stopped {
+			t.timer = m.afterFunc(interval, run)
+		}
+	}
+	t.mu.Lock()
+	t.timer = m.afterFunc(interval, run)
+	t.mu.Unlock()
+	return t, nil
+}
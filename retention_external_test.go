@@ -0,0 +1,126 @@
+package emitter_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+// journalTopics returns the topics of the journal's events, in order.
+func journalTopics(t *testing.T, journal []byte) []string {
+	t.Helper()
+	var topics []string
+	if err := emitter.ReadJournal(bytes.NewReader(journal), func(env *emitter.Envelope) error {
+		topics = append(topics, env.Topic)
+		return nil
+	}); err != nil {
+		t.Fatalf("ReadJournal() failed with error: %v", err)
+	}
+	return topics
+}
+
+func TestCompactJournal(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var buf bytes.Buffer
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+	em.On("**", emitter.NewJournal(&buf).Listener())
+	for _, topic := range []string{"audit.login", "metrics.cpu", "metrics.cpu", "order.created", "metrics.cpu", "audit.logout", "order.paid"} {
+		em.EmitSync(topic, topic)
+		clock.Advance(time.Minute)
+	}
+
+	tests := []struct {
+		name   string
+		policy emitter.RetentionPolicy
+		want   []string
+	}{
+		{"no limits", emitter.RetentionPolicy{}, []string{"audit.login", "metrics.cpu", "metrics.cpu", "order.created", "metrics.cpu", "audit.logout", "order.paid"}},
+		{"max age", emitter.RetentionPolicy{Retention: emitter.Retention{MaxAge: 3 * time.Minute}}, []string{"metrics.cpu", "audit.logout", "order.paid"}},
+		{"max count", emitter.RetentionPolicy{Retention: emitter.Retention{MaxCount: 2}}, []string{"audit.logout", "order.paid"}},
+		{"topic overrides", emitter.RetentionPolicy{
+			Retention: emitter.Retention{MaxCount: 2},
+			Topics: []emitter.TopicRetention{
+				{Pattern: "audit.*"},
+				{Pattern: "metrics.*", Retention: emitter.Retention{MaxCount: 1}},
+			},
+		}, []string{"audit.login", "order.created", "metrics.cpu", "audit.logout", "order.paid"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			result, err := emitter.CompactJournal(bytes.NewReader(buf.Bytes()), &out, tt.policy, clock.Now())
+			if err != nil {
+				t.Fatalf("CompactJournal() failed with error: %v", err)
+			}
+			if got := journalTopics(t, out.Bytes()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Kept topics = %v, want %v", got, tt.want)
+			}
+			if result.Kept != len(tt.want) || result.Dropped != 7-len(tt.want) {
+				t.Errorf("Result = %+v, want %d kept", result, len(tt.want))
+			}
+		})
+	}
+
+	// The byte limit keeps the newest events that fit.
+	lines := bytes.SplitAfter(buf.Bytes(), []byte("\n"))
+	limit := int64(len(lines[5]) + len(lines[6]))
+	var out bytes.Buffer
+	policy := emitter.RetentionPolicy{Retention: emitter.Retention{MaxBytes: limit}}
+	if _, err := emitter.CompactJournal(bytes.NewReader(buf.Bytes()), &out, policy, clock.Now()); err != nil {
+		t.Fatalf("CompactJournal() failed with error: %v", err)
+	}
+	if got := journalTopics(t, out.Bytes()); !reflect.DeepEqual(got, []string{"audit.logout", "order.paid"}) {
+		t.Errorf("Kept topics with a byte limit = %v", got)
+	}
+}
+
+func TestScheduleCompaction(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	journal, err := emitter.OpenFileJournal(path)
+	if err != nil {
+		t.Fatalf("OpenFileJournal() failed with error: %v", err)
+	}
+	defer journal.Close()
+	em.On("**", journal.Listener())
+
+	policy := emitter.RetentionPolicy{Retention: emitter.Retention{MaxAge: 90 * time.Second}}
+	if _, err := em.ScheduleCompaction(journal, policy, time.Minute, func(err error) {
+		t.Errorf("Compaction failed with error: %v", err)
+	}); err != nil {
+		t.Fatalf("ScheduleCompaction() failed with error: %v", err)
+	}
+
+	em.EmitSync("a")
+	clock.Advance(time.Minute)
+	em.EmitSync("b")
+	clock.Advance(time.Minute) // Compacts at 2m: a is 2m old and dropped.
+	em.EmitSync("c")           // Appends keep working after the rewrite.
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed with error: %v", err)
+	}
+	if got := journalTopics(t, data); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("Journal topics = %v, want [b c]", got)
+	}
+
+	// Closing the emitter ends the schedule.
+	em.Close()
+	clock.Advance(time.Minute)
+	if clock.PendingTimers() != 0 {
+		t.Errorf("PendingTimers() = %d after Close, want 0", clock.PendingTimers())
+	}
+
+	if _, err := em.ScheduleCompaction(journal, policy, 0, nil); !errors.Is(err, emitter.ErrInvalidInterval) {
+		t.Errorf("ScheduleCompaction() with a zero interval error = %v, want %v", err, emitter.ErrInvalidInterval)
+	}
+}
@@ -0,0 +1,189 @@
+package emitter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// RoutingTableVersion is the version of the routing table format written by ExportRoutes.
+const RoutingTableVersion = 1
+
+// RoutingTable describes the listeners of an emitter in a form that configuration tools
+// can store and edit. Its JSON encoding is:
+//
+//	{
+//	  "version": 1,
+//	  "routes": [
+//	    {
+//	      "id": "8f1c…",
+//	      "pattern": "order.*",
+//	      "handler": "audit",
+//	      "group": "billing",
+//	      "priority": "high",
+//	      "options": {"readOnly": true, "canAbort": false, "maxCalls": 3}
+//	    }
+//	  ]
+//	}
+//
+// Handlers are referenced by the names they are registered under in a HandlerRegistry.
+type RoutingTable struct {
+	Version int     `json:"version"`
+	Routes  []Route `json:"routes"`
+}
+
+// Route describes a single listener.
+type Route struct {
+	ID       string       `json:"id,omitempty"`    // Listener ID; informational, ignored by ImportRoutes.
+	Pattern  string       `json:"pattern"`         // Topic name or wildcard pattern.
+	Handler  string       `json:"handler"`         // Handler name; empty for listeners registered without WithHandlerName.
+	Group    string       `json:"group,omitempty"` // Group set with WithGroup.
+	Priority Priority     `json:"priority"`        // Listener priority; zero means Normal.
+	Options  RouteOptions `json:"options"`         // Other listener options.
+}
+
+// RouteOptions holds the listener options a route carries besides its priority and group.
+type RouteOptions struct {
+	ReadOnly bool  `json:"readOnly,omitempty"` // WithReadOnlyEvent.
+	Cloned   bool  `json:"cloned,omitempty"`   // WithClonedEvent.
+	CanAbort *bool `json:"canAbort,omitempty"` // WithCanAbort, if set.
+	MaxCalls int   `json:"maxCalls,omitempty"` // WithMaxCalls.
+}
+
+// listenerOptions converts the route into listener options.
+func (r Route) listenerOptions() []ListenerOption {
+	priority := r.Priority
+	if priority == 0 {
+		priority = Normal
+	}
+	opts := []ListenerOption{WithPriority(priority), WithHandlerName(r.Handler)}
+	if r.Group != "" {
+		opts = append(opts, WithGroup(r.Group))
+	}
+	if r.Options.ReadOnly {
+		opts = append(opts, WithReadOnlyEvent())
+	}
+	if r.Options.Cloned {
+		opts = append(opts, WithClonedEvent())
+	}
+	if r.Options.CanAbort != nil {
+		opts = append(opts, WithCanAbort(*r.Options.CanAbort))
+	}
+	if r.Options.MaxCalls > 0 {
+		opts = append(opts, WithMaxCalls(r.Options.MaxCalls))
+	}
+	return opts
+}
+
+// route describes the listener item as a route. Callers must hold t.mu.
+func (item *listenerItem) route(id, pattern string) Route {
+	r := Route{
+		ID:       id,
+		Pattern:  pattern,
+		Handler:  item.handler,
+		Group:    item.group,
+		Priority: item.priority,
+		Options: RouteOptions{
+			ReadOnly: item.readOnly,
+			Cloned:   item.cloned,
+			MaxCalls: int(item.maxCalls),
+		},
+	}
+	if item.canAbort != abortDefault {
+		canAbort := item.canAbort == abortAllowed
+		r.Options.CanAbort = &canAbort
+	}
+	return r
+}
+
+// ExportRoutes returns the emitter's listeners as a routing table, ordered by pattern and
+// then by priority. Expiry settings and callbacks are not part of routes, and internal
+// listeners of related emitters are left out.
+func (m *MemoryEmitter) ExportRoutes() RoutingTable {
+	table := RoutingTable{Version: RoutingTableVersion, Routes: []Route{}}
+	m.eachListener(func(id, pattern string, item *listenerItem) {
+		table.Routes = append(table.Routes, item.route(id, pattern))
+	})
+	return table
+}
+
+// eachListener calls fn for every listener, ordered by pattern and then by priority.
+// Internal listeners of related emitters are skipped. fn runs with the topic's read lock
+// held.
+func (m *MemoryEmitter) eachListener(fn func(id, pattern string, item *listenerItem)) {
+	snapshot := m.topics.load()
+	patterns := make([]string, 0, len(snapshot.entries))
+	for pattern := range snapshot.entries {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		topic := snapshot.entries[pattern].topic
+		topic.mu.RLock()
+		for _, id := range topic.sortedListenerIDs {
+			if item := topic.listeners[id]; !item.envelope {
+				fn(id, pattern, item)
+			}
+		}
+		topic.mu.RUnlock()
+	}
+}
+
+// ImportRoutes subscribes the handlers of the routing table's routes, looked up in
+// handlers by name. Routes replace the listeners already subscribed to their pattern
+// with the same handler name, so importing an edited table applies the changes. Other
+// listeners are left untouched.
+//
+// The whole table is validated first: if a route has an invalid pattern or references an
+// unregistered handler, no route is imported and the returned error lists every problem.
+func (m *MemoryEmitter) ImportRoutes(table RoutingTable, handlers *HandlerRegistry) error {
+	if table.Version != 0 && table.Version != RoutingTableVersion {
+		return &ConfigError{Op: "ImportRoutes", Err: fmt.Errorf("%w: unsupported version %d", ErrInvalidRoutes, table.Version)}
+	}
+
+	listeners := make([]Listener, len(table.Routes))
+	var errs []error
+	for i, route := range table.Routes {
+		if !isValidTopicName(route.Pattern) {
+			errs = append(errs, fmt.Errorf("route %d: %w: %q", i, ErrInvalidTopicName, route.Pattern))
+		}
+		handler, ok := handlers.Lookup(route.Handler)
+		if !ok {
+			errs = append(errs, fmt.Errorf("route %d: %w: %q", i, ErrHandlerNotFound, route.Handler))
+		}
+		listeners[i] = handler
+	}
+	if len(errs) > 0 {
+		return &ConfigError{Op: "ImportRoutes", Err: fmt.Errorf("%w: %w", ErrInvalidRoutes, errors.Join(errs...))}
+	}
+
+	for _, route := range table.Routes {
+		m.offHandler(route.Pattern, route.Handler)
+	}
+	for i, route := range table.Routes {
+		if _, err := m.On(route.Pattern, listeners[i], route.listenerOptions()...); err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// offHandler unsubscribes the listeners registered on pattern under the handler name.
+func (m *MemoryEmitter) offHandler(pattern, handler string) {
+	topic, ok := m.topics.get(pattern)
+	if !ok {
+		return
+	}
+	var ids []string
+	topic.mu.RLock()
+	for id, item := range topic.listeners {
+		if item.handler == handler {
+			ids = append(ids, id)
+		}
+	}
+	topic.mu.RUnlock()
+	for _, id := range ids {
+		_ = m.Off(pattern, id)
+	}
+}
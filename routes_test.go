@@ -0,0 +1,82 @@
+package emitter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExportImportRoutes(t *testing.T) {
+	handlers := NewHandlerRegistry()
+	var calls []string
+	for _, name := range []string{"audit", "billing"} {
+		name := name
+		handlers.Register(name, func(Event) error {
+			calls = append(calls, name)
+			return nil
+		})
+	}
+	audit, _ := handlers.Lookup("audit")
+	billing, _ := handlers.Lookup("billing")
+
+	source := NewMemoryEmitter()
+	source.On("order.*", audit, WithHandlerName("audit"), WithGroup("compliance"), WithReadOnlyEvent(), WithCanAbort(false))
+	source.On("order.created", billing, WithHandlerName("billing"), WithPriority(High), WithMaxCalls(3))
+	source.On("order.created", func(Event) error { return nil }) // Unnamed listeners are exported without a handler.
+
+	table := source.ExportRoutes()
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatalf("Marshal() failed with error: %v", err)
+	}
+	var decoded RoutingTable
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() failed with error: %v", err)
+	}
+
+	canAbort := false
+	want := []Route{
+		{Pattern: "order.*", Handler: "audit", Group: "compliance", Priority: Normal, Options: RouteOptions{ReadOnly: true, CanAbort: &canAbort}},
+		{Pattern: "order.created", Handler: "billing", Priority: High, Options: RouteOptions{MaxCalls: 3}},
+		{Pattern: "order.created", Priority: Normal},
+	}
+	for i := range decoded.Routes {
+		decoded.Routes[i].ID = ""
+	}
+	if decoded.Version != RoutingTableVersion || !reflect.DeepEqual(decoded.Routes, want) {
+		t.Fatalf("Exported routes = %+v, want %+v", decoded.Routes, want)
+	}
+
+	// Routes without a registered handler make the whole import fail.
+	target := NewMemoryEmitter()
+	if err := target.ImportRoutes(decoded, handlers); !errors.Is(err, ErrHandlerNotFound) || !errors.Is(err, ErrInvalidRoutes) {
+		t.Errorf("ImportRoutes() error = %v, want %v", err, ErrHandlerNotFound)
+	}
+	if routes := target.ExportRoutes().Routes; len(routes) != 0 {
+		t.Errorf("Failed import subscribed %d routes, want 0", len(routes))
+	}
+
+	decoded.Routes = decoded.Routes[:2]
+	if err := target.ImportRoutes(decoded, handlers); err != nil {
+		t.Fatalf("ImportRoutes() failed with error: %v", err)
+	}
+	target.EmitSync("order.created")
+	if want := []string{"billing", "audit"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("Listener calls = %v, want %v", calls, want)
+	}
+
+	// Importing an edited table replaces the listeners of the same handlers.
+	decoded.Routes[1].Priority = Lowest
+	if err := target.ImportRoutes(decoded, handlers); err != nil {
+		t.Fatalf("ImportRoutes() failed with error: %v", err)
+	}
+	routes := target.ExportRoutes().Routes
+	if len(routes) != 2 || routes[1].Priority != Lowest {
+		t.Errorf("Routes after re-import = %+v, want 2 routes with billing at lowest priority", routes)
+	}
+
+	if err := target.ImportRoutes(RoutingTable{Version: 2}, handlers); !errors.Is(err, ErrInvalidRoutes) {
+		t.Errorf("ImportRoutes() of an unknown version error = %v, want %v", err, ErrInvalidRoutes)
+	}
+}
@@ -0,0 +1,150 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Run ties the emitter's lifetime to ctx, fitting services built with errgroup or
+// oklog/run. It blocks until ctx is done and then closes the emitter: the background work
+// it owns, such as delayed emissions, watchdogs, scheduled compactions and listener
+// expiries, is stopped, and its pool is released once queued emissions completed.
+//
+// Run returns nil after a clean shutdown, including when the emitter is closed by other
+// means while it runs, or the error of Close otherwise. It returns ErrEmitterClosed at
+// once if the emitter is already closed.
+func (m *MemoryEmitter) Run(ctx context.Context) error {
+	return m.run(ctx, m.Close)
+}
+
+// run waits for ctx or for the emitter to close, and shuts the emitter down with close.
+func (m *MemoryEmitter) run(ctx context.Context, close func() error) error {
+	if m.closed.Load().(bool) {
+		return ErrEmitterClosed
+	}
+	select {
+	case <-ctx.Done():
+	case <-m.background.done():
+		return nil
+	}
+	if err := close(); err != nil && !errors.Is(err, ErrEmitterAlreadyClosed) {
+		return err
+	}
+	return nil
+}
+
+// backgroundWork tracks the background work of an emitter so that Close can stop it.
+// Its zero value is ready to use.
+type backgroundWork struct {
+	mu      sync.Mutex
+	next    int
+	stops   map[int]func()
+	stopped bool
+	closed  chan struct{} // Closed by stop; created on demand.
+}
+
+// track registers a function stopping some background work and returns a function
+// unregistering it. If the work was already stopped, stop is called right away.
+func (b *backgroundWork) track(stop func()) (untrack func()) {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		stop()
+		return func() {}
+	}
+	if b.stops == nil {
+		b.stops = make(map[int]func())
+	}
+	id := b.next
+	b.next++
+	b.stops[id] = stop
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		delete(b.stops, id)
+		b.mu.Unlock()
+	}
+}
+
+// stop stops all tracked work, as well as any work tracked afterwards.
+func (b *backgroundWork) stop() {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	b.stopped = true
+	stops := b.stops
+	b.stops = nil
+	if b.closed == nil {
+		b.closed = make(chan struct{})
+	}
+	close(b.closed)
+	b.mu.Unlock()
+
+	for _, stop := range stops {
+		stop()
+	}
+}
+
+// done returns a channel that is closed once the work is stopped.
+func (b *backgroundWork) done() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed == nil {
+		b.closed = make(chan struct{})
+	}
+	return b.closed
+}
+
+// backgroundTimer is a Timer of the emitter's clock that Close stops.
+type backgroundTimer struct {
+	mu      sync.Mutex
+	timer   Timer
+	untrack func()
+	stopped bool
+}
+
+// Stop prevents the function from running. It reports whether the call stopped it.
+func (t *backgroundTimer) Stop() bool {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return false
+	}
+	t.stopped = true
+	timer, untrack := t.timer, t.untrack
+	t.mu.Unlock()
+
+	if untrack != nil {
+		untrack()
+	}
+	return timer != nil && timer.Stop()
+}
+
+// afterFunc calls f once the duration has elapsed on the emitter's clock, unless the
+// returned Timer is stopped or the emitter is closed first.
+func (m *MemoryEmitter) afterFunc(d time.Duration, f func()) Timer {
+	t := &backgroundTimer{}
+	untrack := m.background.track(func() { t.Stop() })
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.untrack = untrack
+	if !t.stopped {
+		t.timer = m.clock.AfterFunc(d, func() {
+			untrack()
+			f()
+		})
+	}
+	return t
+}
+
+// Run ties the child's lifetime to ctx like MemoryEmitter.Run, detaching the child from
+// its parent when closing it.
+func (c *ChildEmitter) Run(ctx context.Context) error {
+	return c.MemoryEmitter.run(ctx, c.Close)
+}
@@ -0,0 +1,76 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunClosesOnCancel(t *testing.T) {
+	e := NewMemoryEmitter()
+	timer := e.EmitAfter(time.Hour, "later")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() { done <- e.Run(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() error = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not return after cancel")
+	}
+	if !e.closed.Load().(bool) {
+		t.Error("emitter not closed after Run returned")
+	}
+	if timer.Stop() {
+		t.Error("delayed emission still pending after Run returned")
+	}
+	if _, err := e.On("topic", func(Event) error { return nil }); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("On() error = %v, want %v", err, ErrEmitterClosed)
+	}
+}
+
+func TestRunReturnsWhenClosed(t *testing.T) {
+	e := NewMemoryEmitter()
+	done := make(chan error, 1)
+	go func() { done <- e.Run(context.Background()) }()
+	time.Sleep(10 * time.Millisecond) // Let Run start waiting.
+
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not return after Close")
+	}
+
+	if err := e.Run(context.Background()); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("Run() on a closed emitter error = %v, want %v", err, ErrEmitterClosed)
+	}
+}
+
+func TestAfterFuncAfterClose(t *testing.T) {
+	e := NewMemoryEmitter()
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	ran := make(chan struct{})
+	timer := e.afterFunc(time.Millisecond, func() { close(ran) })
+	if timer.Stop() {
+		t.Error("Stop() = true for work scheduled after Close")
+	}
+	select {
+	case <-ran:
+		t.Error("work scheduled after Close ran")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
@@ -0,0 +1,278 @@
+package emitter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScheduledEmission is the durable form of an emission scheduled with EmitAfter or
+// EmitAt on an emitter with a ScheduleStore.
+type ScheduledEmission struct {
+	Key   string    `json:"key"`   // Unique identifier of the scheduled emission.
+	At    time.Time `json:"at"`    // Time at which the event is due, on the emitter's clock.
+	Event *Envelope `json:"event"` // Event to emit, with its payload encoded as JSON.
+}
+
+// ScheduleStore persists the emissions scheduled with EmitAfter and EmitAt, so that
+// they can be restored with RestoreScheduled after a restart.
+type ScheduleStore interface {
+	// Save stores the scheduled emission, replacing any with the same key.
+	Save(s ScheduledEmission) error
+	// Delete removes the scheduled emission with the given key. Deleting an unknown key is not an error.
+	Delete(key string) error
+	// Load returns every stored scheduled emission.
+	Load() ([]ScheduledEmission, error)
+}
+
+// SetScheduleStore sets the store persisting the emissions scheduled with EmitAfter and
+// EmitAt. A nil store keeps them in memory only.
+func (m *MemoryEmitter) SetScheduleStore(store ScheduleStore) {
+	m.schedules.store = store
+}
+
+// scheduledEmissions tracks the persisted emissions waiting for their time.
+type scheduledEmissions struct {
+	store  ScheduleStore
+	mu     sync.Mutex
+	timers map[string]Timer // Pending emissions, by key.
+}
+
+// persistScheduled saves an emission scheduled with EmitAfter to the schedule store and
+// schedules it. It reports false, after passing the error to the error handler, if the
+// emission could not be saved and must be kept in memory only.
+func (m *MemoryEmitter) persistScheduled(delay time.Duration, eventName string, args []interface{}) (Timer, bool) {
+	payloads, _ := splitEmitArgs(args)
+	event := newArgsEvent(eventName, payloads)
+	env, err := NewEnvelope(event, JSONCodec{})
+	if err == nil {
+		key := m.idGenerator()
+		err = m.schedules.store.Save(ScheduledEmission{Key: key, At: m.clock.Now().Add(delay), Event: env})
+		if err == nil {
+			return m.schedule(key, event, delay, func() {
+				m.Emit(eventName, args...)
+			}), true
+		}
+	}
+	m.reportScheduleError(event, err)
+	return nil, false
+}
+
+// schedule emits the event of a persisted emission once the delay has elapsed, and
+// then deletes it from the store. Canceling the returned Timer deletes it as well. It
+// returns nil if the emission is already scheduled.
+func (m *MemoryEmitter) schedule(key string, event Event, delay time.Duration, emit func()) Timer {
+	m.schedules.mu.Lock()
+	defer m.schedules.mu.Unlock()
+	if _, ok := m.schedules.timers[key]; ok {
+		return nil
+	}
+	if m.schedules.timers == nil {
+		m.schedules.timers = make(map[string]Timer)
+	}
+	t := &scheduledTimer{emitter: m, key: key, event: event}
+	t.Timer = m.afterFunc(delay, func() {
+		emit()
+		m.forgetScheduled(key, event)
+	})
+	m.schedules.timers[key] = t.Timer
+	return t
+}
+
+// forgetScheduled deletes a persisted emission that was emitted or canceled.
+func (m *MemoryEmitter) forgetScheduled(key string, event Event) {
+	m.schedules.mu.Lock()
+	delete(m.schedules.timers, key)
+	m.schedules.mu.Unlock()
+	if err := m.schedules.store.Delete(key); err != nil {
+		m.reportScheduleError(event, err)
+	}
+}
+
+// reportScheduleError passes an error of the schedule store to the error handler, if
+// set, along with the event concerned.
+func (m *MemoryEmitter) reportScheduleError(event Event, err error) {
+	if m.errorHandler != nil {
+		_ = m.errorHandler(event, fmt.Errorf("schedule store: %w", err))
+	}
+}
+
+// RestoreScheduled schedules again the emissions persisted in the emitter's
+// ScheduleStore that are not scheduled yet, typically when the application starts.
+// Emissions whose time has passed are emitted right away. The restored events carry
+// their payload encoded, like those read from a journal, and the emit options given
+// when they were scheduled do not apply anymore.
+func (m *MemoryEmitter) RestoreScheduled() error {
+	if m.schedules.store == nil {
+		return &ConfigError{Op: "RestoreScheduled", Err: ErrNoScheduleStore}
+	}
+	stored, err := m.schedules.store.Load()
+	if err != nil {
+		return err
+	}
+	for _, s := range stored {
+		if s.Event == nil {
+			continue
+		}
+		event := s.Event.Event()
+		m.schedule(s.Key, event, s.At.Sub(m.clock.Now()), func() {
+			m.EmitEvent(event)
+		})
+	}
+	return nil
+}
+
+// scheduledTimer is the Timer of a persisted emission.
+type scheduledTimer struct {
+	Timer
+	emitter *MemoryEmitter
+	key     string
+	event   Event
+}
+
+// Stop cancels the emission and deletes it from the store. It reports whether the call
+// stopped it.
+func (t *scheduledTimer) Stop() bool {
+	if !t.Timer.Stop() {
+		return false
+	}
+	t.emitter.forgetScheduled(t.key, t.event)
+	return true
+}
+
+// MemoryScheduleStore is a ScheduleStore that keeps scheduled emissions in memory.
+type MemoryScheduleStore struct {
+	mu        sync.Mutex
+	schedules map[string]ScheduledEmission
+}
+
+// NewMemoryScheduleStore creates an empty MemoryScheduleStore.
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{schedules: make(map[string]ScheduledEmission)}
+}
+
+// Save stores the scheduled emission.
+func (s *MemoryScheduleStore) Save(scheduled ScheduledEmission) error {
+	s.mu.Lock()
+	s.schedules[scheduled.Key] = scheduled
+	s.mu.Unlock()
+	return nil
+}
+
+// Delete removes the scheduled emission with the given key.
+func (s *MemoryScheduleStore) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.schedules, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// Load returns the stored scheduled emissions ordered by time.
+func (s *MemoryScheduleStore) Load() ([]ScheduledEmission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedSchedules(s.schedules), nil
+}
+
+// FileScheduleStore is a ScheduleStore that keeps scheduled emissions in a JSON file.
+// Every change rewrites the file atomically.
+type FileScheduleStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileScheduleStore creates a FileScheduleStore backed by the file at path. The file
+// is created on the first Save.
+func NewFileScheduleStore(path string) *FileScheduleStore {
+	return &FileScheduleStore{path: path}
+}
+
+// Save stores the scheduled emission.
+func (s *FileScheduleStore) Save(scheduled ScheduledEmission) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules, err := s.read()
+	if err != nil {
+		return err
+	}
+	schedules[scheduled.Key] = scheduled
+	return s.write(schedules)
+}
+
+// Delete removes the scheduled emission with the given key.
+func (s *FileScheduleStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := schedules[key]; !ok {
+		return nil
+	}
+	delete(schedules, key)
+	return s.write(schedules)
+}
+
+// Load returns the stored scheduled emissions ordered by time.
+func (s *FileScheduleStore) Load() ([]ScheduledEmission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return sortedSchedules(schedules), nil
+}
+
+// read loads the scheduled emissions from the file, treating a missing file as empty.
+func (s *FileScheduleStore) read() (map[string]ScheduledEmission, error) {
+	schedules := make(map[string]ScheduledEmission)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return schedules, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []ScheduledEmission
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decoding scheduled emissions from %s: %w", s.path, err)
+	}
+	for _, scheduled := range list {
+		schedules[scheduled.Key] = scheduled
+	}
+	return schedules, nil
+}
+
+// write replaces the file with the given scheduled emissions.
+func (s *FileScheduleStore) write(schedules map[string]ScheduledEmission) error {
+	data, err := json.MarshalIndent(sortedSchedules(schedules), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(s.path, data)
+}
+
+// sortedSchedules returns the scheduled emissions of the map ordered by time, then key.
+func sortedSchedules(schedules map[string]ScheduledEmission) []ScheduledEmission {
+	list := make([]ScheduledEmission, 0, len(schedules))
+	for _, scheduled := range schedules {
+		list = append(list, scheduled)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].At.Equal(list[j].At) {
+			return list[i].At.Before(list[j].At)
+		}
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
@@ -0,0 +1,104 @@
+package emitter_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+func TestScheduleStoreSurvivesRestart(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := emitter.NewFileScheduleStore(filepath.Join(t.TempDir(), "schedules.json"))
+
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock), emitter.WithScheduleStore(store))
+	em.EmitAfter(7*24*time.Hour, "subscription.expire.warn", map[string]string{"user": "ada"})
+	canceled := em.EmitAfter(time.Hour, "subscription.renewed", "bob")
+	if !canceled.Stop() {
+		t.Fatal("Stop() = false, want the emission canceled")
+	}
+	if err := em.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	stored, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(stored) != 1 || stored[0].Event.Topic != "subscription.expire.warn" {
+		t.Fatalf("stored schedules = %+v, want the expiry warning only", stored)
+	}
+
+	restarted := emitter.NewMemoryEmitter(emitter.WithClock(clock), emitter.WithScheduleStore(store))
+	defer restarted.Close()
+	received := make(chan emitter.Event, 1)
+	restarted.On("subscription.**", func(evt emitter.Event) error {
+		received <- evt
+		return nil
+	})
+	if err := restarted.RestoreScheduled(); err != nil {
+		t.Fatalf("RestoreScheduled() error = %v", err)
+	}
+	if err := restarted.RestoreScheduled(); err != nil {
+		t.Fatalf("RestoreScheduled() again error = %v", err)
+	}
+
+	clock.Advance(7 * 24 * time.Hour)
+	if err := restarted.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(received) != 1 {
+		t.Fatalf("listener received %d events, want 1", len(received))
+	}
+	var payload map[string]string
+	if err := json.Unmarshal((<-received).Payload().(json.RawMessage), &payload); err != nil || payload["user"] != "ada" {
+		t.Errorf("restored payload = %v, %v, want user ada", payload, err)
+	}
+	if stored, _ := store.Load(); len(stored) != 0 {
+		t.Errorf("stored schedules = %+v after the emission, want none", stored)
+	}
+}
+
+func TestScheduleStoreUnencodablePayload(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := emitter.NewMemoryScheduleStore()
+	var reported error
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock), emitter.WithScheduleStore(store),
+		emitter.WithErrorHandler(func(_ emitter.Event, err error) error {
+			reported = err
+			return nil
+		}))
+	defer em.Close()
+
+	fired := make(chan struct{}, 1)
+	em.On("tick", func(emitter.Event) error {
+		fired <- struct{}{}
+		return nil
+	})
+	em.EmitAfter(time.Minute, "tick", func() {})
+	if reported == nil {
+		t.Error("error handler received no error for a payload that cannot be encoded")
+	}
+	if stored, _ := store.Load(); len(stored) != 0 {
+		t.Errorf("stored schedules = %+v, want none", stored)
+	}
+
+	clock.Advance(time.Minute)
+	em.Flush(context.Background())
+	if len(fired) != 1 {
+		t.Error("emission kept in memory did not fire")
+	}
+}
+
+func TestRestoreScheduledWithoutStore(t *testing.T) {
+	em := emitter.NewMemoryEmitter()
+	defer em.Close()
+	if err := em.RestoreScheduled(); !errors.Is(err, emitter.ErrNoScheduleStore) {
+		t.Errorf("RestoreScheduled() error = %v, want %v", err, emitter.ErrNoScheduleStore)
+	}
+}
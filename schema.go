@@ -0,0 +1,418 @@
+package emitter
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Compatibility selects the payload schema changes a SchemaRegistry accepts, like the
+// compatibility modes of a schema registry.
+type Compatibility int
+
+const (
+	// CompatibilityNone accepts any change.
+	CompatibilityNone Compatibility = iota
+	// CompatibilityBackward accepts changes that let listeners built against the new
+	// schema read payloads of the previous one: removing fields and adding optional ones.
+	CompatibilityBackward
+	// CompatibilityForward accepts changes that let listeners built against the previous
+	// schema read payloads of the new one: adding fields and removing optional ones.
+	CompatibilityForward
+	// CompatibilityFull accepts changes that are both backward and forward compatible:
+	// adding or removing optional fields.
+	CompatibilityFull
+)
+
+// String returns the name of the compatibility mode.
+func (c Compatibility) String() string {
+	switch c {
+	case CompatibilityNone:
+		return "none"
+	case CompatibilityBackward:
+		return "backward"
+	case CompatibilityForward:
+		return "forward"
+	case CompatibilityFull:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
+// PayloadSchema describes the shape of a topic's payload as encoded to JSON. Derive it
+// from a Go type with SchemaOf.
+type PayloadSchema struct {
+	Topic  string        `json:"topic"`
+	Type   string        `json:"type"`             // Go type the schema was derived from; informational.
+	Kind   string        `json:"kind"`             // Kind of the payload, as in SchemaField.
+	Fields []SchemaField `json:"fields,omitempty"` // Fields of an object payload, ordered by name.
+}
+
+// SchemaField describes a field of an object payload. Its Kind is one of "string",
+// "integer", "number", "boolean", "object" and "any", "array:<kind>" or "map:<kind>" for
+// collections, or the name of a type encoding itself, such as "time.Time".
+type SchemaField struct {
+	Name     string        `json:"name"`             // JSON name of the field.
+	Kind     string        `json:"kind"`             // Kind of the field's values.
+	Required bool          `json:"required"`         // Whether the field is always encoded: not a pointer, nor omitempty.
+	Fields   []SchemaField `json:"fields,omitempty"` // Fields of an object, or of the objects in a collection.
+}
+
+// SchemaOf derives the schema of the payloads of type T emitted on topic, following the
+// encoding/json rules for field names, omitempty and embedded structs.
+func SchemaOf[T any](topic string) PayloadSchema {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	kind, fields := describeType(t, map[reflect.Type]bool{})
+	return PayloadSchema{Topic: topic, Type: t.String(), Kind: kind, Fields: fields}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// describeType returns the kind of t and, for objects, their fields. Recursive types are
+// described down to their first repetition.
+func describeType(t reflect.Type, seen map[reflect.Type]bool) (string, []SchemaField) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return t.String(), nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return "string", nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer", nil
+	case reflect.Float32, reflect.Float64:
+		return "number", nil
+	case reflect.String:
+		return "string", nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return "string", nil // Encoded in base64.
+		}
+		kind, fields := describeType(t.Elem(), seen)
+		return "array:" + kind, fields
+	case reflect.Map:
+		kind, fields := describeType(t.Elem(), seen)
+		return "map:" + kind, fields
+	case reflect.Struct:
+		if seen[t] {
+			return "object", nil
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		fields := structFields(t, seen)
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+		return "object", fields
+	default:
+		return "any", nil
+	}
+}
+
+// structFields returns the fields encoded for the struct type t, including the fields
+// promoted from untagged embedded structs.
+func structFields(t reflect.Type, seen map[reflect.Type]bool) []SchemaField {
+	var fields []SchemaField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, structFields(embedded, seen)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		kind, nested := describeType(f.Type, seen)
+		omitempty := strings.Contains(","+options+",", ",omitempty,")
+		optional := omitempty || f.Type.Kind() == reflect.Pointer || f.Type.Kind() == reflect.Interface
+		fields = append(fields, SchemaField{Name: name, Kind: kind, Required: !optional, Fields: nested})
+	}
+	return fields
+}
+
+// CheckCompatibility reports whether next may replace previous under mode. It returns a
+// *SchemaError listing the breaking changes if it may not.
+func CheckCompatibility(mode Compatibility, previous, next PayloadSchema) error {
+	var problems []string
+	if mode == CompatibilityBackward || mode == CompatibilityFull {
+		problems = append(problems, readProblems("", next.Kind, next.Fields, previous.Kind, previous.Fields, "new", "previous")...)
+	}
+	if mode == CompatibilityForward || mode == CompatibilityFull {
+		problems = append(problems, readProblems("", previous.Kind, previous.Fields, next.Kind, next.Fields, "previous", "new")...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &SchemaError{Topic: next.Topic, Mode: mode, Problems: uniqueStrings(problems)}
+}
+
+// readProblems lists what keeps a reader of the reader schema from decoding payloads of
+// the writer schema, for the object at path, empty for the payload itself. Fields only
+// the writer has are ignored by readers.
+func readProblems(path, readerKind string, readerFields []SchemaField, writerKind string, writerFields []SchemaField, reader, writer string) []string {
+	if readerKind != writerKind {
+		from, to := writerKind, readerKind
+		if reader == "previous" {
+			from, to = readerKind, writerKind
+		}
+		if path == "" {
+			return []string{fmt.Sprintf("payload changed from %s to %s", from, to)}
+		}
+		return []string{fmt.Sprintf("field %s changed from %s to %s", path, from, to)}
+	}
+
+	written := make(map[string]SchemaField, len(writerFields))
+	for _, field := range writerFields {
+		written[field.Name] = field
+	}
+	var problems []string
+	for _, field := range readerFields {
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		other, ok := written[field.Name]
+		switch {
+		case !ok && field.Required:
+			problems = append(problems, fmt.Sprintf("field %s is required by the %s schema but missing from the %s one", fieldPath, reader, writer))
+		case !ok:
+		case field.Required && !other.Required:
+			problems = append(problems, fmt.Sprintf("field %s is required by the %s schema but optional in the %s one", fieldPath, reader, writer))
+			fallthrough
+		default:
+			problems = append(problems, readProblems(fieldPath, field.Kind, field.Fields, other.Kind, other.Fields, reader, writer)...)
+		}
+	}
+	return problems
+}
+
+// uniqueStrings returns values without repetitions, keeping their order.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0]
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// SchemaStore persists the payload schemas declared to a SchemaRegistry.
+type SchemaStore interface {
+	// Save stores the schema, replacing any schema of the same topic.
+	Save(schema PayloadSchema) error
+	// Load returns every stored schema.
+	Load() ([]PayloadSchema, error)
+}
+
+// SchemaRegistry checks that the payload schemas of topics evolve compatibly between
+// releases. Declare each topic's payload at startup; a declaration that differs from the
+// stored one is checked against it under the registry's compatibility mode, so that an
+// incompatible change fails fast instead of breaking listeners at runtime:
+//
+//	schemas := emitter.NewSchemaRegistry(emitter.NewFileSchemaStore("schemas.json"), emitter.CompatibilityBackward)
+//	if err := emitter.DeclarePayload[OrderCreated](schemas, "order.created"); err != nil {
+//		log.Fatal(err)
+//	}
+type SchemaRegistry struct {
+	mu      sync.Mutex
+	store   SchemaStore
+	mode    Compatibility
+	schemas map[string]PayloadSchema // Loaded from the store on first use.
+}
+
+// NewSchemaRegistry creates a SchemaRegistry checking declarations against the schemas
+// in store under mode.
+func NewSchemaRegistry(store SchemaStore, mode Compatibility) *SchemaRegistry {
+	return &SchemaRegistry{store: store, mode: mode}
+}
+
+// Declare records the payload schema of a topic. If the topic already has a different
+// schema, the new one must be compatible with it, or Declare returns a *SchemaError and
+// keeps the stored schema.
+func (r *SchemaRegistry) Declare(schema PayloadSchema) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.load(); err != nil {
+		return err
+	}
+	if previous, ok := r.schemas[schema.Topic]; ok {
+		if reflect.DeepEqual(previous, schema) {
+			return nil
+		}
+		if err := CheckCompatibility(r.mode, previous, schema); err != nil {
+			return err
+		}
+	}
+	if err := r.store.Save(schema); err != nil {
+		return err
+	}
+	r.schemas[schema.Topic] = schema
+	return nil
+}
+
+// Schema returns the schema declared or stored for topic.
+func (r *SchemaRegistry) Schema(topic string) (PayloadSchema, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.load(); err != nil {
+		return PayloadSchema{}, false, err
+	}
+	schema, ok := r.schemas[topic]
+	return schema, ok, nil
+}
+
+// load reads the stored schemas unless they were read already. Callers must hold r.mu.
+func (r *SchemaRegistry) load() error {
+	if r.schemas != nil {
+		return nil
+	}
+	stored, err := r.store.Load()
+	if err != nil {
+		return err
+	}
+	r.schemas = make(map[string]PayloadSchema, len(stored))
+	for _, schema := range stored {
+		r.schemas[schema.Topic] = schema
+	}
+	return nil
+}
+
+// DeclarePayload declares SchemaOf[T](topic) to the registry.
+func DeclarePayload[T any](r *SchemaRegistry, topic string) error {
+	return r.Declare(SchemaOf[T](topic))
+}
+
+// MemorySchemaStore is a SchemaStore that keeps schemas in memory.
+type MemorySchemaStore struct {
+	mu      sync.Mutex
+	schemas map[string]PayloadSchema
+}
+
+// NewMemorySchemaStore creates an empty MemorySchemaStore.
+func NewMemorySchemaStore() *MemorySchemaStore {
+	return &MemorySchemaStore{schemas: make(map[string]PayloadSchema)}
+}
+
+// Save stores the schema.
+func (s *MemorySchemaStore) Save(schema PayloadSchema) error {
+	s.mu.Lock()
+	s.schemas[schema.Topic] = schema
+	s.mu.Unlock()
+	return nil
+}
+
+// Load returns the stored schemas ordered by topic.
+func (s *MemorySchemaStore) Load() ([]PayloadSchema, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedSchemas(s.schemas), nil
+}
+
+// FileSchemaStore is a SchemaStore that keeps schemas in a JSON file, which can be
+// committed alongside the code declaring them. Every change rewrites the file atomically.
+type FileSchemaStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSchemaStore creates a FileSchemaStore backed by the file at path. The file is
+// created on the first Save.
+func NewFileSchemaStore(path string) *FileSchemaStore {
+	return &FileSchemaStore{path: path}
+}
+
+// Save stores the schema.
+func (s *FileSchemaStore) Save(schema PayloadSchema) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schemas, err := s.read()
+	if err != nil {
+		return err
+	}
+	schemas[schema.Topic] = schema
+	data, err := json.MarshalIndent(sortedSchemas(schemas), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(s.path, data)
+}
+
+// Load returns the stored schemas ordered by topic.
+func (s *FileSchemaStore) Load() ([]PayloadSchema, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schemas, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return sortedSchemas(schemas), nil
+}
+
+// read loads the schemas from the file, treating a missing file as empty.
+func (s *FileSchemaStore) read() (map[string]PayloadSchema, error) {
+	schemas := make(map[string]PayloadSchema)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return schemas, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []PayloadSchema
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decoding schemas from %s: %w", s.path, err)
+	}
+	for _, schema := range list {
+		schemas[schema.Topic] = schema
+	}
+	return schemas, nil
+}
+
+// sortedSchemas returns the schemas of the map ordered by topic.
+func sortedSchemas(schemas map[string]PayloadSchema) []PayloadSchema {
+	list := make([]PayloadSchema, 0, len(schemas))
+	for _, schema := range schemas {
+		list = append(list, schema)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Topic < list[j].Topic })
+	return list
+}
@@ -0,0 +1,137 @@
+package emitter
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type auditInfo struct {
+	By string `json:"by"`
+}
+
+type orderV1 struct {
+	ID     string    `json:"id"`
+	Amount int       `json:"amount"`
+	Note   string    `json:"note,omitempty"`
+	At     time.Time `json:"at"`
+	Items  []struct {
+		SKU string `json:"sku"`
+	} `json:"items"`
+	auditInfo
+	internal string
+	Ignored  string `json:"-"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema := SchemaOf[*orderV1]("order.created")
+	want := PayloadSchema{
+		Topic: "order.created",
+		Type:  "*emitter.orderV1",
+		Kind:  "object",
+		Fields: []SchemaField{
+			{Name: "amount", Kind: "integer", Required: true},
+			{Name: "at", Kind: "time.Time", Required: true},
+			{Name: "by", Kind: "string", Required: true},
+			{Name: "id", Kind: "string", Required: true},
+			{Name: "items", Kind: "array:object", Required: true, Fields: []SchemaField{{Name: "sku", Kind: "string", Required: true}}},
+			{Name: "note", Kind: "string"},
+		},
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("SchemaOf() = %+v, want %+v", schema, want)
+	}
+	if schema := SchemaOf[[]byte]("raw"); schema.Kind != "string" {
+		t.Errorf("SchemaOf[[]byte]().Kind = %q, want string", schema.Kind)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	previous := PayloadSchema{Topic: "order.created", Kind: "object", Fields: []SchemaField{
+		{Name: "id", Kind: "string", Required: true},
+		{Name: "note", Kind: "string"},
+	}}
+	with := func(fields ...SchemaField) PayloadSchema {
+		return PayloadSchema{Topic: "order.created", Kind: "object", Fields: fields}
+	}
+	id := SchemaField{Name: "id", Kind: "string", Required: true}
+
+	tests := []struct {
+		name string
+		next PayloadSchema
+		ok   map[Compatibility]bool
+	}{
+		{"add optional field", with(id, SchemaField{Name: "note", Kind: "string"}, SchemaField{Name: "tag", Kind: "string"}),
+			map[Compatibility]bool{CompatibilityBackward: true, CompatibilityForward: true, CompatibilityFull: true}},
+		{"add required field", with(id, SchemaField{Name: "note", Kind: "string"}, SchemaField{Name: "total", Kind: "integer", Required: true}),
+			map[Compatibility]bool{CompatibilityForward: true}},
+		{"remove optional field", with(id),
+			map[Compatibility]bool{CompatibilityBackward: true, CompatibilityForward: true, CompatibilityFull: true}},
+		{"remove required field", with(SchemaField{Name: "note", Kind: "string"}),
+			map[Compatibility]bool{CompatibilityBackward: true}},
+		{"change field kind", with(SchemaField{Name: "id", Kind: "integer", Required: true}, SchemaField{Name: "note", Kind: "string"}),
+			map[Compatibility]bool{}},
+		{"change payload kind", PayloadSchema{Topic: "order.created", Kind: "string"},
+			map[Compatibility]bool{}},
+	}
+	for _, tt := range tests {
+		for _, mode := range []Compatibility{CompatibilityNone, CompatibilityBackward, CompatibilityForward, CompatibilityFull} {
+			err := CheckCompatibility(mode, previous, tt.next)
+			want := mode == CompatibilityNone || tt.ok[mode]
+			if (err == nil) != want {
+				t.Errorf("%s under %s: CheckCompatibility() error = %v, want compatible %v", tt.name, mode, err, want)
+			}
+			var schemaErr *SchemaError
+			if err != nil && (!errors.As(err, &schemaErr) || !errors.Is(err, ErrIncompatibleSchema) || len(schemaErr.Problems) == 0) {
+				t.Errorf("%s under %s: error = %v, want a *SchemaError listing problems", tt.name, mode, err)
+			}
+		}
+	}
+
+	err := CheckCompatibility(CompatibilityFull, previous, with(SchemaField{Name: "id", Kind: "integer", Required: true}))
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || len(schemaErr.Problems) != 1 || schemaErr.Problems[0] != "field id changed from string to integer" {
+		t.Errorf("CheckCompatibility() error = %v, want a single kind change", err)
+	}
+}
+
+type orderV2 struct {
+	ID       string `json:"id"`
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency,omitempty"`
+}
+
+type orderV3 struct {
+	ID       string `json:"id"`
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func TestSchemaRegistryAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemas.json")
+
+	schemas := NewSchemaRegistry(NewFileSchemaStore(path), CompatibilityBackward)
+	if err := DeclarePayload[orderV1](schemas, "order.created"); err != nil {
+		t.Fatalf("DeclarePayload() error = %v", err)
+	}
+
+	// A new release adds an optional field and drops some: backward compatible.
+	schemas = NewSchemaRegistry(NewFileSchemaStore(path), CompatibilityBackward)
+	if err := DeclarePayload[orderV2](schemas, "order.created"); err != nil {
+		t.Fatalf("DeclarePayload() of a compatible change error = %v", err)
+	}
+
+	// The next one changes a field's kind and adds a required field.
+	schemas = NewSchemaRegistry(NewFileSchemaStore(path), CompatibilityBackward)
+	err := DeclarePayload[orderV3](schemas, "order.created")
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || len(schemaErr.Problems) != 2 {
+		t.Fatalf("DeclarePayload() of an incompatible change error = %v, want two problems", err)
+	}
+	stored, ok, err := schemas.Schema("order.created")
+	if err != nil || !ok || stored.Type != "emitter.orderV2" {
+		t.Errorf("Schema() = %v, %v, %v, want the orderV2 schema kept", stored.Type, ok, err)
+	}
+}
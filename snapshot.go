@@ -0,0 +1,112 @@
+package emitter
+
+import "sync"
+
+// snapshotMetadataKey marks the synthetic events delivered by OnWithSnapshot.
+const snapshotMetadataKey = "emitter.snapshot"
+
+// OnWithSnapshot subscribes a listener that starts from the current state, for subscribers
+// such as caches that must be warmed before following updates. It subscribes the listener,
+// calls fetchState, and invokes the listener with a synthetic snapshot event on topicName
+// whose payload is the state, recognizable with IsSnapshot. Events emitted while the state
+// is fetched are buffered and delivered after the snapshot, in order, before the listener
+// receives events directly, so that no event is missed between the snapshot and the
+// stream. The buffered events may already be reflected in the state, so listeners should
+// apply them idempotently.
+//
+// If fetchState or the snapshot call fails, the listener is unsubscribed and the error
+// is returned. Errors of the buffered events go to the error handler and the error
+// topic, if set, since no emission awaits them anymore.
+func (m *MemoryEmitter) OnWithSnapshot(topicName string, fetchState func() (interface{}, error), listener Listener, opts ...ListenerOption) (string, error) {
+	if listener == nil {
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: ErrNilListener}
+	}
+	if fetchState == nil {
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, Err: ErrInvalidHandler}
+	}
+
+	stream := &snapshotStream{listener: listener}
+	id, err := m.On(topicName, stream.receive, opts...)
+	if err != nil {
+		return "", err
+	}
+	fail := func(err error) (string, error) {
+		_ = m.Off(topicName, id)
+		return "", &SubscriptionError{Op: OpOn, Topic: topicName, ListenerID: id, Err: err}
+	}
+
+	state, err := fetchState()
+	if err != nil {
+		return fail(err)
+	}
+	snapshot := NewBaseEvent(topicName, state).WithMetadata(snapshotMetadataKey, true)
+	snapshot.timestamp = m.clock.Now()
+	if err := listener(snapshot); err != nil {
+		return fail(err)
+	}
+
+	stream.goLive(func(evt Event, err error) {
+		m.reportDeferredError(evt, topicName, id, err)
+	})
+	return id, nil
+}
+
+// IsSnapshot reports whether evt is the snapshot event delivered by OnWithSnapshot.
+func IsSnapshot(evt Event) bool {
+	snapshot, _ := LookupMetadata(evt, snapshotMetadataKey)
+	return snapshot == true
+}
+
+// snapshotStream buffers the events of an OnWithSnapshot listener until its snapshot
+// was delivered. Listeners receiving retained events use it the same way.
+type snapshotStream struct {
+	listener Listener
+
+	mu       sync.Mutex
+	buffered []Event
+	live     bool             // Whether events are passed to the listener directly.
+	skip     func(Event) bool // Drops events the listener must not receive, if set.
+}
+
+// receive buffers the event until the stream is live, and passes it to the listener
+// afterwards.
+func (s *snapshotStream) receive(evt Event) error {
+	s.mu.Lock()
+	if s.skip != nil && s.skip(evt) {
+		s.mu.Unlock()
+		return nil
+	}
+	if !s.live {
+		s.buffered = append(s.buffered, evt)
+		s.mu.Unlock()
+		return nil
+	}
+	s.mu.Unlock()
+	return s.listener(evt)
+}
+
+// goLive delivers the buffered events, including those arriving meanwhile, and then
+// lets events through. Listener errors are passed to report.
+func (s *snapshotStream) goLive(report func(Event, error)) {
+	for {
+		s.mu.Lock()
+		events := s.buffered
+		s.buffered = nil
+		if len(events) == 0 {
+			s.live = true
+			s.mu.Unlock()
+			return
+		}
+		skip := s.skip
+		s.mu.Unlock()
+
+		for _, evt := range events {
+			if skip != nil && skip(evt) {
+				continue
+			}
+			if err := s.listener(evt); err != nil {
+				report(evt, err)
+			}
+		}
+	}
+}
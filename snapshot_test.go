@@ -0,0 +1,77 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOnWithSnapshot(t *testing.T) {
+	e := NewMemoryEmitter()
+	var seen []interface{}
+	snapshots := 0
+	listener := func(evt Event) error {
+		if IsSnapshot(evt) {
+			snapshots++
+		}
+		seen = append(seen, evt.Payload())
+		return nil
+	}
+	fetch := func() (interface{}, error) {
+		// Updates emitted while the state is fetched are delivered after the snapshot.
+		e.EmitSync("price.btc", 101)
+		return "state", nil
+	}
+
+	if _, err := e.OnWithSnapshot("price.*", fetch, listener); err != nil {
+		t.Fatalf("OnWithSnapshot() error = %v", err)
+	}
+	e.EmitSync("price.btc", 102)
+
+	if len(seen) != 3 || seen[0] != "state" || seen[1] != 101 || seen[2] != 102 {
+		t.Errorf("listener saw %v, want [state 101 102]", seen)
+	}
+	if snapshots != 1 {
+		t.Errorf("listener saw %d snapshots, want 1", snapshots)
+	}
+}
+
+func TestOnWithSnapshotFetchError(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	e := NewMemoryEmitter()
+	_, err := e.OnWithSnapshot("price.*", func() (interface{}, error) {
+		return nil, errUnavailable
+	}, func(Event) error { return nil })
+
+	if !errors.Is(err, errUnavailable) {
+		t.Errorf("OnWithSnapshot() error = %v, want %v", err, errUnavailable)
+	}
+	if topic, _ := e.GetTopic("price.*"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after a failed fetch, want 0", topic.ListenerCount())
+	}
+}
+
+func TestOnWithSnapshotBufferedError(t *testing.T) {
+	errStale := errors.New("stale")
+	var reported error
+	e := NewMemoryEmitter(WithErrorHandler(func(evt Event, err error) error {
+		reported = err
+		return nil
+	}))
+	fetch := func() (interface{}, error) {
+		e.EmitSync("price.btc", 101)
+		return "state", nil
+	}
+	listener := func(evt Event) error {
+		if IsSnapshot(evt) {
+			return nil
+		}
+		return errStale
+	}
+
+	if _, err := e.OnWithSnapshot("price.*", fetch, listener); err != nil {
+		t.Fatalf("OnWithSnapshot() error = %v", err)
+	}
+	if !errors.Is(reported, errStale) {
+		t.Errorf("error handler received %v, want %v", reported, errStale)
+	}
+}
@@ -0,0 +1,345 @@
+package emitter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Subscription is the durable description of a listener. The listener itself is not
+// stored; Handler names a function registered in a HandlerRegistry, which lets
+// subscriptions be saved, reloaded after a restart and attached again.
+type Subscription struct {
+	Key      string   `json:"key"`      // Unique, caller-chosen identifier of the subscription.
+	Topic    string   `json:"topic"`    // Topic name or wildcard pattern to listen on.
+	Handler  string   `json:"handler"`  // Name of the handler in the HandlerRegistry.
+	Priority Priority `json:"priority"` // Listener priority; zero means Normal.
+	ReadOnly bool     `json:"readOnly,omitempty"`
+}
+
+// listenerOptions converts the stored options into listener options.
+func (s Subscription) listenerOptions() []ListenerOption {
+	priority := s.Priority
+	if priority == 0 {
+		priority = Normal
+	}
+	opts := []ListenerOption{WithPriority(priority), WithHandlerName(s.Handler)}
+	if s.ReadOnly {
+		opts = append(opts, WithReadOnlyEvent())
+	}
+	return opts
+}
+
+// SubscriptionStore persists subscription metadata.
+type SubscriptionStore interface {
+	// Save stores the subscription, replacing any subscription with the same key.
+	Save(sub Subscription) error
+	// Delete removes the subscription with the given key. Deleting an unknown key is not an error.
+	Delete(key string) error
+	// Load returns every stored subscription.
+	Load() ([]Subscription, error)
+}
+
+// HandlerRegistry maps handler names to listeners so that stored subscriptions can be
+// bound to code again.
+type HandlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]Listener
+}
+
+// NewHandlerRegistry creates an empty HandlerRegistry.
+func NewHandlerRegistry() *HandlerRegistry {
+	return &HandlerRegistry{handlers: make(map[string]Listener)}
+}
+
+// Register adds a named handler. Registering a name twice replaces the earlier handler.
+func (r *HandlerRegistry) Register(name string, handler Listener) error {
+	if handler == nil {
+		return ErrNilListener
+	}
+	if name == "" {
+		return fmt.Errorf("%w: handler name cannot be empty", ErrInvalidHandler)
+	}
+
+	r.mu.Lock()
+	r.handlers[name] = handler
+	r.mu.Unlock()
+	return nil
+}
+
+// Lookup returns the handler registered under name.
+func (r *HandlerRegistry) Lookup(name string) (Listener, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, ok := r.handlers[name]
+	return handler, ok
+}
+
+// attachedSubscription records where a durable subscription is attached.
+type attachedSubscription struct {
+	sub Subscription
+	id  string // Listener ID returned by Emitter.On.
+}
+
+// DurableSubscriptions attaches subscriptions to an emitter and keeps a SubscriptionStore
+// in sync with them, so that the same subscriptions can be restored after a restart.
+type DurableSubscriptions struct {
+	mu       sync.Mutex
+	emitter  Emitter
+	store    SubscriptionStore
+	handlers *HandlerRegistry
+	attached map[string]attachedSubscription
+}
+
+// NewDurableSubscriptions creates a DurableSubscriptions for the given emitter, store and
+// handler registry. Call Restore to attach the subscriptions already in the store.
+func NewDurableSubscriptions(e Emitter, store SubscriptionStore, handlers *HandlerRegistry) *DurableSubscriptions {
+	return &DurableSubscriptions{
+		emitter:  e,
+		store:    store,
+		handlers: handlers,
+		attached: make(map[string]attachedSubscription),
+	}
+}
+
+// Subscribe attaches the subscription to the emitter and saves it to the store. A
+// subscription with the same key is detached first, so that the two are never attached
+// together; if attaching or saving the new one fails, the previous one is attached again.
+func (d *DurableSubscriptions) Subscribe(sub Subscription) error {
+	if sub.Key == "" {
+		return fmt.Errorf("%w: subscription key cannot be empty", ErrInvalidHandler)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.attach(sub, d.store.Save)
+}
+
+// Unsubscribe detaches the subscription with the given key and deletes it from the store.
+func (d *DurableSubscriptions) Unsubscribe(key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.attached[key]; !ok {
+		return fmt.Errorf("%w: %q", ErrSubscriptionNotFound, key)
+	}
+	d.detach(key)
+	return d.store.Delete(key)
+}
+
+// Restore attaches every subscription in the store that is not attached yet. Subscriptions
+// whose handler is not registered are skipped and reported in the returned error; the
+// others are attached regardless.
+func (d *DurableSubscriptions) Restore() error {
+	subs, err := d.store.Load()
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if _, ok := d.attached[sub.Key]; ok {
+			continue
+		}
+		if err := d.attach(sub, nil); err != nil {
+			errs = append(errs, fmt.Errorf("restoring subscription %q: %w", sub.Key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Subscriptions returns the attached subscriptions ordered by key.
+func (d *DurableSubscriptions) Subscriptions() []Subscription {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	subs := make([]Subscription, 0, len(d.attached))
+	for _, a := range d.attached {
+		subs = append(subs, a.sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Key < subs[j].Key })
+	return subs
+}
+
+// attach subscribes the handler of sub and then calls save, if not nil, replacing an
+// attachment with the same key. The previous attachment is detached first and attached
+// again when subscribing or saving fails. Callers must hold d.mu.
+func (d *DurableSubscriptions) attach(sub Subscription, save func(Subscription) error) error {
+	previous, replacing := d.attached[sub.Key]
+	d.detach(sub.Key)
+
+	a, err := d.listen(sub)
+	if err == nil && save != nil {
+		if err = save(sub); err != nil {
+			_ = d.emitter.Off(sub.Topic, a.id)
+		}
+	}
+	if err == nil {
+		d.attached[sub.Key] = a
+		return nil
+	}
+	if replacing {
+		restored, rerr := d.listen(previous.sub)
+		if rerr != nil {
+			return errors.Join(err, fmt.Errorf("reattaching subscription %q: %w", sub.Key, rerr))
+		}
+		d.attached[sub.Key] = restored
+	}
+	return err
+}
+
+// listen registers the subscription's handler on the emitter, without recording it.
+func (d *DurableSubscriptions) listen(sub Subscription) (attachedSubscription, error) {
+	handler, ok := d.handlers.Lookup(sub.Handler)
+	if !ok {
+		return attachedSubscription{}, fmt.Errorf("%w: %q", ErrHandlerNotFound, sub.Handler)
+	}
+	id, err := d.emitter.On(sub.Topic, handler, sub.listenerOptions()...)
+	if err != nil {
+		return attachedSubscription{}, err
+	}
+	return attachedSubscription{sub: sub, id: id}, nil
+}
+
+// detach removes the listener attached for key, if any. Callers must hold d.mu.
+func (d *DurableSubscriptions) detach(key string) {
+	if a, ok := d.attached[key]; ok {
+		_ = d.emitter.Off(a.sub.Topic, a.id)
+		delete(d.attached, key)
+	}
+}
+
+// MemorySubscriptionStore is a SubscriptionStore that keeps subscriptions in memory.
+type MemorySubscriptionStore struct {
+	mu   sync.Mutex
+	subs map[string]Subscription
+}
+
+// NewMemorySubscriptionStore creates an empty MemorySubscriptionStore.
+func NewMemorySubscriptionStore() *MemorySubscriptionStore {
+	return &MemorySubscriptionStore{subs: make(map[string]Subscription)}
+}
+
+// Save stores the subscription.
+func (s *MemorySubscriptionStore) Save(sub Subscription) error {
+	s.mu.Lock()
+	s.subs[sub.Key] = sub
+	s.mu.Unlock()
+	return nil
+}
+
+// Delete removes the subscription with the given key.
+func (s *MemorySubscriptionStore) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.subs, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// Load returns the stored subscriptions ordered by key.
+func (s *MemorySubscriptionStore) Load() ([]Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedSubscriptions(s.subs), nil
+}
+
+// FileSubscriptionStore is a SubscriptionStore that keeps subscriptions in a JSON file.
+// Every change rewrites the file atomically.
+type FileSubscriptionStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSubscriptionStore creates a FileSubscriptionStore backed by the file at path.
+// The file is created on the first Save.
+func NewFileSubscriptionStore(path string) *FileSubscriptionStore {
+	return &FileSubscriptionStore{path: path}
+}
+
+// Save stores the subscription.
+func (s *FileSubscriptionStore) Save(sub Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs, err := s.read()
+	if err != nil {
+		return err
+	}
+	subs[sub.Key] = sub
+	return s.write(subs)
+}
+
+// Delete removes the subscription with the given key.
+func (s *FileSubscriptionStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := subs[key]; !ok {
+		return nil
+	}
+	delete(subs, key)
+	return s.write(subs)
+}
+
+// Load returns the stored subscriptions ordered by key.
+func (s *FileSubscriptionStore) Load() ([]Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return sortedSubscriptions(subs), nil
+}
+
+// read loads the subscriptions from the file, treating a missing file as empty.
+func (s *FileSubscriptionStore) read() (map[string]Subscription, error) {
+	subs := make(map[string]Subscription)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return subs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Subscription
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("decoding subscriptions from %s: %w", s.path, err)
+	}
+	for _, sub := range list {
+		subs[sub.Key] = sub
+	}
+	return subs, nil
+}
+
+// write replaces the file with the given subscriptions.
+func (s *FileSubscriptionStore) write(subs map[string]Subscription) error {
+	data, err := json.MarshalIndent(sortedSubscriptions(subs), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(s.path, data)
+}
+
+// sortedSubscriptions returns the subscriptions of the map ordered by key.
+func sortedSubscriptions(subs map[string]Subscription) []Subscription {
+	list := make([]Subscription, 0, len(subs))
+	for _, sub := range subs {
+		list = append(list, sub)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
+}
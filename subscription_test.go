@@ -0,0 +1,207 @@
+package emitter
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDurableSubscriptionsRestoreAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subscriptions.json")
+
+	var received []string
+	newHandlers := func() *HandlerRegistry {
+		handlers := NewHandlerRegistry()
+		handlers.Register("webhook", func(e Event) error {
+			received = append(received, e.Topic())
+			return nil
+		})
+		return handlers
+	}
+
+	// First run: subscribe dynamically configured integrations.
+	first := NewMemoryEmitter()
+	subs := NewDurableSubscriptions(first, NewFileSubscriptionStore(path), newHandlers())
+	if err := subs.Subscribe(Subscription{Key: "crm", Topic: "customer.*", Handler: "webhook", Priority: High}); err != nil {
+		t.Fatalf("Subscribe() failed with error: %v", err)
+	}
+	if err := subs.Subscribe(Subscription{Key: "audit", Topic: "**", Handler: "webhook"}); err != nil {
+		t.Fatalf("Subscribe() failed with error: %v", err)
+	}
+	if err := subs.Unsubscribe("audit"); err != nil {
+		t.Fatalf("Unsubscribe() failed with error: %v", err)
+	}
+
+	// Second run: a fresh emitter restores the stored subscriptions.
+	second := NewMemoryEmitter()
+	restored := NewDurableSubscriptions(second, NewFileSubscriptionStore(path), newHandlers())
+	if err := restored.Restore(); err != nil {
+		t.Fatalf("Restore() failed with error: %v", err)
+	}
+
+	got := restored.Subscriptions()
+	if len(got) != 1 || got[0].Key != "crm" || got[0].Priority != High {
+		t.Fatalf("Subscriptions() = %+v, want the crm subscription with high priority", got)
+	}
+
+	second.EmitSync("customer.created", nil)
+	second.EmitSync("order.created", nil)
+	if len(received) != 1 || received[0] != "customer.created" {
+		t.Errorf("Received topics = %v, want [customer.created]", received)
+	}
+
+	// Restoring twice does not attach duplicates.
+	if err := restored.Restore(); err != nil {
+		t.Fatalf("Restore() failed with error: %v", err)
+	}
+	second.EmitSync("customer.updated", nil)
+	if len(received) != 2 {
+		t.Errorf("Handler called %d times, want 2", len(received))
+	}
+}
+
+func TestDurableSubscriptionsReplaceByKey(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	handlers := NewHandlerRegistry()
+	calls := 0
+	handlers.Register("count", func(e Event) error {
+		calls++
+		return nil
+	})
+
+	subs := NewDurableSubscriptions(emitter, NewMemorySubscriptionStore(), handlers)
+	subs.Subscribe(Subscription{Key: "k", Topic: "a", Handler: "count"})
+	subs.Subscribe(Subscription{Key: "k", Topic: "b", Handler: "count"})
+
+	emitter.EmitSync("a", nil)
+	emitter.EmitSync("b", nil)
+	if calls != 1 {
+		t.Errorf("Handler called %d times, want 1 after replacing the subscription", calls)
+	}
+}
+
+// failingStore is a SubscriptionStore whose saves fail once fail is set. It calls
+// saving, if set, before each save.
+type failingStore struct {
+	*MemorySubscriptionStore
+	fail   bool
+	saving func()
+}
+
+func (s *failingStore) Save(sub Subscription) error {
+	if s.saving != nil {
+		s.saving()
+	}
+	if s.fail {
+		return errors.New("store unavailable")
+	}
+	return s.MemorySubscriptionStore.Save(sub)
+}
+
+func TestDurableSubscriptionsReplaceSaveFailure(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	handlers := NewHandlerRegistry()
+	var topics []string
+	handlers.Register("record", func(e Event) error {
+		topics = append(topics, e.Topic())
+		return nil
+	})
+
+	store := &failingStore{MemorySubscriptionStore: NewMemorySubscriptionStore()}
+	subs := NewDurableSubscriptions(emitter, store, handlers)
+	subs.Subscribe(Subscription{Key: "k", Topic: "a", Handler: "record"})
+	store.fail = true
+	if err := subs.Subscribe(Subscription{Key: "k", Topic: "b", Handler: "record"}); err == nil {
+		t.Fatal("Subscribe() should fail when the store cannot save")
+	}
+
+	// The previous subscription stays attached, matching the store.
+	emitter.EmitSync("a", nil)
+	emitter.EmitSync("b", nil)
+	if len(topics) != 1 || topics[0] != "a" {
+		t.Errorf("Handler called for %v, want [a]", topics)
+	}
+	if got := subs.Subscriptions(); len(got) != 1 || got[0].Topic != "a" {
+		t.Errorf("Subscriptions() = %v, want the previous subscription", got)
+	}
+}
+
+func TestDurableSubscriptionsReplaceDetachesFirst(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	handlers := NewHandlerRegistry()
+	var calls []string
+	handlers.Register("old", func(Event) error {
+		calls = append(calls, "old")
+		return nil
+	})
+	handlers.Register("new", func(Event) error {
+		calls = append(calls, "new")
+		return nil
+	})
+
+	store := &failingStore{MemorySubscriptionStore: NewMemorySubscriptionStore()}
+	subs := NewDurableSubscriptions(emitter, store, handlers)
+	subs.Subscribe(Subscription{Key: "k", Topic: "a", Handler: "old"})
+	if err := subs.Subscribe(Subscription{Key: "k", Topic: "a", Handler: "missing"}); !errors.Is(err, ErrHandlerNotFound) {
+		t.Fatalf("Subscribe() error = %v, want %v", err, ErrHandlerNotFound)
+	}
+	emitter.EmitSync("a", nil)
+	if len(calls) != 1 || calls[0] != "old" {
+		t.Errorf("Handlers called %v, want the previous subscription attached again", calls)
+	}
+
+	// An event emitted while the replacement is saved reaches only one of them.
+	calls = nil
+	store.saving = func() { emitter.EmitSync("a", nil) }
+	subs.Subscribe(Subscription{Key: "k", Topic: "a", Handler: "new"})
+	if len(calls) != 1 || calls[0] != "new" {
+		t.Errorf("Handlers called %v while saving, want only the replacement", calls)
+	}
+}
+
+func TestDurableSubscriptionsErrors(t *testing.T) {
+	emitter := NewMemoryEmitter()
+	store := NewMemorySubscriptionStore()
+	subs := NewDurableSubscriptions(emitter, store, NewHandlerRegistry())
+
+	if err := subs.Subscribe(Subscription{Key: "k", Topic: "a", Handler: "missing"}); !errors.Is(err, ErrHandlerNotFound) {
+		t.Errorf("Subscribe() error = %v, want %v", err, ErrHandlerNotFound)
+	}
+	if err := subs.Subscribe(Subscription{Topic: "a", Handler: "missing"}); !errors.Is(err, ErrInvalidHandler) {
+		t.Errorf("Subscribe() error = %v, want %v", err, ErrInvalidHandler)
+	}
+	if err := subs.Unsubscribe("k"); !errors.Is(err, ErrSubscriptionNotFound) {
+		t.Errorf("Unsubscribe() error = %v, want %v", err, ErrSubscriptionNotFound)
+	}
+
+	store.Save(Subscription{Key: "orphan", Topic: "a", Handler: "gone"})
+	err := subs.Restore()
+	if !errors.Is(err, ErrHandlerNotFound) || !strings.Contains(err.Error(), `"orphan"`) {
+		t.Errorf("Restore() error = %v, want a missing handler error for the orphan subscription", err)
+	}
+}
+
+func TestPriorityTextEncoding(t *testing.T) {
+	data, err := json.Marshal([]Priority{High, Priority(7)})
+	if err != nil {
+		t.Fatalf("Marshal() failed with error: %v", err)
+	}
+	if string(data) != `["high","7"]` {
+		t.Errorf("Marshal() = %s, want [\"high\",\"7\"]", data)
+	}
+
+	var decoded []Priority
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() failed with error: %v", err)
+	}
+	if decoded[0] != High || decoded[1] != Priority(7) {
+		t.Errorf("Unmarshal() = %v, want [high 7]", decoded)
+	}
+
+	var p Priority
+	if err := p.UnmarshalText([]byte("urgent")); !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("UnmarshalText() error = %v, want %v", err, ErrInvalidPriority)
+	}
+}
@@ -0,0 +1,74 @@
+package emitter
+
+import (
+	"reflect"
+	"sync"
+)
+
+// WithSuppressUnchanged drops emissions on topics matching pattern whose payload equals
+// the payload last dispatched on the same topic, so that loops republishing state only
+// notify listeners of actual changes. A nil equal compares payloads with
+// reflect.DeepEqual. Emissions made with WithForceEmit are dispatched regardless.
+func WithSuppressUnchanged(pattern string, equal func(previous, next interface{}) bool) EmitterOption {
+	return func(m Emitter) {
+		m.SetSuppressUnchanged(pattern, equal)
+	}
+}
+
+// WithForceEmit dispatches the emission even if its payload is unchanged on a topic
+// configured with WithSuppressUnchanged.
+func WithForceEmit() EmitOption {
+	return func(cfg *emitConfig) {
+		cfg.force = true
+	}
+}
+
+// SetSuppressUnchanged drops emissions on topics matching pattern whose payload equals the
+// payload last dispatched on the same topic, compared with equal or, if nil,
+// reflect.DeepEqual. The last payload of every matching topic is kept until the emitter
+// is garbage collected. When several patterns match a topic, the first one set applies.
+func (m *MemoryEmitter) SetSuppressUnchanged(pattern string, equal func(previous, next interface{}) bool) {
+	m.suppressions = append(m.suppressions, &suppression{pattern: pattern, equal: equal, last: make(map[string]interface{})})
+}
+
+// suppression tracks the last payloads of the topics matching a pattern set with
+// SetSuppressUnchanged.
+type suppression struct {
+	pattern string
+	equal   func(previous, next interface{}) bool
+	mu      sync.Mutex
+	last    map[string]interface{} // Last payload dispatched, by topic.
+}
+
+// unchanged reports whether the event must be dropped because its payload did not
+// change, and records it as the topic's last payload otherwise.
+func (m *MemoryEmitter) unchanged(event *BaseEvent) bool {
+	if len(m.suppressions) == 0 {
+		return false
+	}
+
+	topic := event.Topic()
+	profile := m.topics.load().profile
+	for _, s := range m.suppressions {
+		if s.pattern != topic && !profile.Match(s.pattern, topic) {
+			continue
+		}
+		payload := event.Payload()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if previous, ok := s.last[topic]; ok && !event.forced && s.isEqual(previous, payload) {
+			return true
+		}
+		s.last[topic] = payload
+		return false
+	}
+	return false
+}
+
+// isEqual compares two payloads of the suppression's topics.
+func (s *suppression) isEqual(previous, next interface{}) bool {
+	if s.equal != nil {
+		return s.equal(previous, next)
+	}
+	return reflect.DeepEqual(previous, next)
+}
@@ -0,0 +1,61 @@
+package emitter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithSuppressUnchanged(t *testing.T) {
+	e := NewMemoryEmitter(WithSuppressUnchanged("state.*", nil))
+	defer e.Close()
+
+	var seen []interface{}
+	e.On("state.*", func(evt Event) error {
+		seen = append(seen, evt.Payload())
+		return nil
+	})
+
+	e.EmitSync("state.sync", map[string]int{"users": 1})
+	e.EmitSync("state.sync", map[string]int{"users": 1})
+	e.EmitSync("state.other", map[string]int{"users": 1})
+	e.EmitSync("state.sync", map[string]int{"users": 2})
+	e.EmitSync("state.sync", map[string]int{"users": 2}, WithForceEmit())
+	<-e.Emit("state.sync", map[string]int{"users": 2})
+
+	want := []interface{}{
+		map[string]int{"users": 1},
+		map[string]int{"users": 1},
+		map[string]int{"users": 2},
+		map[string]int{"users": 2},
+	}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("listener saw %v, want %v", seen, want)
+	}
+}
+
+func TestWithSuppressUnchangedEqual(t *testing.T) {
+	type reading struct {
+		Value int
+		At    int
+	}
+	sameValue := func(previous, next interface{}) bool {
+		return previous.(reading).Value == next.(reading).Value
+	}
+	e := NewMemoryEmitter(WithSuppressUnchanged("sensor", sameValue))
+	defer e.Close()
+
+	var seen []interface{}
+	e.On("sensor", func(evt Event) error {
+		seen = append(seen, evt.Payload())
+		return nil
+	})
+
+	e.EmitSync("sensor", reading{Value: 1, At: 1})
+	e.EmitSync("sensor", reading{Value: 1, At: 2})
+	e.EmitSync("sensor", reading{Value: 3, At: 3})
+
+	want := []interface{}{reading{Value: 1, At: 1}, reading{Value: 3, At: 3}}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("listener saw %v, want %v", seen, want)
+	}
+}
@@ -0,0 +1,138 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+)
+
+// TeeOriginKey is the metadata key under which a TeeEmitter records its origin in the
+// envelopes it publishes, so that it can recognize its own events when the backend
+// delivers them back.
+const TeeOriginKey = "emitter.origin"
+
+// Publisher publishes the envelopes of events to a remote backend, such as a message
+// broker.
+type Publisher interface {
+	// Publish sends the envelope to the backend.
+	Publish(ctx context.Context, env *Envelope) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface.
+type PublisherFunc func(ctx context.Context, env *Envelope) error
+
+// Publish calls f.
+func (f PublisherFunc) Publish(ctx context.Context, env *Envelope) error {
+	return f(ctx, env)
+}
+
+// TeeEmitter is a MemoryEmitter whose events are also published to a remote backend.
+// Events emitted on it are dispatched to its own listeners first and then published,
+// unless they were aborted. The events the backend delivers are handed to Receive,
+// which dispatches those of other processes to the local listeners and drops the
+// emitter's own, so that they are not handled twice.
+type TeeEmitter struct {
+	*MemoryEmitter
+	publisher   Publisher
+	codec       Codec
+	origin      string
+	filter      func(Event) bool // Decides which events are published.
+	emitterOpts []EmitterOption
+}
+
+// TeeOption defines a function type for TeeEmitter configuration options.
+type TeeOption func(*TeeEmitter)
+
+// WithTeeEmitterOptions applies regular emitter options to the tee's own MemoryEmitter.
+func WithTeeEmitterOptions(opts ...EmitterOption) TeeOption {
+	return func(t *TeeEmitter) {
+		t.emitterOpts = append(t.emitterOpts, opts...)
+	}
+}
+
+// WithTeeCodec sets the codec encoding the payloads of published events. The default is
+// JSONCodec.
+func WithTeeCodec(codec Codec) TeeOption {
+	return func(t *TeeEmitter) {
+		t.codec = codec
+	}
+}
+
+// WithTeeOrigin sets the origin recorded in published envelopes. It defaults to a
+// unique ID, and must differ between the processes sharing the backend.
+func WithTeeOrigin(origin string) TeeOption {
+	return func(t *TeeEmitter) {
+		t.origin = origin
+	}
+}
+
+// WithTeeFilter restricts which events emitted on the tee are published. The others
+// are only dispatched locally.
+func WithTeeFilter(filter func(Event) bool) TeeOption {
+	return func(t *TeeEmitter) {
+		t.filter = filter
+	}
+}
+
+// NewTeeEmitter creates a TeeEmitter publishing its events with publisher.
+func NewTeeEmitter(publisher Publisher, opts ...TeeOption) *TeeEmitter {
+	t := &TeeEmitter{publisher: publisher, codec: JSONCodec{}}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.origin == "" {
+		t.origin = DefaultIDGenerator()
+	}
+
+	t.MemoryEmitter = NewMemoryEmitter(t.emitterOpts...)
+	t.SetForwarder(ForwarderFunc(t.publishRemote))
+	if attacher, ok := publisher.(teeAttacher); ok {
+		attacher.attachTee(t)
+	}
+	return t
+}
+
+// Origin returns the origin the tee records in the envelopes it publishes.
+func (t *TeeEmitter) Origin() string {
+	return t.origin
+}
+
+// teeAttacher is implemented by publishers that schedule work on the tee's clock and
+// report their state on the tee, such as FailoverPublisher.
+type teeAttacher interface {
+	attachTee(t *TeeEmitter)
+}
+
+// publishRemote sends an event dispatched on the tee to the remote backend.
+func (t *TeeEmitter) publishRemote(ctx context.Context, evt Event) error {
+	if t.filter != nil && !t.filter(evt) {
+		return nil
+	}
+
+	env, err := NewEnvelope(evt, t.codec)
+	if err != nil {
+		return err
+	}
+	metadata := make(map[string]interface{}, len(env.Metadata)+1)
+	for k, v := range env.Metadata {
+		metadata[k] = v
+	}
+	metadata[TeeOriginKey] = t.origin
+	env.Metadata = metadata
+	return t.publisher.Publish(ctx, env)
+}
+
+// Receive dispatches an event delivered by the remote backend to the local listeners,
+// and returns their errors. Envelopes the tee published itself are dropped, and the
+// received events are never published again.
+func (t *TeeEmitter) Receive(ctx context.Context, env *Envelope) error {
+	if origin, ok := env.Metadata[TeeOriginKey]; ok && origin == t.origin {
+		return nil // The event originated here and was already dispatched.
+	}
+	return errors.Join(t.DispatchLocal(ctx, env.Event())...)
+}
+
+// publishLocal dispatches an event generated by the tee itself to the local listeners,
+// discarding their errors.
+func (t *TeeEmitter) publishLocal(topic string, payload interface{}) {
+	t.DispatchLocal(context.Background(), NewBaseEvent(topic, payload))
+}
@@ -0,0 +1,108 @@
+package emitter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// loopbackBroker delivers every published envelope to all the subscribed tees,
+// including the one that published it, like a broker topic shared by processes.
+type loopbackBroker struct {
+	mu   sync.Mutex
+	tees []*TeeEmitter
+	errs []error
+}
+
+func (b *loopbackBroker) Publish(ctx context.Context, env *Envelope) error {
+	b.mu.Lock()
+	tees := append([]*TeeEmitter(nil), b.tees...)
+	b.mu.Unlock()
+	for _, tee := range tees {
+		if err := tee.Receive(ctx, env); err != nil {
+			b.mu.Lock()
+			b.errs = append(b.errs, err)
+			b.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+func TestTeeEmitter(t *testing.T) {
+	broker := &loopbackBroker{}
+	local := NewTeeEmitter(broker, WithTeeOrigin("local"))
+	remote := NewTeeEmitter(broker, WithTeeOrigin("remote"))
+	broker.tees = []*TeeEmitter{local, remote}
+	defer local.Close()
+	defer remote.Close()
+
+	var mu sync.Mutex
+	seen := map[string][]interface{}{}
+	record := func(name string) Listener {
+		return func(evt Event) error {
+			mu.Lock()
+			seen[name] = append(seen[name], evt.Payload())
+			mu.Unlock()
+			return nil
+		}
+	}
+	local.On("order.created", record("local"))
+	remote.On("order.created", record("remote"))
+
+	if errs := local.EmitSync("order.created", "o-1"); len(errs) != 0 {
+		t.Fatalf("EmitSync() = %v, want no error", errs)
+	}
+	for range remote.Emit("order.created", "o-2") {
+	}
+
+	if got := seen["local"]; len(got) != 2 || got[0] != "o-1" || string(got[1].(json.RawMessage)) != `"o-2"` {
+		t.Errorf("local listener saw %v, want o-1 locally and o-2 from the backend", got)
+	}
+	if got := seen["remote"]; len(got) != 2 || string(got[0].(json.RawMessage)) != `"o-1"` || got[1] != "o-2" {
+		t.Errorf("remote listener saw %v, want o-1 from the backend and o-2 locally", got)
+	}
+	if len(broker.errs) != 0 {
+		t.Errorf("Receive() errors = %v", broker.errs)
+	}
+}
+
+func TestTeeEmitterPublishErrors(t *testing.T) {
+	errBroker := errors.New("broker unavailable")
+	published := 0
+	tee := NewTeeEmitter(PublisherFunc(func(ctx context.Context, env *Envelope) error {
+		published++
+		if env.Metadata[TeeOriginKey] != "origin" {
+			t.Errorf("published metadata = %v, want the origin", env.Metadata)
+		}
+		if env.Topic == "order.failed" {
+			return errBroker
+		}
+		return nil
+	}), WithTeeOrigin("origin"), WithTeeFilter(func(evt Event) bool {
+		return evt.Topic() != "cache.evicted"
+	}))
+	defer tee.Close()
+
+	called := 0
+	tee.On("**", func(Event) error {
+		called++
+		return nil
+	})
+
+	errs := tee.EmitSync("order.failed", "o-1")
+	var emitErr *EmitError
+	if len(errs) != 1 || !errors.As(errs[0], &emitErr) || emitErr.Op != OpPublish || !errors.Is(errs[0], errBroker) {
+		t.Errorf("EmitSync() = %v, want a publish error wrapping %v", errs, errBroker)
+	}
+	tee.EmitSync("cache.evicted", "k")
+	if called != 2 || published != 1 {
+		t.Errorf("%d local deliveries and %d publishes, want 2 and 1", called, published)
+	}
+
+	env := &Envelope{Topic: "order.created", Metadata: map[string]interface{}{TeeOriginKey: "origin"}, ContentType: JSONContentType, Payload: []byte(`"o-2"`)}
+	if err := tee.Receive(context.Background(), env); err != nil || called != 2 {
+		t.Errorf("Receive() of an own envelope = %v with %d deliveries, want it dropped", err, called)
+	}
+}
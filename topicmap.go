@@ -0,0 +1,79 @@
+package emitter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TopicMapper translates topic names between an emitter and an external system, such
+// as a message broker, whose naming conventions differ. Transports translate outgoing
+// topics with ToExternal and incoming names with ToLocal.
+type TopicMapper interface {
+	// ToExternal returns the external name of a local topic.
+	ToExternal(topic string) (string, error)
+	// ToLocal returns the local topic of an external name.
+	ToLocal(name string) (string, error)
+}
+
+// TopicRule maps the local topics starting with the segments of Local to the external
+// names starting with the segments of External. The remaining segments are kept.
+type TopicRule struct {
+	Local    string // Leading segments of local topics, such as "order".
+	External string // Leading segments of external names, such as "prod.orders".
+}
+
+// TopicMap is a TopicMapper translating topics with a list of rules, so that with the
+// rule {Local: "order", External: "prod.orders"}, "order.created" and
+// "prod.orders.created" translate into each other. In each direction the first rule
+// whose leading segments match applies; topics no rule matches are refused with
+// ErrUnmappedTopic.
+type TopicMap struct {
+	rules []TopicRule
+}
+
+// NewTopicMap creates a TopicMap with the given rules.
+func NewTopicMap(rules ...TopicRule) *TopicMap {
+	return &TopicMap{rules: rules}
+}
+
+// ToExternal returns the external name of a local topic.
+func (m *TopicMap) ToExternal(topic string) (string, error) {
+	for _, rule := range m.rules {
+		if name, ok := replacePrefix(topic, rule.Local, rule.External); ok {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w: local topic %q", ErrUnmappedTopic, topic)
+}
+
+// ToLocal returns the local topic of an external name.
+func (m *TopicMap) ToLocal(name string) (string, error) {
+	for _, rule := range m.rules {
+		if topic, ok := replacePrefix(name, rule.External, rule.Local); ok {
+			return topic, nil
+		}
+	}
+	return "", fmt.Errorf("%w: external name %q", ErrUnmappedTopic, name)
+}
+
+// replacePrefix replaces the leading segments from of topic with to. It reports false if
+// topic does not start with the segments of from.
+func replacePrefix(topic, from, to string) (string, bool) {
+	switch {
+	case topic == from:
+		return to, true
+	case from == "":
+		return joinSegments(to, topic), true
+	case strings.HasPrefix(topic, from) && topic[len(from)] == '.':
+		return joinSegments(to, topic[len(from)+1:]), true
+	}
+	return "", false
+}
+
+// joinSegments joins two dot-separated parts of a topic, either of which may be empty.
+func joinSegments(head, tail string) string {
+	if head == "" || tail == "" {
+		return head + tail
+	}
+	return head + "." + tail
+}
@@ -0,0 +1,36 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTopicMap(t *testing.T) {
+	m := NewTopicMap(
+		TopicRule{Local: "order", External: "prod.orders"},
+		TopicRule{Local: "", External: "prod.misc"},
+	)
+
+	tests := []struct{ local, external string }{
+		{"order.created", "prod.orders.created"},
+		{"order", "prod.orders"},
+		{"order.item.added", "prod.orders.item.added"},
+		{"orders.created", "prod.misc.orders.created"},
+		{"user", "prod.misc.user"},
+	}
+	for _, tc := range tests {
+		if got, err := m.ToExternal(tc.local); err != nil || got != tc.external {
+			t.Errorf("ToExternal(%q) = %q, %v, want %q", tc.local, got, err, tc.external)
+		}
+		if got, err := m.ToLocal(tc.external); err != nil || got != tc.local {
+			t.Errorf("ToLocal(%q) = %q, %v, want %q", tc.external, got, err, tc.local)
+		}
+	}
+
+	if _, err := m.ToLocal("staging.orders.created"); !errors.Is(err, ErrUnmappedTopic) {
+		t.Errorf("ToLocal() of an unmapped name error = %v, want %v", err, ErrUnmappedTopic)
+	}
+	if _, err := NewTopicMap().ToExternal("order.created"); !errors.Is(err, ErrUnmappedTopic) {
+		t.Errorf("ToExternal() without rules error = %v, want %v", err, ErrUnmappedTopic)
+	}
+}
@@ -3,6 +3,8 @@ package emitter
 import (
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Topic represents an event channel to which listeners can subscribe.
@@ -11,6 +13,13 @@ type Topic struct {
 	mu                sync.RWMutex
 	listeners         map[string]*listenerItem // Map of listeners indexed by their ID.
 	sortedListenerIDs []string                 // Sorted list of listener IDs for priority-based iteration.
+	designatedAborts  int                      // Number of listeners explicitly allowed to abort.
+	metrics           topicMetrics             // Emission activity of the topic.
+	matchCost         matchCost                // Time spent matching subjects against the topic's pattern.
+	pendingMu         sync.Mutex               // Guards pending; also held while listeners and sortedListenerIDs change.
+	pending           []string                 // IDs of listeners removed during an emission, awaiting removal.
+	pendingCount      atomic.Int32             // Length of pending, readable without the lock.
+	dispatching       atomic.Int32             // Number of emissions notifying the listeners.
 }
 
 // NewTopic creates a new Topic.
@@ -45,6 +54,11 @@ func (t *Topic) removeSortedListenerID(id string) {
 
 // AddListener adds a new listener to the topic with a specified priority and returns an identifier for the listener.
 func (t *Topic) AddListener(id string, listener Listener, opts ...ListenerOption) {
+	t.addListener(id, listener, opts...)
+}
+
+// addListener adds a new listener to the topic and returns the configured listener item.
+func (t *Topic) addListener(id string, listener Listener, opts ...ListenerOption) *listenerItem {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -57,42 +71,286 @@ func (t *Topic) AddListener(id string, listener Listener, opts ...ListenerOption
 		opt(item)
 	}
 
+	if item.remove == nil {
+		item.remove = func() error { return t.RemoveListener(id) }
+	}
+
+	t.pendingMu.Lock()
 	t.listeners[id] = item
 	t.addSortedListenerID(id, item.priority)
+	t.pendingMu.Unlock()
+	if item.canAbort == abortAllowed {
+		t.designatedAborts++
+	}
+	return item
+}
+
+// setExpiry records the timer that removes an expiring listener, so that removing the
+// listener earlier can stop it.
+func (t *Topic) setExpiry(item *listenerItem, timer Timer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	item.expiry = timer
 }
 
-// RemoveListener removes a listener from the topic using its identifier.
+// RemoveListener removes a listener from the topic using its identifier. While an
+// emission notifies the topic's listeners, it holds the topic's lock, and the caller may
+// be one of them; the removal is then deferred until the emission finishes, and the
+// listener is no longer notified in the meantime.
 func (t *Topic) RemoveListener(id string) error {
+	if t.dispatching.Load() > 0 {
+		err := t.deferRemoval(id)
+		t.applyDeferredRemovals() // In case the emissions finished meanwhile.
+		return err
+	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	if _, ok := t.listeners[id]; !ok {
+	if item, ok := t.listeners[id]; !ok || item.removed.Load() {
 		return ErrListenerNotFound
 	}
+	t.removeLocked(id)
+	return nil
+}
+
+// removeLocked removes a listener, if present. Callers must hold the write lock.
+func (t *Topic) removeLocked(id string) {
+	item, ok := t.listeners[id]
+	if !ok {
+		return
+	}
 
+	if item.canAbort == abortAllowed {
+		t.designatedAborts--
+	}
+	if item.expiry != nil {
+		item.expiry.Stop()
+	}
+	if item.errs != nil {
+		item.errs.close()
+	}
+	if item.batch != nil {
+		item.batch.detach()
+	}
+	t.pendingMu.Lock()
 	delete(t.listeners, id)
 	t.removeSortedListenerID(id)
+	t.pendingMu.Unlock()
+}
+
+// removeAll removes every listener of the topic and returns their IDs. During an
+// emission, the removals are deferred like RemoveListener's.
+func (t *Topic) removeAll() []string {
+	if t.dispatching.Load() > 0 {
+		t.pendingMu.Lock()
+		ids := append([]string(nil), t.sortedListenerIDs...)
+		t.pendingMu.Unlock()
+
+		removed := ids[:0]
+		for _, id := range ids {
+			if t.deferRemoval(id) == nil {
+				removed = append(removed, id)
+			}
+		}
+		t.applyDeferredRemovals()
+		return removed
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ids := make([]string, 0, len(t.sortedListenerIDs))
+	for _, id := range append([]string(nil), t.sortedListenerIDs...) {
+		if !t.listeners[id].removed.Load() {
+			ids = append(ids, id)
+		}
+		t.removeLocked(id)
+	}
+	return ids
+}
+
+// deferRemoval queues the removal of a listener requested during an emission, without
+// taking the topic's lock, which the calling goroutine may hold already.
+func (t *Topic) deferRemoval(id string) error {
+	t.pendingMu.Lock()
+	defer t.pendingMu.Unlock()
 
+	item, ok := t.listeners[id]
+	if !ok || !item.removed.CompareAndSwap(false, true) {
+		return ErrListenerNotFound
+	}
+	t.pending = append(t.pending, id)
+	t.pendingCount.Add(1)
 	return nil
 }
 
-// Trigger calls all listeners of the topic with the event.
+// applyDeferredRemovals removes the listeners whose removal was requested during an
+// emission, once no emission notifies the topic anymore. The last emission to finish
+// applies them; the calling goroutine then cannot hold the read lock.
+func (t *Topic) applyDeferredRemovals() {
+	if t.pendingCount.Load() == 0 || t.dispatching.Load() > 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pendingMu.Lock()
+	ids := t.pending
+	t.pending = nil
+	t.pendingCount.Store(0)
+	t.pendingMu.Unlock()
+	for _, id := range ids {
+		t.removeLocked(id)
+	}
+}
+
+// ListenerCount returns the number of listeners subscribed to the topic.
+func (t *Topic) ListenerCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.listeners)
+}
+
+// Trigger calls all listeners of the topic with the event. When the event carries an
+// emission context, it is checked before each listener so the chain can be preempted.
 func (t *Topic) Trigger(event Event) []error {
+	if base, ok := event.(*BaseEvent); ok {
+		return t.trigger(base.listenerEvent(), base)
+	}
+	return t.trigger(event, nil)
+}
+
+// trigger calls all listeners of the topic with event. The envelope, if any, carries the
+// checkpoint state of the emission and counts the notified listeners. Listeners that
+// reached their maximum number of calls are removed afterwards.
+func (t *Topic) trigger(event Event, base *BaseEvent) []error {
+	errs, exhausted, demoted := t.notify(event, base)
+	t.applyDeferredRemovals()
+	for _, item := range exhausted {
+		_ = item.remove()
+	}
+	for _, item := range demoted {
+		item.health.applyDemotion()
+	}
+	return errs
+}
+
+// notify calls the listeners of the topic while holding the read lock and returns their
+// errors along with the listeners that must be removed because they were exhausted and
+// those due for a demotion under the emitter's DecayPolicy.
+func (t *Topic) notify(event Event, base *BaseEvent) (errs []error, exhausted, demoted []*listenerItem) {
+	t.dispatching.Add(1)
+	defer t.dispatching.Add(-1)
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	var errs []error
+	var em *emission
+	if base != nil {
+		em = base.checkpoint
+	}
+
+	var readOnly Event // Lazily created view for read-only listeners.
 	for _, id := range t.sortedListenerIDs {
 		item, ok := t.listeners[id]
-		if !ok {
+		if !ok || item.removed.Load() {
 			continue // Listener was removed; skip it.
 		}
-		if err := item.listener(event); err != nil {
-			errs = append(errs, err)
+		if stop, err := em.checkpoint(); stop {
+			if err != nil {
+				errs = append(errs, &EmitError{Op: OpNotify, Topic: event.Topic(), Pattern: t.Name, EventID: eventID(event), Err: err})
+			}
+			break
+		}
+		if item.filter != nil {
+			if readOnly == nil {
+				readOnly = newReadOnlyEvent(event)
+			}
+			if !item.filter(readOnly) {
+				continue
+			}
+		}
+		if item.maxCalls > 0 {
+			calls := item.calls.Add(1)
+			if calls > item.maxCalls {
+				continue // Exhausted by a concurrent emission; removal is pending.
+			}
+			if calls == item.maxCalls {
+				exhausted = append(exhausted, item)
+			}
+		}
+		if base != nil {
+			base.delivered.Add(1)
+			base.running, base.current, base.currentOn = id, item, t
+		}
+		target := event
+		if item.envelope && base != nil {
+			target = base
+		} else if item.cloned {
+			target = CloneEvent(event)
+			if item.readOnly {
+				target = newReadOnlyEvent(target)
+			}
+		} else if item.readOnly {
+			if readOnly == nil {
+				readOnly = newReadOnlyEvent(event)
+			}
+			target = readOnly
+		}
+		var start time.Time
+		if item.health != nil {
+			start = time.Now()
+		}
+		wasAborted := event.IsAborted()
+		err := deliver(item, target, base)
+		if base != nil {
+			// Deliver the event again to a listener that failed it, under AtLeastOnce.
+			for retries := base.redeliveries; err != nil && retries > 0 && !event.IsAborted() && !em.canceled(); retries-- {
+				err = deliver(item, target, base)
+			}
+		}
+		if item.health != nil && item.health.observe(err, time.Since(start)) {
+			demoted = append(demoted, item)
+		}
+		if base != nil {
+			base.running, base.current, base.currentOn = "", nil, nil
+		}
+		if err != nil {
+			errs = append(errs, item.emitError(event, t.Name, id, err))
+		}
+		if item.cloned && !item.readOnly && target != event && target.IsAborted() {
+			event.SetAborted(true)
 		}
 		if event.IsAborted() {
+			// Only the listener that aborted the event answers for it.
+			if !wasAborted && !t.mayAbort(item) {
+				// Undo the unauthorized abort and keep notifying listeners.
+				event.SetAborted(false)
+				errs = append(errs, item.emitError(event, t.Name, id, ErrAbortNotPermitted))
+				continue
+			}
 			break // Stop notifying listeners if the event is aborted.
 		}
 	}
-	return errs
+	return errs, exhausted, demoted
+}
+
+// deliver calls the listener with the event, recovering its panics if the emission's
+// emitter does.
+func deliver(item *listenerItem, target Event, base *BaseEvent) error {
+	if base != nil && base.recoverer != nil {
+		return base.recoverer.callRecovering(item, target, base)
+	}
+	return item.listener(target)
+}
+
+// mayAbort reports whether the listener is permitted to abort the topic's events.
+// Callers must hold t.mu.
+func (t *Topic) mayAbort(item *listenerItem) bool {
+	switch item.canAbort {
+	case abortAllowed:
+		return true
+	case abortDenied:
+		return false
+	default:
+		return t.designatedAborts == 0
+	}
 }
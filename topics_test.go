@@ -2,7 +2,9 @@ package emitter
 
 import (
 	"errors"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -72,10 +74,187 @@ func TestTriggerListeners(t *testing.T) {
 		errors := topic.Trigger(event)
 		if len(errors) != 1 {
 			t.Errorf("Trigger() should return exactly 1 error, got: %d", len(errors))
-		} else if errors[0].Error() != "listener error 2" {
-			t.Errorf("Trigger() should return 'listener error 2', got: %s", errors[0].Error())
+		} else if errors[0].Error() != `notify "test" listener 2: listener error 2` {
+			t.Errorf("Trigger() should return the wrapped 'listener error 2', got: %s", errors[0].Error())
 		}
 	}()
 
 	wg.Wait()
 }
+
+func TestAbortPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		loggerOpts  []ListenerOption
+		validator   []ListenerOption
+		wantReached bool
+		wantDenied  int
+	}{
+		{"any listener may abort by default", nil, nil, false, 0},
+		{"explicitly denied listener cannot abort", []ListenerOption{WithCanAbort(false)}, nil, true, 1},
+		{"designated aborters restrict others", nil, []ListenerOption{WithCanAbort(true)}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic := NewTopic()
+			reached := false
+
+			topic.AddListener("logger", func(e Event) error {
+				e.SetAborted(true) // Accidental abort by an observer.
+				return nil
+			}, append([]ListenerOption{WithPriority(Highest)}, tt.loggerOpts...)...)
+			topic.AddListener("validator", func(e Event) error {
+				return nil
+			}, append([]ListenerOption{WithPriority(High)}, tt.validator...)...)
+			topic.AddListener("handler", func(e Event) error {
+				reached = true
+				return nil
+			}, WithPriority(Low))
+
+			errs := topic.Trigger(NewBaseEvent("test", nil))
+
+			if reached != tt.wantReached {
+				t.Errorf("Handler reached = %v, want %v", reached, tt.wantReached)
+			}
+			denied := 0
+			for _, err := range errs {
+				if errors.Is(err, ErrAbortNotPermitted) {
+					denied++
+				}
+			}
+			if denied != tt.wantDenied {
+				t.Errorf("Denied aborts reported = %d, want %d (errors: %v)", denied, tt.wantDenied, errs)
+			}
+		})
+	}
+}
+
+func TestDesignatedAborterCanAbort(t *testing.T) {
+	topic := NewTopic()
+
+	topic.AddListener("validator", func(e Event) error {
+		e.SetAborted(true)
+		return nil
+	}, WithPriority(High), WithCanAbort(true))
+	topic.AddListener("handler", func(e Event) error {
+		t.Error("Handler should not run after a designated abort")
+		return nil
+	}, WithPriority(Low))
+
+	if errs := topic.Trigger(NewBaseEvent("test", nil)); len(errs) != 0 {
+		t.Errorf("Trigger() errors = %v, want none", errs)
+	}
+
+	// Removing the only designated aborter lifts the restriction.
+	topic.RemoveListener("validator")
+	if topic.designatedAborts != 0 {
+		t.Errorf("designatedAborts = %d, want 0 after removal", topic.designatedAborts)
+	}
+}
+
+func TestAbortOnEarlierTopicIsNotBlamed(t *testing.T) {
+	emitter := NewMemoryEmitter()
+
+	emitter.On("order.created", func(e Event) error {
+		e.SetAborted(true)
+		return nil
+	})
+	emitter.On("order.*", func(e Event) error {
+		t.Error("Listeners of later matched topics should not run after an abort")
+		return nil
+	}, WithCanAbort(false))
+
+	event := NewEvent("order.created")
+	if errs := emitter.EmitEventSync(event); len(errs) != 0 {
+		t.Errorf("EmitEventSync() errors = %v, want none", errs)
+	}
+	if !event.IsAborted() {
+		t.Error("Event should stay aborted")
+	}
+}
+
+func TestWithMaxCalls(t *testing.T) {
+	emitter := NewMemoryEmitter(WithLifecycleEvents())
+
+	removed := 0
+	emitter.On(TopicListenerRemoved, func(e Event) error {
+		removed++
+		return nil
+	})
+
+	var calls atomic.Int64
+	emitter.On("sample.taken", func(e Event) error {
+		calls.Add(1)
+		return nil
+	}, WithMaxCalls(3))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emitter.EmitSync("sample.taken", nil)
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("Listener called %d times, want 3", got)
+	}
+	if topic, _ := emitter.GetTopic("sample.taken"); topic.ListenerCount() != 0 {
+		t.Error("Exhausted listener should be unsubscribed")
+	}
+	if removed != 1 {
+		t.Errorf("Removal reported %d times, want 1", removed)
+	}
+}
+
+func TestTopicWithMaxCalls(t *testing.T) {
+	topic := NewTopic()
+	calls := 0
+	topic.AddListener("once", func(e Event) error {
+		calls++
+		return nil
+	}, WithMaxCalls(1))
+
+	topic.Trigger(NewBaseEvent("topic", nil))
+	topic.Trigger(NewBaseEvent("topic", nil))
+
+	if calls != 1 || topic.ListenerCount() != 0 {
+		t.Errorf("Listener called %d times with %d remaining, want 1 call and removal", calls, topic.ListenerCount())
+	}
+}
+
+func TestWithFilter(t *testing.T) {
+	e := NewMemoryEmitter()
+	defer e.Close()
+
+	var seen []string
+	e.On("order.*", func(evt Event) error {
+		seen = append(seen, "vip:"+evt.Payload().(string))
+		evt.SetAborted(true)
+		return nil
+	}, WithPriority(High), WithMaxCalls(1), WithFilter(func(evt Event) bool {
+		return evt.Payload() == "gold"
+	}))
+	e.On("order.*", func(evt Event) error {
+		seen = append(seen, "all:"+evt.Payload().(string))
+		return nil
+	}, WithFilter(func(evt Event) bool {
+		evt.SetPayload("changed")
+		return true
+	}))
+
+	if matches, _ := e.DryRun("order.created", "basic"); len(matches) != 1 {
+		t.Errorf("DryRun() returned %d matches, want 1 passing the filters", len(matches))
+	}
+	e.EmitSync("order.created", "basic")
+	e.EmitSync("order.created", "gold")
+	e.EmitSync("order.created", "gold")
+
+	want := []string{"all:basic", "vip:gold", "all:gold"}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("listeners saw %v, want %v", seen, want)
+	}
+}
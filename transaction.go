@@ -0,0 +1,94 @@
+package emitter
+
+import "context"
+
+// EventSpec describes one event of a batch emitted with EmitAll or EmitBatch.
+type EventSpec struct {
+	Topic   string      // Topic to emit the event on.
+	Payload interface{} // Payload of the event.
+}
+
+// Compensation is called once a batch emitted with EmitAll was dispatched, if any of its
+// events failed. It receives the whole batch and the errors, which name the failing
+// topics, so it can undo the batch's effects, for example by emitting compensating events.
+type Compensation func(batch []EventSpec, errs []error)
+
+// EmitAll queues a batch of correlated events with all-or-nothing semantics: either the
+// whole batch is accepted, or none of it is and the returned error says why, such as
+// ErrEmitterClosed or ErrPoolSaturated. The batch is queued as a single task, so a pool
+// never accepts part of it; its events are then dispatched in order.
+//
+// Every event is dispatched even if an earlier one failed. When any failed, compensate
+// is called, if not nil, before the returned channel receives the errors and is closed.
+func (m *MemoryEmitter) EmitAll(specs []EventSpec, compensate Compensation) (<-chan error, error) {
+	if m.closed.Load().(bool) {
+		return nil, &EmitError{Op: OpEmit, Topic: batchTopic(specs), Err: ErrEmitterClosed}
+	}
+
+	errChan := make(chan error, m.errChanBufferSize)
+	m.inflight.add()
+	if !m.trySubmit(m.batchTask(specs, compensate, errChan), Normal, batchTopic(specs)) {
+		m.inflight.done()
+		return nil, &EmitError{Op: OpEmit, Topic: batchTopic(specs), Err: ErrPoolSaturated}
+	}
+	return errChan, nil
+}
+
+// EmitBatch dispatches a batch of related events in order as a single pool task, rather
+// than one task and one error channel per event. Like Emit, it waits for the pool to
+// accept the task. The returned channel receives the errors of every event of the batch,
+// or ErrEmitterClosed, and is then closed. Use EmitAll to compensate failed batches.
+func (m *MemoryEmitter) EmitBatch(specs []EventSpec) <-chan error {
+	if m.closed.Load().(bool) {
+		errChan := make(chan error, 1)
+		errChan <- &EmitError{Op: OpEmit, Topic: batchTopic(specs), Err: ErrEmitterClosed}
+		close(errChan)
+		return errChan
+	}
+
+	errChan := make(chan error, m.errChanBufferSize)
+	m.inflight.add()
+	m.submit(m.batchTask(specs, nil, errChan), Normal, batchTopic(specs))
+	return errChan
+}
+
+// batchTask returns the task dispatching a copy of the batch in order. Once every event
+// was dispatched, it calls compensate, if any event failed and compensate is not nil,
+// sends the errors to errChan and closes it. The task marks the in-flight emission the
+// caller added as done.
+func (m *MemoryEmitter) batchTask(specs []EventSpec, compensate Compensation, errChan chan<- error) func() {
+	batch := append([]EventSpec(nil), specs...)
+	return func() {
+		defer m.inflight.done()
+		defer close(errChan)
+
+		ctx := context.Background()
+		if m.Pool != nil {
+			ctx = m.markPoolTask(ctx)
+		}
+		var errs []error
+		for _, spec := range batch {
+			event := NewBaseEvent(spec.Topic, spec.Payload)
+			func() {
+				defer m.recoverPanic(event)
+				m.emitEvent(ctx, event, func(err error) {
+					errs = append(errs, err)
+				})
+			}()
+		}
+		if len(errs) > 0 && compensate != nil {
+			compensate(batch, errs)
+		}
+		for _, err := range errs {
+			errChan <- err
+		}
+	}
+}
+
+// batchTopic names a batch in errors by the topic of its first event.
+func batchTopic(specs []EventSpec) string {
+	if len(specs) == 0 {
+		return ""
+	}
+	return specs[0].Topic
+}
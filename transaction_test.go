@@ -0,0 +1,144 @@
+package emitter
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestEmitAll(t *testing.T) {
+	e := NewMemoryEmitter()
+	var mu sync.Mutex
+	var received []string
+	e.On("order.*", func(evt Event) error {
+		mu.Lock()
+		received = append(received, evt.Topic())
+		mu.Unlock()
+		if evt.Topic() == "order.charged" {
+			return errors.New("card declined")
+		}
+		return nil
+	})
+
+	var compensated []EventSpec
+	var compensatedErrs []error
+	batch := []EventSpec{
+		{Topic: "order.created", Payload: "o-1"},
+		{Topic: "order.charged", Payload: "o-1"},
+		{Topic: "order.shipped", Payload: "o-1"},
+	}
+	errChan, err := e.EmitAll(batch, func(batch []EventSpec, errs []error) {
+		compensated, compensatedErrs = batch, errs
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	for err := range errChan {
+		errs = append(errs, err)
+	}
+
+	if len(received) != 3 || received[0] != "order.created" || received[2] != "order.shipped" {
+		t.Errorf("received = %v, want the whole batch in order", received)
+	}
+	var emitErr *EmitError
+	if len(errs) != 1 || !errors.As(errs[0], &emitErr) || emitErr.Topic != "order.charged" {
+		t.Errorf("errors = %v, want the order.charged failure", errs)
+	}
+	if len(compensated) != 3 || len(compensatedErrs) != 1 {
+		t.Errorf("compensation got %d events and %v, want the batch and its error", len(compensated), compensatedErrs)
+	}
+}
+
+func TestEmitAllWithoutFailures(t *testing.T) {
+	e := NewMemoryEmitter()
+	e.On("**", func(evt Event) error { return nil })
+	errChan, err := e.EmitAll([]EventSpec{{Topic: "a"}, {Topic: "b"}}, func([]EventSpec, []error) {
+		t.Error("compensation called without failures")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for err := range errChan {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestEmitAllRefused(t *testing.T) {
+	e := NewMemoryEmitter(WithPool(NewPondPool(1, 1)))
+	defer e.Close()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var received []string
+	e.On("job.*", func(evt Event) error {
+		if evt.Topic() == "job.block" {
+			started <- struct{}{}
+			<-release
+		}
+		mu.Lock()
+		received = append(received, evt.Topic())
+		mu.Unlock()
+		return nil
+	})
+	e.Emit("job.block") // Occupies the only worker.
+	<-started
+	e.Emit("job.queued") // Fills the queue.
+
+	_, err := e.EmitAll([]EventSpec{{Topic: "job.a"}, {Topic: "job.b"}}, nil)
+	if !errors.Is(err, ErrPoolSaturated) {
+		t.Errorf("EmitAll on a saturated pool error = %v, want ErrPoolSaturated", err)
+	}
+	close(release)
+	e.Close()
+	mu.Lock()
+	defer mu.Unlock()
+	for _, topic := range received {
+		if topic == "job.a" || topic == "job.b" {
+			t.Errorf("event %s of a refused batch was dispatched", topic)
+		}
+	}
+
+	if _, err := e.EmitAll([]EventSpec{{Topic: "job.a"}}, nil); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("EmitAll after Close error = %v, want ErrEmitterClosed", err)
+	}
+}
+
+func TestEmitBatch(t *testing.T) {
+	e := NewMemoryEmitter()
+	var received []string
+	e.On("order.*", func(evt Event) error {
+		received = append(received, evt.Topic()+":"+evt.Payload().(string))
+		if evt.Topic() == "order.charged" {
+			return errors.New("card declined")
+		}
+		return nil
+	})
+
+	var errs []error
+	for err := range e.EmitBatch([]EventSpec{
+		{Topic: "order.created", Payload: "o-1"},
+		{Topic: "order.charged", Payload: "o-1"},
+		{Topic: "order.shipped", Payload: "o-1"},
+	}) {
+		errs = append(errs, err)
+	}
+
+	if len(received) != 3 || received[0] != "order.created:o-1" || received[1] != "order.charged:o-1" || received[2] != "order.shipped:o-1" {
+		t.Errorf("received = %v, want the whole batch in order", received)
+	}
+	var emitErr *EmitError
+	if len(errs) != 1 || !errors.As(errs[0], &emitErr) || emitErr.Topic != "order.charged" {
+		t.Errorf("errors = %v, want the order.charged failure", errs)
+	}
+
+	e.Close()
+	errs = nil
+	for err := range e.EmitBatch([]EventSpec{{Topic: "order.created"}}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrEmitterClosed) {
+		t.Errorf("errors after Close = %v, want %v", errs, ErrEmitterClosed)
+	}
+}
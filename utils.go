@@ -1,7 +1,11 @@
 package emitter
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 var (
@@ -9,16 +13,60 @@ var (
 	MultiWildcard  = "**"
 )
 
+// maxInternedTopics bounds the number of topic names whose segments are interned.
+const maxInternedTopics = 4096
+
+// topicInterner caches the dot-separated segments of topic names and patterns so that
+// frequently emitted topics are split only once for the lifetime of the process.
+type topicInterner struct {
+	segments sync.Map // Maps a topic name to its read-only []string segments.
+	size     atomic.Int64
+	limit    int64
+}
+
+var defaultInterner = &topicInterner{limit: maxInternedTopics}
+
+// split returns the segments of the given topic name. The returned slice is shared
+// and must not be modified by the caller.
+func (i *topicInterner) split(name string) []string {
+	if cached, ok := i.segments.Load(name); ok {
+		return cached.([]string)
+	}
+
+	parts := strings.Split(name, ".")
+	if i.size.Load() < i.limit {
+		if actual, loaded := i.segments.LoadOrStore(name, parts); loaded {
+			return actual.([]string)
+		}
+		i.size.Add(1)
+	}
+	return parts
+}
+
+// splitTopic returns the interned segments of a topic name or pattern.
+func splitTopic(name string) []string {
+	return defaultInterner.split(name)
+}
+
+// MatchTopic reports whether the topic name matches the pattern, using the same
+// wildcard rules as listener subscriptions.
+func MatchTopic(pattern, topic string) bool {
+	return matchTopicPattern(pattern, topic)
+}
+
 // matchTopicPattern checks if the given subject matches the pattern with wildcards.
 func matchTopicPattern(pattern, subject string) bool {
+	return matchTopicSegments(pattern, subject, splitTopic(pattern), splitTopic(subject))
+}
+
+// matchTopicSegments checks if the subject matches the pattern using segments that were
+// already split, allowing callers to reuse the subject segments across many patterns.
+func matchTopicSegments(pattern, subject string, patternParts, subjectParts []string) bool {
 	// Special case: single wildcard matches an empty string
 	if pattern == SingleWildcard && subject == "" {
 		return true
 	}
 
-	patternParts := strings.Split(pattern, ".")
-	subjectParts := strings.Split(subject, ".")
-
 	// Handle the case where pattern ends with ".**", it should not match just "event"
 	if len(patternParts) > 1 && patternParts[len(patternParts)-1] == MultiWildcard && len(subjectParts) == 1 && subjectParts[0] == patternParts[0] {
 		return false
@@ -73,3 +121,22 @@ func matchTopicPattern(pattern, subject string) bool {
 func isValidTopicName(topicName string) bool {
 	return !strings.ContainsAny(topicName, "?[")
 }
+
+// writeFileAtomically replaces the file at path with data, writing a temporary file in
+// the same directory first so that readers never see a partial file.
+func writeFileAtomically(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once the rename succeeded.
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
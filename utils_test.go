@@ -62,3 +62,38 @@ func TestMatchTopicPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitTopicInterning(t *testing.T) {
+	first := splitTopic("event.some.thing")
+	second := splitTopic("event.some.thing")
+
+	if len(first) != 3 || first[0] != "event" || first[1] != "some" || first[2] != "thing" {
+		t.Fatalf("splitTopic() = %v, want [event some thing]", first)
+	}
+	if &first[0] != &second[0] {
+		t.Error("splitTopic() should return the interned segments for a repeated topic")
+	}
+}
+
+func TestTopicInternerLimit(t *testing.T) {
+	interner := &topicInterner{limit: 1}
+
+	interner.split("first.topic")
+	parts := interner.split("second.topic")
+
+	if len(parts) != 2 || parts[0] != "second" {
+		t.Fatalf("split() = %v, want [second topic]", parts)
+	}
+	if _, ok := interner.segments.Load("second.topic"); ok {
+		t.Error("split() should not intern topics beyond the configured limit")
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	if !MatchTopic("order.*", "order.created") {
+		t.Error("MatchTopic() should match a single wildcard segment")
+	}
+	if MatchTopic("order.*", "user.created") {
+		t.Error("MatchTopic() should not match a different topic")
+	}
+}
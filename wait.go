@@ -0,0 +1,30 @@
+package emitter
+
+import "context"
+
+// WaitFor blocks until the next event matching topicPattern is emitted and returns a
+// read-only view of it. It returns ctx.Err() if ctx is done first, and ErrEmitterClosed
+// if the emitter is closed meanwhile. The temporary listener it registers is removed in
+// every case:
+//
+//	evt, err := e.WaitFor(ctx, "job.*.done")
+func (m *MemoryEmitter) WaitFor(ctx context.Context, topicPattern string) (Event, error) {
+	received := make(chan Event, 1)
+	id, err := m.On(topicPattern, func(evt Event) error {
+		received <- evt
+		return nil
+	}, WithMaxCalls(1), WithReadOnlyEvent(), WithPriority(Highest))
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case evt := <-received:
+		return evt, nil
+	case <-ctx.Done():
+		_ = m.Off(topicPattern, id)
+		return nil, ctx.Err()
+	case <-m.background.done():
+		return nil, &EmitError{Op: OpEmit, Topic: topicPattern, Err: ErrEmitterClosed}
+	}
+}
@@ -0,0 +1,78 @@
+package emitter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitForListener blocks until the topic has a listener.
+func waitForListener(t *testing.T, e *MemoryEmitter, topicName string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if topic, err := e.GetTopic(topicName); err == nil && topic.ListenerCount() > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no listener subscribed to %s", topicName)
+}
+
+func TestWaitFor(t *testing.T) {
+	e := NewMemoryEmitter()
+	type result struct {
+		evt Event
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		evt, err := e.WaitFor(context.Background(), "job.*.done")
+		done <- result{evt, err}
+	}()
+	waitForListener(t, e, "job.*.done")
+
+	e.EmitSync("job.1.started")
+	e.EmitSync("job.1.done", "ok")
+	e.EmitSync("job.2.done", "ok")
+
+	r := <-done
+	if r.err != nil || r.evt.Topic() != "job.1.done" || r.evt.Payload() != "ok" {
+		t.Errorf("WaitFor() = %v, %v, want the job.1.done event", r.evt, r.err)
+	}
+	if topic, _ := e.GetTopic("job.*.done"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after WaitFor, want 0", topic.ListenerCount())
+	}
+}
+
+func TestWaitForCanceled(t *testing.T) {
+	e := NewMemoryEmitter()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := e.WaitFor(ctx, "never"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitFor() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if topic, _ := e.GetTopic("never"); topic.ListenerCount() != 0 {
+		t.Errorf("ListenerCount() = %d after WaitFor, want 0", topic.ListenerCount())
+	}
+}
+
+func TestWaitForClosed(t *testing.T) {
+	e := NewMemoryEmitter()
+	done := make(chan error, 1)
+	go func() {
+		_, err := e.WaitFor(context.Background(), "never")
+		done <- err
+	}()
+	waitForListener(t, e, "never")
+
+	e.Close()
+	if err := <-done; !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("WaitFor() error = %v, want %v", err, ErrEmitterClosed)
+	}
+	if _, err := e.WaitFor(context.Background(), "never"); !errors.Is(err, ErrEmitterClosed) {
+		t.Errorf("WaitFor() after Close error = %v, want %v", err, ErrEmitterClosed)
+	}
+}
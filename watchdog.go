@@ -0,0 +1,126 @@
+package emitter
+
+import (
+	"sync"
+	"time"
+)
+
+// TopicStaleness is the reserved topic on which watchdogs without a callback report
+// that an expected topic went quiet. Its payload is a *Staleness.
+const TopicStaleness = "emitter.staleness"
+
+// Staleness describes a topic that has not seen activity within the expected interval.
+type Staleness struct {
+	Pattern  string        // Topic name or pattern that went quiet.
+	Within   time.Duration // Interval within which activity was expected.
+	LastSeen time.Time     // Time of the last matching event, or the zero time if there was none.
+}
+
+// Watchdog watches a topic for activity. It is created with ExpectActivity.
+type Watchdog struct {
+	m          *MemoryEmitter
+	pattern    string
+	within     time.Duration
+	callback   func(*Staleness)
+	listenerID string
+
+	mu      sync.Mutex
+	since   time.Time // Start of the current quiet period: the last event or the start of the watch.
+	seen    time.Time // Time of the last matching event.
+	timer   Timer     // Pending check, or nil while an alert is outstanding.
+	stopped bool
+}
+
+// ExpectActivity starts a watchdog that reports when no event matching pattern was
+// emitted within the given interval, such as a heartbeat topic going silent. The report
+// goes to callback, or is published on TopicStaleness when callback is nil. A quiet
+// topic is reported once; the watchdog re-arms as soon as a matching event arrives.
+//
+// The watchdog observes events through a read-only listener of the highest priority and
+// uses the emitter's clock. Stop it with Watchdog.Stop.
+func (m *MemoryEmitter) ExpectActivity(pattern string, within time.Duration, callback func(*Staleness)) (*Watchdog, error) {
+	if within <= 0 {
+		return nil, &ConfigError{Op: "ExpectActivity", Err: ErrInvalidInterval}
+	}
+	w := &Watchdog{m: m, pattern: pattern, within: within, callback: callback, since: m.clock.Now()}
+
+	// Arm before subscribing so that events arriving meanwhile find the watch running.
+	w.mu.Lock()
+	w.arm(within)
+	w.mu.Unlock()
+
+	id, err := m.On(pattern, w.observe, WithReadOnlyEvent(), WithPriority(Highest))
+	if err != nil {
+		w.mu.Lock()
+		w.stopped = true
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+		w.mu.Unlock()
+		return nil, err
+	}
+	w.mu.Lock()
+	w.listenerID = id
+	w.mu.Unlock()
+	return w, nil
+}
+
+// Stop ends the watch and removes the watchdog's listener.
+func (w *Watchdog) Stop() error {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		return nil
+	}
+	w.stopped = true
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	id := w.listenerID
+	w.mu.Unlock()
+	return w.m.Off(w.pattern, id)
+}
+
+// observe records a matching event, re-arming the watchdog if it already reported the
+// topic as quiet.
+func (w *Watchdog) observe(Event) error {
+	now := w.m.clock.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.since, w.seen = now, now
+	if w.timer == nil && !w.stopped {
+		w.arm(w.within)
+	}
+	return nil
+}
+
+// arm schedules the next check after d. Callers must hold w.mu.
+func (w *Watchdog) arm(d time.Duration) {
+	w.timer = w.m.afterFunc(d, w.check)
+}
+
+// check reports the topic as quiet if no event arrived since the watch or the last event
+// started the current period. Otherwise it schedules the next check at the end of it.
+func (w *Watchdog) check() {
+	w.mu.Lock()
+	if w.stopped || w.m.closed.Load().(bool) {
+		w.timer = nil
+		w.mu.Unlock()
+		return
+	}
+	if remaining := w.since.Add(w.within).Sub(w.m.clock.Now()); remaining > 0 {
+		w.arm(remaining)
+		w.mu.Unlock()
+		return
+	}
+	w.timer = nil
+	staleness := &Staleness{Pattern: w.pattern, Within: w.within, LastSeen: w.seen}
+	w.mu.Unlock()
+
+	if w.callback != nil {
+		w.callback(staleness)
+		return
+	}
+	w.m.publish(TopicStaleness, staleness)
+}
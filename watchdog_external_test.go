@@ -0,0 +1,84 @@
+package emitter_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kaptinlin/emitter"
+	"github.com/kaptinlin/emitter/emittertest"
+)
+
+func TestExpectActivity(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := emittertest.NewFakeClock(start)
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	var reports []*emitter.Staleness
+	watchdog, err := em.ExpectActivity("heartbeat.*", 10*time.Second, func(s *emitter.Staleness) {
+		reports = append(reports, s)
+	})
+	if err != nil {
+		t.Fatalf("ExpectActivity() failed with error: %v", err)
+	}
+
+	// Heartbeats every five seconds keep the watchdog quiet.
+	for i := 0; i < 4; i++ {
+		clock.Advance(5 * time.Second)
+		em.EmitSync("heartbeat.service-a")
+	}
+	if len(reports) != 0 {
+		t.Fatalf("Got %d reports while heartbeats arrived, want 0", len(reports))
+	}
+
+	// Silence is reported once, however long it lasts.
+	clock.Advance(30 * time.Second)
+	if len(reports) != 1 {
+		t.Fatalf("Got %d reports after silence, want 1", len(reports))
+	}
+	want := emitter.Staleness{Pattern: "heartbeat.*", Within: 10 * time.Second, LastSeen: start.Add(20 * time.Second)}
+	if *reports[0] != want {
+		t.Errorf("Report = %+v, want %+v", *reports[0], want)
+	}
+
+	// Activity re-arms the watchdog.
+	em.EmitSync("heartbeat.service-a")
+	clock.Advance(10 * time.Second)
+	if len(reports) != 2 {
+		t.Errorf("Got %d reports after the second silence, want 2", len(reports))
+	}
+
+	if err := watchdog.Stop(); err != nil {
+		t.Fatalf("Stop() failed with error: %v", err)
+	}
+	em.EmitSync("heartbeat.service-a")
+	clock.Advance(time.Minute)
+	if len(reports) != 2 || clock.PendingTimers() != 0 {
+		t.Errorf("Stopped watchdog reported %d times with %d pending timers, want 2 and 0", len(reports), clock.PendingTimers())
+	}
+}
+
+func TestExpectActivityPublishesStaleness(t *testing.T) {
+	clock := emittertest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	em := emitter.NewMemoryEmitter(emitter.WithClock(clock))
+
+	var stale *emitter.Staleness
+	if _, err := em.On(emitter.TopicStaleness, func(e emitter.Event) error {
+		stale = emitter.MustPayload[*emitter.Staleness](e)
+		return nil
+	}); err != nil {
+		t.Fatalf("On() failed with error: %v", err)
+	}
+	if _, err := em.ExpectActivity("jobs.done", time.Minute, nil); err != nil {
+		t.Fatalf("ExpectActivity() failed with error: %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	if stale == nil || stale.Pattern != "jobs.done" || !stale.LastSeen.IsZero() {
+		t.Errorf("Published staleness = %+v, want jobs.done never seen", stale)
+	}
+
+	if _, err := em.ExpectActivity("jobs.done", 0, nil); !errors.Is(err, emitter.ErrInvalidInterval) {
+		t.Errorf("ExpectActivity() with a zero interval error = %v, want %v", err, emitter.ErrInvalidInterval)
+	}
+}
@@ -0,0 +1,58 @@
+package emitter
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// matchCost accumulates the time spent matching emitted subjects against a wildcard
+// pattern, recorded while the emitter is configured with WithMatchCosts.
+type matchCost struct {
+	evaluations atomic.Uint64
+	nanos       atomic.Int64
+}
+
+// record accounts for one evaluation of the pattern that took d.
+func (c *matchCost) record(d time.Duration) {
+	c.evaluations.Add(1)
+	c.nanos.Add(int64(d))
+}
+
+// WildcardCount returns the number of registered wildcard patterns. Every emission
+// evaluates each of them, so emit latency grows with this count.
+func (m *MemoryEmitter) WildcardCount() int {
+	return len(m.topics.load().wildcards)
+}
+
+// SetWildcardLimit makes On refuse new wildcard patterns once limit of them are
+// registered. A limit of zero or less removes it.
+func (m *MemoryEmitter) SetWildcardLimit(limit int) {
+	m.wildcardLimit = limit
+}
+
+// SetWildcardWarning sets a handler that is called whenever a new wildcard pattern
+// brings their number above count.
+func (m *MemoryEmitter) SetWildcardWarning(count int, handler func(pattern string, count int)) {
+	m.wildcardWarnCount = count
+	m.wildcardWarnHandler = handler
+}
+
+// SetMatchCosts controls whether the emitter times the matching of emitted subjects
+// against each wildcard pattern, reported in TopicStats.
+func (m *MemoryEmitter) SetMatchCosts(enabled bool) {
+	m.topics.setCosts(enabled)
+}
+
+// topicCreated reports a newly registered topic through lifecycle events and the
+// wildcard warning.
+func (m *MemoryEmitter) topicCreated(topicName string) {
+	m.publishLifecycle(TopicTopicCreated, topicName, "")
+
+	if m.wildcardWarnHandler == nil || m.wildcardWarnCount <= 0 {
+		return
+	}
+	snapshot := m.topics.load()
+	if entry, ok := snapshot.entries[topicName]; ok && entry.wildcard && len(snapshot.wildcards) > m.wildcardWarnCount {
+		m.wildcardWarnHandler(topicName, len(snapshot.wildcards))
+	}
+}
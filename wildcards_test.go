@@ -0,0 +1,70 @@
+package emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWildcardLimit(t *testing.T) {
+	var warnings []string
+	e := NewMemoryEmitter(
+		WithWildcardLimit(2),
+		WithWildcardWarning(1, func(pattern string, count int) {
+			warnings = append(warnings, pattern)
+		}),
+	)
+	listener := func(evt Event) error { return nil }
+
+	for _, pattern := range []string{"order.*", "order.created", "user.**", "order.*"} {
+		if _, err := e.On(pattern, listener); err != nil {
+			t.Fatalf("On(%q) error = %v", pattern, err)
+		}
+	}
+	if len(warnings) != 1 || warnings[0] != "user.**" {
+		t.Errorf("warnings = %v, want user.**", warnings)
+	}
+
+	_, err := e.On("audit.**", listener)
+	var subErr *SubscriptionError
+	if !errors.Is(err, ErrWildcardLimitExceeded) || !errors.As(err, &subErr) || subErr.Topic != "audit.**" {
+		t.Errorf("On beyond the limit error = %v, want ErrWildcardLimitExceeded", err)
+	}
+	if _, err := e.On("audit.created", listener); err != nil {
+		t.Errorf("On an exact topic beyond the limit error = %v", err)
+	}
+	if got := e.WildcardCount(); got != 2 {
+		t.Errorf("WildcardCount() = %d, want 2", got)
+	}
+	if got := e.Stats().Wildcards; got != 2 {
+		t.Errorf("Stats().Wildcards = %d, want 2", got)
+	}
+}
+
+func TestMatchCosts(t *testing.T) {
+	e := NewMemoryEmitter(WithMatchCosts())
+	listener := func(evt Event) error { return nil }
+	e.On("order.*", listener)
+	e.On("**.created", listener)
+	e.On("order.created", listener)
+
+	for i := 0; i < 3; i++ {
+		e.EmitSync("order.created")
+	}
+	e.EmitSync("user.deleted")
+
+	stats := e.Stats()
+	for _, pattern := range []string{"order.*", "**.created"} {
+		if got := stats.Topics[pattern]; !got.Wildcard || got.Evaluations != 4 || got.MatchCost <= 0 {
+			t.Errorf("stats of %s = %+v, want 4 timed evaluations", pattern, got)
+		}
+	}
+	if got := stats.Topics["order.created"]; got.Wildcard || got.Evaluations != 0 {
+		t.Errorf("stats of the exact topic = %+v, want no evaluations", got)
+	}
+
+	e.SetMatchCosts(false)
+	e.EmitSync("order.created")
+	if got := e.Stats().Topics["order.*"].Evaluations; got != 4 {
+		t.Errorf("Evaluations after disabling = %d, want 4", got)
+	}
+}